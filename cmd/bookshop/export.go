@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// runExport implements the export command: it streams the catalog of a running bookshop service
+// from the export endpoint to a file, then prints how many bytes were written to stdout.
+// The export is written to a temporary file next to the destination, which is only replaced once
+// the whole export was received, so an interrupted export never leaves a partial file behind.
+// It returns exitExported on success, exitFailed if the export failed, and exitUsage if the command is misused.
+func runExport(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	server := fs.String("server", "http://localhost:8080", "base URL of the bookshop service")
+	format := fs.String("format", "", "format of the file, csv, ndjson or json; guessed from its extension when empty")
+	includeDeleted := fs.Bool("include-deleted", false, "include the books in the trash")
+	includeAudit := fs.Bool("include-audit", false, "include who created and last changed every book")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "usage: bookshop export [flags] FILE")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+	path := fs.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	switch *format {
+	case "csv", "ndjson", "json":
+	case "jsonl":
+		*format = "ndjson"
+	default:
+		_, _ = fmt.Fprintf(stderr, "unsupported format %q, use csv, ndjson or json\n", *format)
+		return exitUsage
+	}
+
+	query := url.Values{
+		"format":          {*format},
+		"include_deleted": {strconv.FormatBool(*includeDeleted)},
+		"include_audit":   {strconv.FormatBool(*includeAudit)},
+	}
+	n, err := getExport(ctx, path, strings.TrimSuffix(*server, "/")+"/v1/books:export?"+query.Encode())
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "export failed:", err)
+		return exitFailed
+	}
+	_, _ = fmt.Fprintf(stdout, "exported %d bytes to %s\n", n, path)
+	return exitExported
+}
+
+// getExport downloads the export at endpoint to the file at path and returns its size.
+func getExport(ctx context.Context, path, endpoint string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, statusError(res)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(tmp, res.Body)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	err = errors.Join(err, tmp.Close())
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return 0, err
+	}
+	return n, nil
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webservice"
+)
+
+// Exit codes of the import, export and openapi commands.
+const (
+	exitImported  = 0
+	exitExported  = 0
+	exitGenerated = 0
+	exitFailed    = 1
+	exitUsage     = 2
+)
+
+// importReport is the part of the bulk import response the import command prints.
+type importReport struct {
+	Rows []struct {
+		Error  *string `json:"error"`
+		Status string  `json:"status"`
+		Line   int     `json:"line"`
+	} `json:"rows"`
+	Created int  `json:"created"`
+	Failed  int  `json:"failed"`
+	DryRun  bool `json:"dry_run"`
+}
+
+// runImport implements the import command: it streams a CSV or NDJSON file to the bulk import endpoint
+// of a running bookshop service, then prints the failed rows and a summary to stdout.
+// It returns exitImported if every row was imported, exitFailed if some rows failed,
+// and exitUsage if the command is misused or the import could not run.
+func runImport(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	server := fs.String("server", "http://localhost:8080", "base URL of the bookshop service")
+	format := fs.String("format", "", "format of the file, csv or ndjson; guessed from its extension when empty")
+	dryRun := fs.Bool("dry-run", false, "validate every row without creating any book")
+	atomic := fs.Bool("atomic", false, "create the books only if every row is valid")
+	actor := fs.String("actor", "", "actor the created books are attributed to in the audit log")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(fs.Output(), "usage: bookshop import [flags] FILE")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitUsage
+	}
+
+	report, err := postImport(ctx, fs.Arg(0), *server, *format, *actor, *dryRun, *atomic)
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "import failed:", err)
+		return exitUsage
+	}
+	for _, r := range report.Rows {
+		if r.Error != nil {
+			_, _ = fmt.Fprintf(stdout, "line %d: %s\n", r.Line, *r.Error)
+		}
+	}
+	switch {
+	case report.DryRun:
+		_, _ = fmt.Fprintf(stdout, "dry run: %d valid, %d failed\n", len(report.Rows)-report.Failed, report.Failed)
+	case *atomic && report.Failed > 0:
+		_, _ = fmt.Fprintf(stdout, "nothing imported: %d failed\n", report.Failed)
+	default:
+		_, _ = fmt.Fprintf(stdout, "%d created, %d failed\n", report.Created, report.Failed)
+	}
+	if report.Failed > 0 {
+		return exitFailed
+	}
+	return exitImported
+}
+
+// postImport streams the file at path to the bulk import endpoint of server and decodes its report.
+func postImport(
+	ctx context.Context,
+	path, server, format, actor string,
+	dryRun, atomic bool,
+) (*importReport, error) {
+	contentType, err := importContentType(path, format)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path) //nolint:gosec // the file is chosen by the user running the command
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	query := url.Values{"dry_run": {strconv.FormatBool(dryRun)}, "atomic": {strconv.FormatBool(atomic)}}
+	endpoint := strings.TrimSuffix(server, "/") + "/v1/books:import?" + query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, f)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if actor != "" {
+		req.Header.Set(webservice.ActorHeader, actor)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusUnprocessableEntity {
+		return nil, statusError(res)
+	}
+	var report importReport
+	if err = json.NewDecoder(res.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("invalid report: %w", err)
+	}
+	return &report, nil
+}
+
+// importContentType returns the media type of an import file in the given format,
+// or in the format told by the extension of path if format is empty.
+func importContentType(path, format string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	switch format {
+	case "csv":
+		return "text/csv", nil
+	case "ndjson", "jsonl":
+		return "application/x-ndjson", nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, use csv or ndjson", format)
+	}
+}
+
+// statusError returns the error of a failed response, described by the detail of its problem details body.
+func statusError(res *http.Response) error {
+	var problem struct {
+		Detail string `json:"detail"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&problem); err != nil || problem.Detail == "" {
+		return fmt.Errorf("unexpected status %s", res.Status)
+	}
+	return fmt.Errorf("%s: %s", res.Status, problem.Detail)
+}
@@ -1,16 +1,29 @@
 // Package main is the application entry point.
 // Wires up all dependency and handle injection, starts the HTTP server, and sets environment config.
+// The import command streams a file of books to the bulk import endpoint of a running server instead,
+// and the export command streams the catalog of a running server to a file.
+// The openapi command prints the OpenAPI document of the HTTP API.
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"time"
 
+	"google.golang.org/grpc"
+
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/config"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/events"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/grpcservice"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/search"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webhook"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webservice"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
@@ -18,6 +31,17 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			os.Exit(runImport(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+		case "export":
+			os.Exit(runExport(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+		case "openapi":
+			os.Exit(runOpenAPI(os.Stdout, os.Stderr))
+		}
+	}
+
 	configFile := os.Getenv("CONFIG_PATH")
 	if configFile == "" {
 		panic("CONFIG_PATH environment variable not set")
@@ -33,13 +57,83 @@ func main() {
 		Level:     slog.LevelInfo,
 	}))
 
-	repo := db.NewInMemoryBookRepo(logger)
-	interact := interactor.NewBookInteractor(logger, repo)
+	repos, err := newRepositories(logger, cfg.Storage)
+	if err != nil {
+		panic("failed to initialize storage: " + err.Error())
+	}
+	index := search.NewInvertedIndex()
+	indexedRepo, err := search.NewIndexedBookRepo(context.Background(), repos.books, index)
+	if err != nil {
+		panic("failed to build search index: " + err.Error())
+	}
+
+	audit, err := newAuditRepository(logger, cfg.Storage)
+	if err != nil {
+		panic("failed to open audit log: " + err.Error())
+	}
+
+	stocks := db.NewInMemoryStockRepo(logger)
+	orders := db.NewInMemoryOrderRepo(logger)
+
+	credits := interactor.NewCreditLock()
+	interact := interactor.NewBookInteractor(logger, indexedRepo, stocks, repos.authors, audit, credits)
+	authorInteract := interactor.NewAuthorInteractor(logger, repos.authors, indexedRepo, audit, credits)
+	inventoryInteract := interactor.NewInventoryInteractor(logger, indexedRepo, stocks)
+	orderInteract := interactor.NewOrderInteractor(logger, indexedRepo, stocks, orders)
+	searchInteract := interactor.NewBookSearchInteractor(logger, indexedRepo, index, repos.authors)
 	bookPresenter := presenter.NewBookPresenter(logger)
 	errPresenter := presenter.NewErrorPresenter(logger)
-	ctl := controller.NewBookController(logger, interact, bookPresenter, errPresenter)
+	resPresenter := presenter.NewResponsePresenter(logger, errPresenter)
+	ctl := controller.NewBookController(logger, interact, bookPresenter, errPresenter, resPresenter)
+	searchCtl := controller.NewBookSearchController(logger, searchInteract, bookPresenter, errPresenter, resPresenter)
+	inventoryCtl := controller.NewInventoryController(
+		logger, inventoryInteract, presenter.NewStockPresenter(logger), errPresenter, resPresenter,
+	)
+
+	orderCtl := controller.NewOrderController(
+		logger, orderInteract, presenter.NewOrderPresenter(logger), errPresenter, resPresenter,
+	)
+	authorCtl := controller.NewAuthorController(
+		logger, authorInteract, presenter.NewAuthorPresenter(logger), errPresenter, resPresenter,
+	)
+
+	webhooksCfg := cfg.Webhooks.WithDefaults()
+	webhookInteract := interactor.NewWebhookInteractor(
+		logger,
+		repos.webhooks,
+		repos.deliveries,
+		webhook.NewHTTPSender(logger, webhooksCfg.Timeout),
+		domain.RetryPolicy{
+			InitialBackoff: webhooksCfg.InitialBackoff,
+			MaxBackoff:     webhooksCfg.MaxBackoff,
+			MaxAttempts:    webhooksCfg.MaxAttempts,
+		},
+	)
+	webhookCtl := controller.NewWebhookController(
+		logger, webhookInteract, presenter.NewWebhookPresenter(logger), errPresenter, resPresenter,
+	)
+
+	go purgeTrash(context.Background(), logger, interact, cfg.Trash.WithDefaults())
+
+	bus := events.NewBus()
+	bus.Subscribe(events.SubscriberFunc(func(_ context.Context, e *domain.Event) error {
+		logger.With("event_id", e.ID, "type", e.Type, "book_id", e.BookID).Info("book event")
+		return nil
+	}))
+	bus.Subscribe(webhookInteract)
+	dispatcher := interactor.NewEventDispatcher(logger, repos.outbox, bus)
+	go dispatchEvents(context.Background(), logger, dispatcher, cfg.Events.WithDefaults())
+	go deliverWebhooks(context.Background(), logger, webhookInteract, webhooksCfg)
 
-	router := webservice.NewHandler(ctl)
+	gql, err := webservice.NewGraphQL(logger, interact, authorInteract, inventoryInteract, errPresenter)
+	if err != nil {
+		panic("failed to build graphql schema: " + err.Error())
+	}
+	router := webservice.NewHandler(ctl, searchCtl, inventoryCtl, orderCtl, authorCtl, webhookCtl, gql)
+
+	if cfg.GRPCServerAddress != "" {
+		go serveGRPC(logger, cfg.GRPCServerAddress, grpcservice.NewServer(logger, grpcservice.NewBookService(interact)))
+	}
 
 	s := &http.Server{
 		Addr:              cfg.ServerAddress,
@@ -53,3 +147,136 @@ func main() {
 
 	log.Fatal(s.ListenAndServe())
 }
+
+// serveGRPC serves the gRPC API on address, exiting if it can't.
+func serveGRPC(logger *slog.Logger, address string, s *grpc.Server) {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		log.Fatal("failed to listen for gRPC: " + err.Error())
+	}
+	logger.Info("Starting bookshop gRPC service on " + address)
+	log.Fatal(s.Serve(lis))
+}
+
+// repositories are the repositories kept in the configured storage.
+type repositories struct {
+	books      domain.BookRepository
+	authors    domain.AuthorRepository
+	outbox     domain.EventOutbox
+	webhooks   domain.WebhookRepository
+	deliveries domain.DeliveryRepository
+}
+
+// newRepositories selects the repository implementations configured by cfg,
+// and the domain.EventOutbox the book writes are recorded in.
+func newRepositories(logger *slog.Logger, cfg config.StorageCfg) (*repositories, error) {
+	switch cfg.Driver {
+	case "", config.StorageDriverMemory:
+		repo := db.NewInMemoryBookRepo(logger)
+		return &repositories{
+			books:      repo,
+			authors:    db.NewInMemoryAuthorRepo(logger),
+			outbox:     repo.Outbox(),
+			webhooks:   db.NewInMemoryWebhookRepo(logger),
+			deliveries: db.NewInMemoryDeliveryRepo(logger),
+		}, nil
+	case config.StorageDriverSQLite:
+		repo, err := db.NewSQLiteBookRepo(logger, cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+		return &repositories{
+			books:      repo,
+			authors:    repo.Authors(),
+			outbox:     repo.Outbox(),
+			webhooks:   repo.Webhooks(),
+			deliveries: repo.Deliveries(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported storage driver %q", cfg.Driver)
+	}
+}
+
+// newAuditRepository selects the domain.AuditRepository implementation configured by cfg.
+func newAuditRepository(logger *slog.Logger, cfg config.StorageCfg) (domain.AuditRepository, error) {
+	if cfg.AuditPath == "" {
+		return db.NewInMemoryAuditRepo(logger), nil
+	}
+	return db.NewFileAuditRepo(logger, cfg.AuditPath)
+}
+
+// purgeTrash periodically removes for good the books that outlived the trash retention, until ctx is done.
+func purgeTrash(ctx context.Context, logger *slog.Logger, interact *interactor.BookInteractor, cfg config.TrashCfg) {
+	ticker := time.NewTicker(cfg.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := interact.PurgeTrash(ctx, cfg.Retention)
+			if err != nil {
+				logger.With("error", err).Error("failed to purge trash")
+			}
+			if n > 0 {
+				logger.With("purged", n).Info("purged trashed books")
+			}
+		}
+	}
+}
+
+// dispatchEvents periodically delivers the events waiting in the outbox, until ctx is done.
+// A full batch is followed by the next one right away, so a backlog drains without waiting for the ticker.
+func dispatchEvents(
+	ctx context.Context,
+	logger *slog.Logger,
+	dispatcher *interactor.EventDispatcher,
+	cfg config.EventsCfg,
+) {
+	ticker := time.NewTicker(cfg.DispatchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := dispatcher.Dispatch(ctx, cfg.BatchSize)
+				if err != nil {
+					logger.With("error", err).Error("failed to dispatch events")
+				}
+				if err != nil || n < cfg.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// deliverWebhooks periodically attempts the webhook deliveries that are due, until ctx is done.
+// Like dispatchEvents, a full batch is followed by the next one right away.
+func deliverWebhooks(
+	ctx context.Context,
+	logger *slog.Logger,
+	interact *interactor.WebhookInteractor,
+	cfg config.WebhooksCfg,
+) {
+	ticker := time.NewTicker(cfg.DeliveryInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := interact.DeliverDue(ctx, cfg.BatchSize)
+				if err != nil {
+					logger.With("error", err).Error("failed to deliver webhooks")
+				}
+				if err != nil || n < cfg.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webservice"
+)
+
+// runOpenAPI implements the openapi command: it prints the OpenAPI document of the HTTP API to stdout,
+// generated from the Go types of its requests and responses.
+// It returns exitFailed if the document cannot be generated, exitGenerated otherwise.
+func runOpenAPI(stdout, stderr io.Writer) int {
+	doc, err := webservice.OpenAPI()
+	if err != nil {
+		_, _ = fmt.Fprintln(stderr, "unable to generate the OpenAPI document:", err)
+		return exitFailed
+	}
+	if _, err := fmt.Fprintln(stdout, string(doc)); err != nil {
+		return exitFailed
+	}
+	return exitGenerated
+}
@@ -3,13 +3,138 @@ package config
 
 import (
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
+// Trash defaults, see TrashCfg.
+const (
+	DefaultTrashRetention     = 30 * 24 * time.Hour
+	DefaultTrashPurgeInterval = time.Hour
+)
+
+// Event dispatch defaults, see EventsCfg.
+const (
+	DefaultEventDispatchInterval = time.Second
+	DefaultEventBatchSize        = 100
+)
+
+// Webhook delivery defaults, see WebhooksCfg.
+const (
+	DefaultWebhookMaxAttempts      = 8
+	DefaultWebhookInitialBackoff   = 10 * time.Second
+	DefaultWebhookMaxBackoff       = time.Hour
+	DefaultWebhookTimeout          = 10 * time.Second
+	DefaultWebhookDeliveryInterval = time.Second
+	DefaultWebhookBatchSize        = 100
+)
+
+// Supported storage drivers.
+const (
+	StorageDriverMemory = "memory"
+	StorageDriverSQLite = "sqlite"
+)
+
 // ServiceCfg represents the service configuration.
 type ServiceCfg struct {
 	ServerAddress string `yaml:"server_address"`
+	// GRPCServerAddress is the address the gRPC API is served on; it is not served when empty.
+	GRPCServerAddress string      `yaml:"grpc_server_address"`
+	Storage           StorageCfg  `yaml:"storage"`
+	Trash             TrashCfg    `yaml:"trash"`
+	Events            EventsCfg   `yaml:"events"`
+	Webhooks          WebhooksCfg `yaml:"webhooks"`
+}
+
+// StorageCfg selects and configures the persistence backend.
+type StorageCfg struct {
+	// Driver is one of StorageDriverMemory or StorageDriverSQLite; defaults to StorageDriverMemory.
+	Driver string `yaml:"driver"`
+	// DSN is the data source name passed to the driver (e.g. a file path for SQLite).
+	DSN string `yaml:"dsn"`
+	// AuditPath is the JSON Lines file the audit log is appended to; the log is kept in memory when empty.
+	AuditPath string `yaml:"audit_path"`
+}
+
+// TrashCfg configures how long deleted books are kept before they are purged for good.
+type TrashCfg struct {
+	// Retention is how long a deleted book can be restored, e.g. "720h".
+	Retention time.Duration `yaml:"retention"`
+	// PurgeInterval is how often books past their retention are purged.
+	PurgeInterval time.Duration `yaml:"purge_interval"`
+}
+
+// WithDefaults returns a copy of c with unset, or negative, durations defaulting to
+// DefaultTrashRetention and DefaultTrashPurgeInterval.
+func (c TrashCfg) WithDefaults() TrashCfg {
+	if c.Retention <= 0 {
+		c.Retention = DefaultTrashRetention
+	}
+	if c.PurgeInterval <= 0 {
+		c.PurgeInterval = DefaultTrashPurgeInterval
+	}
+	return c
+}
+
+// EventsCfg configures how the domain events waiting in the outbox are dispatched.
+type EventsCfg struct {
+	// DispatchInterval is how often the outbox is checked for pending events, e.g. "1s".
+	DispatchInterval time.Duration `yaml:"dispatch_interval"`
+	// BatchSize is the maximum number of events dispatched at each check.
+	BatchSize int `yaml:"batch_size"`
+}
+
+// WithDefaults returns a copy of c with unset, or negative, values defaulting to
+// DefaultEventDispatchInterval and DefaultEventBatchSize.
+func (c EventsCfg) WithDefaults() EventsCfg {
+	if c.DispatchInterval <= 0 {
+		c.DispatchInterval = DefaultEventDispatchInterval
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultEventBatchSize
+	}
+	return c
+}
+
+// WebhooksCfg configures how events are delivered to webhooks, and retried when the receiver fails.
+type WebhooksCfg struct {
+	// InitialBackoff is the wait after the first failed attempt, doubled after each further failure, e.g. "10s".
+	InitialBackoff time.Duration `yaml:"initial_backoff"`
+	// MaxBackoff caps the wait between two attempts, e.g. "1h".
+	MaxBackoff time.Duration `yaml:"max_backoff"`
+	// Timeout is how long the receiver has to answer each attempt.
+	Timeout time.Duration `yaml:"timeout"`
+	// DeliveryInterval is how often due deliveries are attempted.
+	DeliveryInterval time.Duration `yaml:"delivery_interval"`
+	// MaxAttempts is the number of failed attempts after which a delivery is dead-lettered.
+	MaxAttempts int `yaml:"max_attempts"`
+	// BatchSize is the maximum number of deliveries attempted at each check.
+	BatchSize int `yaml:"batch_size"`
+}
+
+// WithDefaults returns a copy of c with unset, or negative, values defaulting to
+// their DefaultWebhook counterparts.
+func (c WebhooksCfg) WithDefaults() WebhooksCfg {
+	if c.InitialBackoff <= 0 {
+		c.InitialBackoff = DefaultWebhookInitialBackoff
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = DefaultWebhookMaxBackoff
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = DefaultWebhookTimeout
+	}
+	if c.DeliveryInterval <= 0 {
+		c.DeliveryInterval = DefaultWebhookDeliveryInterval
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = DefaultWebhookMaxAttempts
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = DefaultWebhookBatchSize
+	}
+	return c
 }
 
 // Load reads a YAML file and returns a ServiceCfg object.
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/config"
 )
@@ -48,7 +49,31 @@ func TestLoadConfig(t *testing.T) {
 			args: args{
 				path: "./testing/sample-config.yaml",
 			},
-			want:    &config.ServiceCfg{ServerAddress: ":8080"},
+			want: &config.ServiceCfg{
+				ServerAddress:     ":8080",
+				GRPCServerAddress: ":9090",
+				Storage: config.StorageCfg{
+					Driver:    config.StorageDriverSQLite,
+					DSN:       "bookshop.db",
+					AuditPath: "audit.jsonl",
+				},
+				Trash: config.TrashCfg{
+					Retention:     720 * time.Hour,
+					PurgeInterval: time.Hour,
+				},
+				Events: config.EventsCfg{
+					DispatchInterval: time.Second,
+					BatchSize:        100,
+				},
+				Webhooks: config.WebhooksCfg{
+					InitialBackoff:   10 * time.Second,
+					MaxBackoff:       time.Hour,
+					Timeout:          10 * time.Second,
+					DeliveryInterval: time.Second,
+					MaxAttempts:      8,
+					BatchSize:        100,
+				},
+			},
 			wantErr: false,
 		},
 	}
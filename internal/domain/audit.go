@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditOperation is the kind of change recorded by an AuditEntry.
+type AuditOperation string
+
+// Supported AuditOperation values.
+const (
+	AuditCreate  AuditOperation = "create"
+	AuditUpdate  AuditOperation = "update"
+	AuditDelete  AuditOperation = "delete"
+	AuditRestore AuditOperation = "restore"
+)
+
+// AnonymousActor is the actor of changes made by an unidentified caller.
+const AnonymousActor = "anonymous"
+
+// Page size bounds for AuditQuery.Limit.
+const (
+	DefaultAuditPageSize = 20
+	MaxAuditPageSize     = 100
+)
+
+// AuditEntry is the immutable record of a single change to a book.
+type AuditEntry struct {
+	At        time.Time
+	Actor     string
+	Operation AuditOperation
+	// Changes lists the book fields changed by the operation, sorted by field name.
+	Changes []FieldChange
+	ID      uuid.UUID
+	BookID  uuid.UUID
+	// Version is the version of the book after the change.
+	Version int
+}
+
+// FieldChange is the text form of a book field before and after a change.
+// Before is empty for created or restored books, After is empty for deleted ones.
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// auditedFields are the book fields recorded by BookChanges, sorted by name.
+var auditedFields = []string{"author", "authors", "currency", "isbn", "language", "price", "title"}
+
+// BookChanges returns the fields that differ between before and after, sorted by field name.
+// A nil book has no fields, so every set field of the other book is returned.
+func BookChanges(before, after *Book) []FieldChange {
+	b, a := auditFields(before), auditFields(after)
+	changes := []FieldChange{}
+	for _, f := range auditedFields {
+		if b[f] != a[f] {
+			changes = append(changes, FieldChange{Field: f, Before: b[f], After: a[f]})
+		}
+	}
+	return changes
+}
+
+// auditFields returns the text form of the auditedFields of book.
+// Credits are listed as author ID and role, names belong to the authors.
+func auditFields(book *Book) map[string]string {
+	if book == nil {
+		return nil
+	}
+	credits := make([]string, len(book.Authors))
+	for i, c := range book.Authors {
+		credits[i] = c.AuthorID.String() + ":" + string(c.Role)
+	}
+	return map[string]string{
+		"author":   book.Author,
+		"authors":  strings.Join(credits, ","),
+		"currency": book.Price.Currency,
+		"isbn":     book.ISBN,
+		"language": book.LanguageTag,
+		"price":    strconv.FormatInt(book.Price.Amount, 10),
+		"title":    book.Title,
+	}
+}
+
+// AuditQuery describes a single page of the history of a book, oldest change first.
+type AuditQuery struct {
+	// Cursor is the opaque position returned as AuditPage.NextCursor by the previous page.
+	// Empty means the first page.
+	Cursor string
+	Limit  int
+	BookID uuid.UUID
+}
+
+// WithDefaults returns a copy of q with Limit clamped between 1 and MaxAuditPageSize,
+// defaulting to DefaultAuditPageSize.
+func (q AuditQuery) WithDefaults() AuditQuery {
+	switch {
+	case q.Limit <= 0:
+		q.Limit = DefaultAuditPageSize
+	case q.Limit > MaxAuditPageSize:
+		q.Limit = MaxAuditPageSize
+	}
+	return q
+}
+
+// AuditPage is a single page of the history of a book.
+type AuditPage struct {
+	// NextCursor points to the following page; empty on the last page.
+	NextCursor string
+	Entries    []*AuditEntry
+	// Total is the number of entries of the book, across all pages.
+	Total int
+}
+
+// AuditSummary sums up the audit log of a book.
+// It is zero for books without history, such as books created before the log existed.
+type AuditSummary struct {
+	// UpdatedAt is when the book was last changed.
+	UpdatedAt time.Time
+	// CreatedBy is the actor of the first change of the book.
+	CreatedBy string
+	// UpdatedBy is the actor of the last change of the book.
+	UpdatedBy string
+	// Changes is the number of entries of the book.
+	Changes int
+}
+
+// AuditRepository defines repository behavior for AuditEntry records.
+// The log is append-only: stored entries are never changed or removed.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+type AuditRepository interface {
+	// Append a new entry to the log, setting its ID.
+	Append(ctx context.Context, entry *AuditEntry) error
+	// ReadPage return the page of entries matching query.
+	ReadPage(ctx context.Context, query AuditQuery) (*AuditPage, error)
+	// Summary return the AuditSummary of the log of a book.
+	Summary(ctx context.Context, bookID uuid.UUID) (*AuditSummary, error)
+}
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx carrying the actor responsible for the changes made with it.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFrom returns the actor carried by ctx, or AnonymousActor if there is none.
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(actorKey{}).(string); ok && actor != "" {
+		return actor
+	}
+	return AnonymousActor
+}
@@ -0,0 +1,90 @@
+package domain_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+func TestBookChanges(t *testing.T) {
+	authorID := uuid.MustParse("6c1e5b2e-2a4f-4a9e-9d0b-3c1f0f0b8a11")
+	book := &domain.Book{
+		Title:       "Dune",
+		Author:      "Frank Herbert",
+		LanguageTag: "en",
+		Authors:     []domain.BookAuthor{{AuthorID: authorID, Role: domain.RoleAuthor, Name: "Frank Herbert"}},
+		Price:       domain.Money{Amount: 999, Currency: "EUR"},
+	}
+	repriced := *book
+	repriced.Price = domain.Money{Amount: 1299, Currency: "USD"}
+	renamed := *book
+	renamed.Authors = []domain.BookAuthor{{AuthorID: authorID, Role: domain.RoleAuthor, Name: "F. Herbert"}}
+
+	tests := []struct {
+		name   string
+		before *domain.Book
+		after  *domain.Book
+		want   []domain.FieldChange
+	}{
+		{
+			name:  "created book lists every set field",
+			after: book,
+			want: []domain.FieldChange{
+				{Field: "author", After: "Frank Herbert"},
+				{Field: "authors", After: authorID.String() + ":author"},
+				{Field: "currency", After: "EUR"},
+				{Field: "language", After: "en"},
+				{Field: "price", After: "999"},
+				{Field: "title", After: "Dune"},
+			},
+		},
+		{
+			name:   "deleted book lists every set field",
+			before: book,
+			want: []domain.FieldChange{
+				{Field: "author", Before: "Frank Herbert"},
+				{Field: "authors", Before: authorID.String() + ":author"},
+				{Field: "currency", Before: "EUR"},
+				{Field: "language", Before: "en"},
+				{Field: "price", Before: "999"},
+				{Field: "title", Before: "Dune"},
+			},
+		},
+		{
+			name:   "updated book lists changed fields only",
+			before: book,
+			after:  &repriced,
+			want: []domain.FieldChange{
+				{Field: "currency", Before: "EUR", After: "USD"},
+				{Field: "price", Before: "999", After: "1299"},
+			},
+		},
+		{
+			name:   "author names are ignored",
+			before: book,
+			after:  &renamed,
+			want:   []domain.FieldChange{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.BookChanges(tt.before, tt.after); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BookChanges() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestActorFrom(t *testing.T) {
+	ctx := context.Background()
+	if got := domain.ActorFrom(ctx); got != domain.AnonymousActor {
+		t.Errorf("ActorFrom() got = %q, want %q", got, domain.AnonymousActor)
+	}
+	if got := domain.ActorFrom(domain.WithActor(ctx, "alice")); got != "alice" {
+		t.Errorf("ActorFrom() got = %q, want %q", got, "alice")
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Author represents a person credited on one or more books.
+type Author struct {
+	CreatedAt time.Time
+	Name      string
+	ID        uuid.UUID
+}
+
+// AuthorRole is the contribution of an Author to a Book.
+type AuthorRole string
+
+// Supported AuthorRole values.
+const (
+	RoleAuthor      AuthorRole = "author"
+	RoleTranslator  AuthorRole = "translator"
+	RoleIllustrator AuthorRole = "illustrator"
+)
+
+// IsValid reports whether r is one of the supported roles.
+func (r AuthorRole) IsValid() bool {
+	switch r {
+	case RoleAuthor, RoleTranslator, RoleIllustrator:
+		return true
+	default:
+		return false
+	}
+}
+
+// BookAuthor credits an Author on a Book with a role.
+type BookAuthor struct {
+	Role AuthorRole
+	// Name is filled in from the Author when the book is read, it is not stored with the book.
+	Name     string
+	AuthorID uuid.UUID
+}
+
+// Byline returns the names credited with RoleAuthor, comma separated.
+// If no credit has RoleAuthor, every name is listed.
+func Byline(credits []BookAuthor) string {
+	names := make([]string, 0, len(credits))
+	for _, c := range credits {
+		if c.Role == RoleAuthor {
+			names = append(names, c.Name)
+		}
+	}
+	if len(names) == 0 {
+		for _, c := range credits {
+			names = append(names, c.Name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// AuthorRepository defines repository behavior for Author entities.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+type AuthorRepository interface {
+	// Create a new author entry.
+	Create(ctx context.Context, author *Author) error
+	// ReadByID return a single author that matches the given ID.
+	ReadByID(ctx context.Context, id uuid.UUID) (*Author, error)
+	// ReadAll return every author, sorted by name.
+	ReadAll(ctx context.Context) ([]*Author, error)
+	// Update the name of an author by ID.
+	Update(ctx context.Context, author *Author) error
+	// Delete a single author, matched by ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
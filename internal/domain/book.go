@@ -3,28 +3,112 @@
 package domain
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 )
 
 // Book represents a book entity in the system.
 type Book struct {
-	Title       string
+	CreatedAt time.Time
+	// DeletedAt is when the book was moved to the trash; zero while the book is in the catalog.
+	DeletedAt time.Time
+	Title     string
+	// Author is the byline shown for the book.
+	// It is derived from Authors when the book is credited to Author entities.
 	Author      string
 	LanguageTag string
-	ID          uuid.UUID
-	Price       int
+	// ISBN is the normalized ISBN-13 of the book, see ParseISBN. It is optional but unique across books.
+	ISBN string
+	// Authors credits the Author entities that contributed to the book, in display order.
+	Authors []BookAuthor
+	Price   Money
+	ID      uuid.UUID
+	// Version is incremented by the repository on every write, starting at 1.
+	// When set on an update, the update only succeeds if it matches the stored version.
+	Version int
+}
+
+// IsDeleted reports whether the book is in the trash.
+func (b *Book) IsDeleted() bool {
+	return !b.DeletedAt.IsZero()
 }
 
 // BookRepository defines repository behavior for Book entities.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+// Deleted books are kept in a trash, hidden from every read but ReadPage of a trash query,
+// until they are restored or purged. A trashed book keeps its ISBN, so it can always be restored.
+// The events passed to a write are written to the outbox of the repository in the same step as the write,
+// and only if it succeeds; the repository sets their ID, BookID, Version, Title and Price from the stored book.
 type BookRepository interface {
 	// Create a new book entry.
-	Create(book *Book) error
+	// If another book has the same ISBN, ErrDuplicateISBN is returned.
+	Create(ctx context.Context, book *Book, events ...*Event) error
+	// CreateAll creates every book, or none of them if any fails.
+	// If a book has the ISBN of a stored book, or of another book of the batch, ErrDuplicateISBN is returned.
+	// Every book is written with its own copy of events.
+	CreateAll(ctx context.Context, books []*Book, events ...*Event) error
 	// ReadByID return a single book that matches the given ID.
-	ReadByID(id uuid.UUID) (*Book, error)
+	ReadByID(ctx context.Context, id uuid.UUID) (*Book, error)
+	// ReadByISBN return the single book with the given normalized ISBN.
+	ReadByISBN(ctx context.Context, isbn string) (*Book, error)
 	// ReadAll return a list of books.
-	ReadAll() ([]*Book, error)
-	// Update a book by ID.
-	Update(book *Book) error
-	// Delete a single book, matched by ID.
-	Delete(id uuid.UUID) error
+	ReadAll(ctx context.Context) ([]*Book, error)
+	// ReadPage return the page of books matching query.
+	// Only trashed books are returned when query.Trashed is set.
+	ReadPage(ctx context.Context, query BookQuery) (*BookPage, error)
+	// Each calls fn with every book, oldest first and then by ID, and stops at the first error fn returns.
+	// Trashed books are included when withTrashed is set.
+	// Books are read a few at a time as fn consumes them, so the catalog is never held in memory at once;
+	// books written while Each runs may or may not be passed to fn.
+	Each(ctx context.Context, withTrashed bool, fn func(*Book) error) error
+	// Update the title, author, credits, language and price of a book by ID. The ISBN can't be changed.
+	// If book.Version is not zero and does not match the stored version, ErrVersionConflict is returned.
+	// On success book.Version is set to the new version.
+	Update(ctx context.Context, book *Book, events ...*Event) error
+	// Delete moves a single book, matched by ID, to the trash.
+	// On success the trashed book is returned, as it was deleted.
+	Delete(ctx context.Context, id uuid.UUID, events ...*Event) (*Book, error)
+	// Restore moves a single trashed book, matched by ID, back to the catalog.
+	// On success the restored book is returned.
+	Restore(ctx context.Context, id uuid.UUID, events ...*Event) (*Book, error)
+	// Purge removes for good every book trashed before deletedBefore and returns their IDs.
+	Purge(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error)
+}
+
+// BookPatch lists the changes to apply to a Book.
+// Nil fields are left untouched; ID and bookkeeping fields can't be patched.
+type BookPatch struct {
+	Title       *string
+	Author      *string
+	LanguageTag *string
+	// Price is the new amount, in minor units of the book currency.
+	Price *int64
+	// Currency is the new ISO 4217 currency; the amount is not converted.
+	Currency *string
+	// Authors replaces every credit of the book.
+	Authors *[]BookAuthor
+}
+
+// Apply writes every set field of p to book.
+func (p *BookPatch) Apply(book *Book) {
+	if p.Title != nil {
+		book.Title = *p.Title
+	}
+	if p.Author != nil {
+		book.Author = *p.Author
+	}
+	if p.LanguageTag != nil {
+		book.LanguageTag = *p.LanguageTag
+	}
+	if p.Price != nil {
+		book.Price.Amount = *p.Price
+	}
+	if p.Currency != nil {
+		book.Price.Currency = *p.Currency
+	}
+	if p.Authors != nil {
+		book.Authors = *p.Authors
+	}
 }
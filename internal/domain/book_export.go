@@ -0,0 +1,16 @@
+package domain
+
+// ExportOptions changes which books and fields a catalog export includes.
+type ExportOptions struct {
+	// WithTrashed includes the books in the trash.
+	WithTrashed bool
+	// WithAudit includes the AuditSummary of every book.
+	WithAudit bool
+}
+
+// ExportedBook is a single book of a catalog export.
+type ExportedBook struct {
+	Book *Book
+	// Audit sums up the audit log of the book; nil unless the export includes audit metadata.
+	Audit *AuditSummary
+}
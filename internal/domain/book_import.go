@@ -0,0 +1,39 @@
+package domain
+
+// MaxImportRows is the maximum number of rows of a single bulk import.
+const MaxImportRows = 10000
+
+// ImportOptions changes how a bulk import writes its books.
+type ImportOptions struct {
+	// DryRun validates every row without creating any book.
+	DryRun bool
+	// Atomic creates the books only if every row is valid, and all of them at once.
+	Atomic bool
+}
+
+// ImportRow is a single row of a bulk import.
+type ImportRow struct {
+	// Book is the book described by the row; nil if the row could not be read.
+	Book *Book
+	// Err explains why the row was rejected, or why its book could not be created; nil on success.
+	Err error
+	// Line is the line the row starts at in the imported document, starting from 1.
+	Line int
+}
+
+// ImportReport is the outcome of a bulk import, row by row.
+type ImportReport struct {
+	// Rows lists every row of the import, in the order they were read.
+	Rows []*ImportRow
+	// Options are the options the import ran with.
+	Options ImportOptions
+	// Created is the number of books created; always zero for dry runs.
+	Created int
+	// Failed is the number of rejected rows.
+	Failed int
+}
+
+// Rejected reports whether the import was atomic and no book was created because some rows failed.
+func (r *ImportReport) Rejected() bool {
+	return r.Options.Atomic && !r.Options.DryRun && r.Failed > 0
+}
@@ -0,0 +1,83 @@
+package domain
+
+import "github.com/google/uuid"
+
+// BookSortField is a field books can be ordered by.
+type BookSortField string
+
+// Supported BookSortField values.
+const (
+	SortByTitle   BookSortField = "title"
+	SortByAuthor  BookSortField = "author"
+	SortByPrice   BookSortField = "price"
+	SortByCreated BookSortField = "created"
+)
+
+// Page size bounds for BookQuery.Limit.
+const (
+	DefaultBookPageSize = 20
+	MaxBookPageSize     = 100
+)
+
+// IsValid reports whether f is one of the supported sort fields.
+func (f BookSortField) IsValid() bool {
+	switch f {
+	case SortByTitle, SortByAuthor, SortByPrice, SortByCreated:
+		return true
+	default:
+		return false
+	}
+}
+
+// BookFilter restricts which books a BookQuery matches.
+// Zero values are ignored.
+type BookFilter struct {
+	// Author matches books by author byline, case-insensitively.
+	Author string
+	// LanguageTag matches books by BCP 47 language tag.
+	LanguageTag string
+	// MinPrice is the inclusive lower bound of the price amount, in minor units.
+	MinPrice int
+	// MaxPrice is the inclusive upper bound of the price amount, in minor units.
+	MaxPrice int
+	// AuthorID matches books crediting the Author, in any role.
+	AuthorID uuid.UUID
+}
+
+// BookQuery describes a single page of a filtered and sorted list of books.
+type BookQuery struct {
+	// SortBy is the field books are ordered by; ties are broken by ID.
+	SortBy BookSortField
+	// Cursor is the opaque position returned as BookPage.NextCursor by the previous page.
+	// Empty means the first page.
+	Cursor     string
+	Filter     BookFilter
+	Limit      int
+	Descending bool
+	// Trashed queries the trash instead of the catalog.
+	Trashed bool
+}
+
+// WithDefaults returns a copy of q with an unset SortBy defaulting to SortByCreated
+// and Limit clamped between 1 and MaxBookPageSize, defaulting to DefaultBookPageSize.
+func (q BookQuery) WithDefaults() BookQuery {
+	if q.SortBy == "" {
+		q.SortBy = SortByCreated
+	}
+	switch {
+	case q.Limit <= 0:
+		q.Limit = DefaultBookPageSize
+	case q.Limit > MaxBookPageSize:
+		q.Limit = MaxBookPageSize
+	}
+	return q
+}
+
+// BookPage is a single page of books matching a BookQuery.
+type BookPage struct {
+	// NextCursor points to the following page; empty on the last page.
+	NextCursor string
+	Books      []*Book
+	// Total is the number of books matching the filter, across all pages.
+	Total int
+}
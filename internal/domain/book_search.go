@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// BookSearchIndex defines full-text search behavior over Book entities.
+type BookSearchIndex interface {
+	// Search returns the IDs of at most limit books matching query, most relevant first.
+	Search(ctx context.Context, query string, limit int) ([]uuid.UUID, error)
+}
@@ -1,10 +1,115 @@
 package domain
 
-import "errors"
+import "strings"
 
 var (
 	// ErrBookNotFound is the domain error when a book is not found.
-	ErrBookNotFound = errors.New("book not found")
+	ErrBookNotFound = newError("BOOK_NOT_FOUND", "book not found")
 	// ErrInvalidBookID is the domain error returned if an invalid UUID is passed.
-	ErrInvalidBookID = errors.New("invalid book id")
+	ErrInvalidBookID = newError("INVALID_BOOK_ID", "invalid book id")
+	// ErrInvalidCursor is the domain error returned if a pagination cursor is malformed
+	// or does not belong to the requested sort order.
+	ErrInvalidCursor = newError("INVALID_CURSOR", "invalid cursor")
+	// ErrVersionConflict is the domain error returned when a book was modified since the version the caller read.
+	ErrVersionConflict = newError("VERSION_CONFLICT", "book version conflict")
+	// ErrInvalidQuantity is the domain error returned if a stock operation is given a non-positive quantity.
+	ErrInvalidQuantity = newError("INVALID_QUANTITY", "invalid stock quantity")
+	// ErrInsufficientStock is the domain error returned when a stock operation would leave
+	// fewer copies than are reserved, or reserve more copies than are available.
+	ErrInsufficientStock = newError("INSUFFICIENT_STOCK", "insufficient stock")
+	// ErrOrderNotFound is the domain error when an order is not found.
+	ErrOrderNotFound = newError("ORDER_NOT_FOUND", "order not found")
+	// ErrInvalidOrderID is the domain error returned if an invalid order UUID is passed.
+	ErrInvalidOrderID = newError("INVALID_ORDER_ID", "invalid order id")
+	// ErrEmptyOrder is the domain error returned when an order is placed without items.
+	ErrEmptyOrder = newError("EMPTY_ORDER", "order has no items")
+	// ErrCurrencyMismatch is the domain error returned when an order mixes prices in different currencies.
+	ErrCurrencyMismatch = newError("CURRENCY_MISMATCH", "order items have different currencies")
+	// ErrIllegalOrderTransition is the domain error returned when an order can't move to the requested status.
+	ErrIllegalOrderTransition = newError("ILLEGAL_ORDER_TRANSITION", "illegal order status transition")
+	// ErrAuthorNotFound is the domain error when an author is not found.
+	ErrAuthorNotFound = newError("AUTHOR_NOT_FOUND", "author not found")
+	// ErrInvalidAuthorID is the domain error returned if an invalid author UUID is passed.
+	ErrInvalidAuthorID = newError("INVALID_AUTHOR_ID", "invalid author id")
+	// ErrAuthorHasBooks is the domain error returned when deleting an author still credited on books.
+	ErrAuthorHasBooks = newError("AUTHOR_HAS_BOOKS", "author still has books")
+	// ErrInvalidISBN is the domain error returned if an ISBN is malformed or its check digit is wrong.
+	ErrInvalidISBN = newError("INVALID_ISBN", "invalid isbn")
+	// ErrDuplicateISBN is the domain error returned when a book is stored with the ISBN of another book.
+	ErrDuplicateISBN = newError("DUPLICATE_ISBN", "isbn already exists")
+	// ErrTooManyImportRows is the domain error returned when a bulk import has more than MaxImportRows rows.
+	ErrTooManyImportRows = newError("TOO_MANY_IMPORT_ROWS", "too many rows to import")
+	// ErrWebhookNotFound is the domain error when a webhook is not found.
+	ErrWebhookNotFound = newError("WEBHOOK_NOT_FOUND", "webhook not found")
+	// ErrInvalidWebhookID is the domain error returned if an invalid webhook UUID is passed.
+	ErrInvalidWebhookID = newError("INVALID_WEBHOOK_ID", "invalid webhook id")
+	// ErrInvalidID is the domain error returned if an invalid ID is passed for a resource type
+	// without a more specific error.
+	ErrInvalidID = newError("INVALID_ID", "invalid id")
+	// ErrWrongResourceType is the domain error returned if the ID of a resource of another type is passed,
+	// e.g. an author ID where a book ID is expected.
+	ErrWrongResourceType = newError("WRONG_RESOURCE_TYPE", "wrong resource type")
+	// ErrValidation is the domain error wrapped by every ValidationError.
+	ErrValidation = newError("VALIDATION_FAILED", "validation failed")
 )
+
+// Error is a domain error. Its Code is stable across releases, so that clients can switch on it
+// rather than on the message.
+type Error struct {
+	Code    string
+	message string
+}
+
+func newError(code, message string) error {
+	return &Error{Code: code, message: message}
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.message
+}
+
+// FieldError is the validation failure of a single field of a request.
+type FieldError struct {
+	// Field is the name of the field, as the request encodes it, e.g. authors[0].role.
+	Field string
+	// Detail explains the failure to humans.
+	Detail string
+}
+
+// ValidationError lists every field of a request that failed validation. It wraps ErrValidation.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// NewFieldError returns a ValidationError of a single field.
+func NewFieldError(field, detail string) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Detail: detail}}}
+}
+
+// Add records the validation failure of field.
+func (e *ValidationError) Add(field, detail string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Detail: detail})
+}
+
+// Err returns e if any field failed validation, nil otherwise.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Error implements error, joining the details of every field.
+func (e *ValidationError) Error() string {
+	details := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		details[i] = f.Detail
+	}
+	return strings.Join(details, "; ")
+}
+
+// Unwrap returns ErrValidation.
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+func TestError_Code(t *testing.T) {
+	errs := []error{
+		domain.ErrBookNotFound, domain.ErrInvalidBookID, domain.ErrInvalidCursor, domain.ErrVersionConflict,
+		domain.ErrInvalidQuantity, domain.ErrInsufficientStock, domain.ErrOrderNotFound, domain.ErrInvalidOrderID,
+		domain.ErrEmptyOrder, domain.ErrCurrencyMismatch, domain.ErrIllegalOrderTransition, domain.ErrAuthorNotFound,
+		domain.ErrInvalidAuthorID, domain.ErrAuthorHasBooks, domain.ErrInvalidISBN, domain.ErrDuplicateISBN,
+		domain.ErrTooManyImportRows, domain.ErrWebhookNotFound, domain.ErrInvalidWebhookID, domain.ErrValidation,
+		domain.ErrInvalidID, domain.ErrWrongResourceType,
+	}
+	seen := map[string]error{}
+	for _, err := range errs {
+		var domainErr *domain.Error
+		if !errors.As(fmt.Errorf("wrapped: %w", err), &domainErr) {
+			t.Fatalf("%q is not a domain.Error", err)
+		}
+		if domainErr.Code == "" {
+			t.Errorf("%q has no code", err)
+		}
+		if other, ok := seen[domainErr.Code]; ok {
+			t.Errorf("%q and %q share code %s", err, other, domainErr.Code)
+		}
+		seen[domainErr.Code] = err
+	}
+}
+
+func TestValidationError(t *testing.T) {
+	v := &domain.ValidationError{}
+	if err := v.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil without failures", err)
+	}
+
+	v.Add("title", "title is required")
+	v.Add("price", "price must be greater than zero")
+	err := v.Err()
+	if err == nil {
+		t.Fatal("Err() = nil, want the failures")
+	}
+	if !errors.Is(err, domain.ErrValidation) {
+		t.Error("ValidationError does not wrap ErrValidation")
+	}
+	if want := "title is required; price must be greater than zero"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	var single *domain.ValidationError
+	if !errors.As(domain.NewFieldError("q", "q is required"), &single) ||
+		len(single.Fields) != 1 || single.Fields[0] != (domain.FieldError{Field: "q", Detail: "q is required"}) {
+		t.Errorf("NewFieldError() = %+v, want a single q field", single)
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is the kind of change announced by an Event.
+type EventType string
+
+// Supported EventType values.
+const (
+	EventBookCreated      EventType = "book.created"
+	EventBookUpdated      EventType = "book.updated"
+	EventBookPriceChanged EventType = "book.price_changed"
+	EventBookDeleted      EventType = "book.deleted"
+	EventBookRestored     EventType = "book.restored"
+)
+
+// IsValid reports whether t is one of the supported event types.
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventBookCreated, EventBookUpdated, EventBookPriceChanged, EventBookDeleted, EventBookRestored:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event announces a change to a book to the rest of the system.
+// Events are raised with the write that causes them and written to the outbox in the same step,
+// then delivered at least once, so subscribers must tolerate duplicates, e.g. by tracking the ID.
+type Event struct {
+	At   time.Time
+	Type EventType
+	// Title is the title of the book after the change.
+	Title string
+	// Price is the price of the book after the change.
+	Price Money
+	// PreviousPrice is the price of the book before an EventBookPriceChanged; zero for other events.
+	PreviousPrice Money
+	ID            uuid.UUID
+	BookID        uuid.UUID
+	// Version is the version of the book after the change.
+	Version int
+}
+
+// NewBookCreated returns an EventBookCreated, raised now.
+func NewBookCreated() *Event {
+	return &Event{At: time.Now().UTC(), Type: EventBookCreated}
+}
+
+// NewBookUpdated returns an EventBookUpdated, raised now.
+func NewBookUpdated() *Event {
+	return &Event{At: time.Now().UTC(), Type: EventBookUpdated}
+}
+
+// NewBookPriceChanged returns an EventBookPriceChanged from the previous price, raised now.
+func NewBookPriceChanged(previous Money) *Event {
+	return &Event{At: time.Now().UTC(), Type: EventBookPriceChanged, PreviousPrice: previous}
+}
+
+// NewBookDeleted returns an EventBookDeleted, raised now.
+func NewBookDeleted() *Event {
+	return &Event{At: time.Now().UTC(), Type: EventBookDeleted}
+}
+
+// NewBookRestored returns an EventBookRestored, raised now.
+func NewBookRestored() *Event {
+	return &Event{At: time.Now().UTC(), Type: EventBookRestored}
+}
+
+// EventOutbox holds the events raised by repository writes until they are delivered.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+type EventOutbox interface {
+	// Pending return up to limit undelivered events, oldest first.
+	Pending(ctx context.Context, limit int) ([]*Event, error)
+	// Ack removes delivered events, matched by ID, from the outbox.
+	Ack(ctx context.Context, ids ...uuid.UUID) error
+}
+
+// EventPublisher delivers events to their subscribers.
+type EventPublisher interface {
+	// Publish delivers event, failing if any subscriber failed to handle it.
+	Publish(ctx context.Context, event *Event) error
+}
+
+// EventSubscriber is notified of the events it subscribed to.
+type EventSubscriber interface {
+	// HandleEvent processes event, the event is delivered again later if it fails.
+	HandleEvent(ctx context.Context, event *Event) error
+}
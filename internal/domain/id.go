@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ResourceType is the type of resource an ID refers to.
+// IDs are exposed prefixed by their resource type, e.g. book:3f0c6a4e-5d1b-4b7a-9a51-6f0e3c2d1b8a.
+type ResourceType string
+
+// Resource types of the IDs exposed by the service.
+const (
+	BookResource     ResourceType = "book"
+	AuthorResource   ResourceType = "author"
+	OrderResource    ResourceType = "order"
+	WebhookResource  ResourceType = "webhook"
+	DeliveryResource ResourceType = "delivery"
+	AuditResource    ResourceType = "audit"
+	EventResource    ResourceType = "event"
+)
+
+// invalidIDErrors are the errors malformed IDs of each resource type are reported with.
+var invalidIDErrors = map[ResourceType]error{
+	BookResource:    ErrInvalidBookID,
+	AuthorResource:  ErrInvalidAuthorID,
+	OrderResource:   ErrInvalidOrderID,
+	WebhookResource: ErrInvalidWebhookID,
+}
+
+// Format returns the ID of the resource of type t identified by id, e.g. book:<uuid>.
+func (t ResourceType) Format(id uuid.UUID) string {
+	return string(t) + ":" + id.String()
+}
+
+// Parse returns the UUID of s, the ID of a resource of type t, either prefixed by t as Format returns it
+// or bare. IDs prefixed by another resource type are rejected with an error wrapping ErrWrongResourceType,
+// malformed IDs with the invalid ID error of t, e.g. ErrInvalidBookID.
+func (t ResourceType) Parse(s string) (uuid.UUID, error) {
+	if prefix, id, ok := strings.Cut(s, ":"); ok {
+		if ResourceType(prefix) != t {
+			return uuid.Nil, fmt.Errorf("%w: expected %s id, got %s id", ErrWrongResourceType, t, prefix)
+		}
+		s = id
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return uuid.Nil, t.invalidIDError()
+	}
+	return id, nil
+}
+
+// MustParse is like Parse but panics if s cannot be parsed.
+// It is meant for IDs that were already validated with Parse.
+func (t ResourceType) MustParse(s string) uuid.UUID {
+	id, err := t.Parse(s)
+	if err != nil {
+		panic(fmt.Sprintf("domain: Parse(%q): %v", s, err))
+	}
+	return id
+}
+
+func (t ResourceType) invalidIDError() error {
+	if err, ok := invalidIDErrors[t]; ok {
+		return err
+	}
+	return ErrInvalidID
+}
@@ -0,0 +1,54 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+func TestResourceType_Parse(t *testing.T) {
+	id := uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42")
+	tests := []struct {
+		wantErr  error
+		name     string
+		resource domain.ResourceType
+		id       string
+		want     uuid.UUID
+	}{
+		{name: "parses prefixed ids", resource: domain.BookResource, id: "book:" + id.String(), want: id},
+		{name: "parses bare ids", resource: domain.AuthorResource, id: id.String(), want: id},
+		{name: "round-trips formatted ids", resource: domain.OrderResource, id: domain.OrderResource.Format(id), want: id},
+		{
+			name: "rejects ids of other resource types", resource: domain.BookResource, id: "author:" + id.String(),
+			wantErr: domain.ErrWrongResourceType,
+		},
+		{
+			name: "rejects unknown resource types", resource: domain.BookResource, id: "urn:uuid:" + id.String(),
+			wantErr: domain.ErrWrongResourceType,
+		},
+		{name: "rejects malformed ids", resource: domain.BookResource, id: "book:42", wantErr: domain.ErrInvalidBookID},
+		{name: "rejects empty ids", resource: domain.WebhookResource, id: "", wantErr: domain.ErrInvalidWebhookID},
+		{name: "rejects malformed ids of other types", resource: domain.AuditResource, id: "x", wantErr: domain.ErrInvalidID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.resource.Parse(tt.id)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Parse() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Parse() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceType_Format(t *testing.T) {
+	id := uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42")
+	if got, want := domain.BookResource.Format(id), "book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42"; got != want {
+		t.Errorf("Format() = %s, want %s", got, want)
+	}
+}
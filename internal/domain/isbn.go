@@ -0,0 +1,76 @@
+package domain
+
+import "strings"
+
+// ParseISBN validates an ISBN-10 or ISBN-13 and returns its normalized ISBN-13 form: 13 digits, no separators.
+// Hyphens and spaces are ignored; the ISBN-10 check digit may be an upper or lower case X.
+// ISBN-10s are converted by prefixing 978 and recomputing the check digit.
+// Returns ErrInvalidISBN if s is malformed or its check digit is wrong.
+func ParseISBN(s string) (string, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, s)
+
+	switch len(digits) {
+	case 10:
+		if !validISBN10(digits) {
+			return "", ErrInvalidISBN
+		}
+		isbn := "978" + digits[:9]
+		return isbn + string(isbn13CheckDigit(isbn)), nil
+	case 13:
+		if !allDigits(digits) || (!strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979")) ||
+			isbn13CheckDigit(digits[:12]) != digits[12] {
+			return "", ErrInvalidISBN
+		}
+		return digits, nil
+	default:
+		return "", ErrInvalidISBN
+	}
+}
+
+// validISBN10 reports whether s is 9 digits followed by a check digit or X,
+// whose weighted sum is a multiple of 11.
+func validISBN10(s string) bool {
+	if !allDigits(s[:9]) {
+		return false
+	}
+	sum := 0
+	for i := range 9 {
+		sum += (10 - i) * int(s[i]-'0')
+	}
+	switch c := s[9]; {
+	case c == 'X' || c == 'x':
+		sum += 10
+	case c >= '0' && c <= '9':
+		sum += int(c - '0')
+	default:
+		return false
+	}
+	return sum%11 == 0
+}
+
+// isbn13CheckDigit returns the check digit of the first 12 digits of an ISBN-13.
+func isbn13CheckDigit(s string) byte {
+	sum := 0
+	for i := range 12 {
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		sum += weight * int(s[i]-'0')
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+func allDigits(s string) bool {
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+func TestParseISBN(t *testing.T) {
+	tests := []struct {
+		name    string
+		isbn    string
+		want    string
+		wantErr bool
+	}{
+		{name: "normalizes ISBN-10", isbn: "0-306-40615-2", want: "9780306406157"},
+		{name: "normalizes ISBN-10 with X check digit", isbn: "0 8044 2957 x", want: "9780804429573"},
+		{name: "keeps ISBN-13", isbn: "978-0-306-40615-7", want: "9780306406157"},
+		{name: "accepts 979 prefix", isbn: "9791034304509", want: "9791034304509"},
+		{name: "fails with wrong ISBN-10 check digit", isbn: "0306406153", wantErr: true},
+		{name: "fails with wrong ISBN-13 check digit", isbn: "9780306406158", wantErr: true},
+		{name: "fails with X inside ISBN-10", isbn: "03064X6152", wantErr: true},
+		{name: "fails with unknown ISBN-13 prefix", isbn: "9770306406155", wantErr: true},
+		{name: "fails with wrong length", isbn: "978030640615", wantErr: true},
+		{name: "fails if empty", isbn: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.ParseISBN(tt.isbn)
+			if tt.wantErr != (err != nil) || (tt.wantErr && !errors.Is(err, domain.ErrInvalidISBN)) {
+				t.Fatalf("ParseISBN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseISBN() got = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
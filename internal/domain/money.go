@@ -0,0 +1,9 @@
+package domain
+
+// Money is an amount of money in the minor units of its currency, e.g. cents for EUR.
+type Money struct {
+	// Currency is the ISO 4217 code of the currency, e.g. EUR.
+	Currency string
+	// Amount is the number of minor units; 1299 EUR is €12.99.
+	Amount int64
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderStatus is the stage of an Order in its lifecycle.
+type OrderStatus string
+
+// Supported OrderStatus values.
+// Orders start pending, and can be cancelled until they are shipped.
+const (
+	OrderPending   OrderStatus = "pending"
+	OrderPaid      OrderStatus = "paid"
+	OrderShipped   OrderStatus = "shipped"
+	OrderCancelled OrderStatus = "cancelled"
+)
+
+// orderTransitions lists the statuses each OrderStatus can move to.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending:   {OrderPaid, OrderCancelled},
+	OrderPaid:      {OrderShipped, OrderCancelled},
+	OrderShipped:   {},
+	OrderCancelled: {},
+}
+
+// OrderItem is a line of an Order.
+// The book title and price are captured when the order is placed,
+// so later catalog changes don't alter existing orders.
+type OrderItem struct {
+	Title     string
+	UnitPrice Money
+	BookID    uuid.UUID
+	Quantity  int
+}
+
+// Subtotal returns the price of all the copies of the item.
+func (i *OrderItem) Subtotal() Money {
+	return Money{Amount: i.UnitPrice.Amount * int64(i.Quantity), Currency: i.UnitPrice.Currency}
+}
+
+// Order represents a customer purchase of one or more books.
+type Order struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Status    OrderStatus
+	Items     []OrderItem
+	Total     Money
+	ID        uuid.UUID
+}
+
+// NewOrder returns a pending order of items, totalled.
+// Every item must have a positive quantity and all prices must share the same currency.
+func NewOrder(items []OrderItem) (*Order, error) {
+	if len(items) == 0 {
+		return nil, ErrEmptyOrder
+	}
+	o := &Order{Status: OrderPending, Items: items, Total: Money{Currency: items[0].UnitPrice.Currency}}
+	for i := range items {
+		switch {
+		case items[i].Quantity <= 0:
+			return nil, ErrInvalidQuantity
+		case items[i].UnitPrice.Currency != o.Total.Currency:
+			return nil, ErrCurrencyMismatch
+		}
+		o.Total.Amount += items[i].Subtotal().Amount
+	}
+	return o, nil
+}
+
+// TransitionTo moves the order to status.
+// It fails with ErrIllegalOrderTransition if the current status can't move to status.
+func (o *Order) TransitionTo(status OrderStatus) error {
+	if err := o.CanTransitionTo(status); err != nil {
+		return err
+	}
+	o.Status = status
+	return nil
+}
+
+// CanTransitionTo reports with ErrIllegalOrderTransition if the current status can't move to status.
+func (o *Order) CanTransitionTo(status OrderStatus) error {
+	if !slices.Contains(orderTransitions[o.Status], status) {
+		return fmt.Errorf("%w: from %s to %s", ErrIllegalOrderTransition, o.Status, status)
+	}
+	return nil
+}
+
+// OrderRepository defines repository behavior for Order entities.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+type OrderRepository interface {
+	// Create a new order entry.
+	Create(ctx context.Context, order *Order) error
+	// ReadByID return a single order that matches the given ID.
+	ReadByID(ctx context.Context, id uuid.UUID) (*Order, error)
+	// ReadAll return every order, oldest first.
+	ReadAll(ctx context.Context) ([]*Order, error)
+	// Update atomically applies change to an order and returns the stored result.
+	// No other update of the same order runs until change returns; if change fails nothing is stored.
+	Update(ctx context.Context, id uuid.UUID, change func(*Order) error) (*Order, error)
+}
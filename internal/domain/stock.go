@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock tracks the physical copies of a book held in the warehouse.
+// Copies on hand are either available or reserved for pending orders,
+// so the available copies can never be negative.
+type Stock struct {
+	UpdatedAt time.Time
+	BookID    uuid.UUID
+	// OnHand is the number of copies in the warehouse.
+	OnHand int
+	// Reserved is the number of copies on hand set aside for pending orders.
+	Reserved int
+}
+
+// Available returns the number of copies that can still be reserved.
+func (s *Stock) Available() int {
+	return s.OnHand - s.Reserved
+}
+
+// Receive adds quantity delivered copies to the stock.
+func (s *Stock) Receive(quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	s.OnHand += quantity
+	return nil
+}
+
+// Adjust corrects the copies on hand by delta, e.g. after a stock count or to write off damaged copies.
+// Reserved copies can't be adjusted away.
+func (s *Stock) Adjust(delta int) error {
+	switch {
+	case delta == 0:
+		return ErrInvalidQuantity
+	case s.OnHand+delta < s.Reserved:
+		return ErrInsufficientStock
+	}
+	s.OnHand += delta
+	return nil
+}
+
+// Reserve sets quantity available copies aside.
+func (s *Stock) Reserve(quantity int) error {
+	switch {
+	case quantity <= 0:
+		return ErrInvalidQuantity
+	case quantity > s.Available():
+		return ErrInsufficientStock
+	}
+	s.Reserved += quantity
+	return nil
+}
+
+// Release returns quantity reserved copies to the available ones, e.g. when an order is cancelled.
+func (s *Stock) Release(quantity int) error {
+	switch {
+	case quantity <= 0:
+		return ErrInvalidQuantity
+	case quantity > s.Reserved:
+		return ErrInsufficientStock
+	}
+	s.Reserved -= quantity
+	return nil
+}
+
+// Fulfil removes quantity reserved copies from the warehouse, e.g. when an order is shipped.
+func (s *Stock) Fulfil(quantity int) error {
+	switch {
+	case quantity <= 0:
+		return ErrInvalidQuantity
+	case quantity > s.Reserved:
+		return ErrInsufficientStock
+	}
+	s.Reserved -= quantity
+	s.OnHand -= quantity
+	return nil
+}
+
+// Unfulfil puts quantity fulfilled copies back in the warehouse as reserved ones, undoing Fulfil.
+func (s *Stock) Unfulfil(quantity int) error {
+	if quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+	s.OnHand += quantity
+	s.Reserved += quantity
+	return nil
+}
+
+// StockRepository defines repository behavior for Stock entities.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+type StockRepository interface {
+	// Create stores stock as the stock of stock.BookID.
+	// It is a no-op if the book already has a stock.
+	Create(ctx context.Context, stock *Stock) error
+	// ReadByBookID return the stock of a book.
+	ReadByBookID(ctx context.Context, bookID uuid.UUID) (*Stock, error)
+	// Update atomically applies change to the stock of a book and returns the stored result.
+	// No other update of the same stock runs until change returns; if change fails nothing is stored.
+	Update(ctx context.Context, bookID uuid.UUID, change func(*Stock) error) (*Stock, error)
+	// Delete the stock of a book.
+	Delete(ctx context.Context, bookID uuid.UUID) error
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinWebhookSecretLength is the minimum length of the secret shared with a webhook receiver.
+const MinWebhookSecretLength = 16
+
+// Webhook subscribes an external URL to the events of the catalog.
+type Webhook struct {
+	CreatedAt time.Time
+	URL       string
+	// Secret is shared with the receiver, which uses it to verify the signature of every delivery.
+	Secret string
+	// Events lists the event types delivered to the webhook; every event is delivered when empty.
+	Events []EventType
+	ID     uuid.UUID
+}
+
+// Accepts reports whether events of type t are delivered to w.
+func (w *Webhook) Accepts(t EventType) bool {
+	return len(w.Events) == 0 || slices.Contains(w.Events, t)
+}
+
+// DeliveryStatus is the stage of a Delivery in its lifecycle.
+type DeliveryStatus string
+
+// Supported DeliveryStatus values.
+// Deliveries start pending, and stay so while they are retried.
+const (
+	DeliveryPending      DeliveryStatus = "pending"
+	DeliverySucceeded    DeliveryStatus = "succeeded"
+	DeliveryDeadLettered DeliveryStatus = "dead_lettered"
+)
+
+// Delivery is the delivery of an Event to a Webhook.
+type Delivery struct {
+	CreatedAt time.Time
+	// NextAttemptAt is when the delivery is due; zero once it succeeded or was dead-lettered.
+	NextAttemptAt time.Time
+	Status        DeliveryStatus
+	// Attempts lists every attempt made so far, oldest first.
+	Attempts  []DeliveryAttempt
+	Event     Event
+	ID        uuid.UUID
+	WebhookID uuid.UUID
+}
+
+// DeliveryAttempt is the outcome of a single attempt to deliver an event.
+type DeliveryAttempt struct {
+	At time.Time
+	// Error explains why the attempt failed; empty if it succeeded.
+	Error string
+	// StatusCode is the HTTP status the receiver answered with; zero if it could not be reached.
+	StatusCode int
+}
+
+// Failed reports whether the attempt failed.
+func (a *DeliveryAttempt) Failed() bool {
+	return a.Error != ""
+}
+
+// RetryPolicy decides when a failed delivery is attempted again.
+type RetryPolicy struct {
+	// InitialBackoff is the wait after the first failed attempt, doubled after each further failure.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the wait between two attempts.
+	MaxBackoff time.Duration
+	// MaxAttempts is the number of failed attempts after which a delivery is dead-lettered.
+	MaxAttempts int
+}
+
+// Backoff returns the wait after the given number of failed attempts.
+func (p RetryPolicy) Backoff(failures int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < failures && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, p.MaxBackoff)
+}
+
+// Record adds attempt to the delivery and moves it along its lifecycle:
+// a successful attempt completes it, a failed one schedules the next attempt following policy,
+// or dead-letters the delivery once policy.MaxAttempts attempts failed.
+func (d *Delivery) Record(attempt DeliveryAttempt, policy RetryPolicy) {
+	d.Attempts = append(d.Attempts, attempt)
+	switch {
+	case !attempt.Failed():
+		d.Status, d.NextAttemptAt = DeliverySucceeded, time.Time{}
+	case len(d.Attempts) >= policy.MaxAttempts:
+		d.Status, d.NextAttemptAt = DeliveryDeadLettered, time.Time{}
+	default:
+		d.Status, d.NextAttemptAt = DeliveryPending, attempt.At.Add(policy.Backoff(len(d.Attempts)))
+	}
+}
+
+// WebhookRepository defines repository behavior for Webhook entities.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+type WebhookRepository interface {
+	// Create a new webhook entry.
+	Create(ctx context.Context, webhook *Webhook) error
+	// ReadByID return a single webhook that matches the given ID.
+	ReadByID(ctx context.Context, id uuid.UUID) (*Webhook, error)
+	// ReadAll return every webhook, oldest first.
+	ReadAll(ctx context.Context) ([]*Webhook, error)
+	// Delete a single webhook, matched by ID.
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// DeliveryRepository defines repository behavior for Delivery entities.
+// Implementations must stop working and return ctx.Err() once ctx is done.
+type DeliveryRepository interface {
+	// Create a new delivery entry.
+	// Events are delivered at least once, so a delivery of an event to a webhook is only stored once:
+	// creating it again stores nothing and sets delivery to the stored one.
+	Create(ctx context.Context, delivery *Delivery) error
+	// ReadDue return up to limit pending deliveries due at now, earliest first.
+	ReadDue(ctx context.Context, now time.Time, limit int) ([]*Delivery, error)
+	// ReadByWebhook return every delivery to a webhook, newest first.
+	ReadByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*Delivery, error)
+	// Update the status, attempts and next attempt of a delivery by ID.
+	Update(ctx context.Context, delivery *Delivery) error
+	// DeleteByWebhook removes every delivery to a webhook.
+	DeleteByWebhook(ctx context.Context, webhookID uuid.UUID) error
+}
+
+// WebhookSender delivers events to the URL of a webhook.
+type WebhookSender interface {
+	// Send makes a single attempt to deliver the event of delivery to webhook and returns its outcome.
+	Send(ctx context.Context, webhook *Webhook, delivery *Delivery) DeliveryAttempt
+}
@@ -0,0 +1,95 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+func TestWebhook_Accepts(t *testing.T) {
+	all := &domain.Webhook{}
+	filtered := &domain.Webhook{Events: []domain.EventType{domain.EventBookCreated, domain.EventBookDeleted}}
+
+	if !all.Accepts(domain.EventBookPriceChanged) {
+		t.Error("a webhook without filter must accept every event")
+	}
+	if !filtered.Accepts(domain.EventBookDeleted) {
+		t.Error("a filtered webhook must accept the listed events")
+	}
+	if filtered.Accepts(domain.EventBookUpdated) {
+		t.Error("a filtered webhook must reject the other events")
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := domain.RetryPolicy{InitialBackoff: 10 * time.Second, MaxBackoff: time.Minute, MaxAttempts: 5}
+	for failures, want := range map[int]time.Duration{
+		1:  10 * time.Second,
+		2:  20 * time.Second,
+		3:  40 * time.Second,
+		4:  time.Minute,
+		64: time.Minute,
+	} {
+		if got := policy.Backoff(failures); got != want {
+			t.Errorf("Backoff(%d) = %v, want %v", failures, got, want)
+		}
+	}
+}
+
+func TestDelivery_Record(t *testing.T) {
+	policy := domain.RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Hour, MaxAttempts: 3}
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	failure := domain.DeliveryAttempt{At: at, Error: "unexpected status 500", StatusCode: 500}
+	success := domain.DeliveryAttempt{At: at, StatusCode: 204}
+
+	tests := []struct {
+		name       string
+		previous   int
+		attempt    domain.DeliveryAttempt
+		wantStatus domain.DeliveryStatus
+		wantNext   time.Time
+	}{
+		{
+			name:       "success completes the delivery",
+			previous:   2,
+			attempt:    success,
+			wantStatus: domain.DeliverySucceeded,
+		},
+		{
+			name:       "first failure is retried after the initial backoff",
+			attempt:    failure,
+			wantStatus: domain.DeliveryPending,
+			wantNext:   at.Add(time.Second),
+		},
+		{
+			name:       "further failures double the backoff",
+			previous:   1,
+			attempt:    failure,
+			wantStatus: domain.DeliveryPending,
+			wantNext:   at.Add(2 * time.Second),
+		},
+		{
+			name:       "last allowed failure dead-letters the delivery",
+			previous:   2,
+			attempt:    failure,
+			wantStatus: domain.DeliveryDeadLettered,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &domain.Delivery{Status: domain.DeliveryPending, NextAttemptAt: at}
+			for range tt.previous {
+				d.Attempts = append(d.Attempts, failure)
+			}
+			d.Record(tt.attempt, policy)
+			if d.Status != tt.wantStatus || !d.NextAttemptAt.Equal(tt.wantNext) {
+				t.Errorf("Record() got status %q due at %v, want %q due at %v",
+					d.Status, d.NextAttemptAt, tt.wantStatus, tt.wantNext)
+			}
+			if len(d.Attempts) != tt.previous+1 || d.Attempts[tt.previous] != tt.attempt {
+				t.Errorf("Record() expected the attempt to be appended, got %+v", d.Attempts)
+			}
+		})
+	}
+}
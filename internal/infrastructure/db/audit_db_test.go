@@ -0,0 +1,159 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInMemoryAuditRepo(t *testing.T) {
+	testAuditRepository(t, db.NewInMemoryAuditRepo(testlog.NewTestLogger()))
+}
+
+func TestFileAuditRepo(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	t.Run("append and read", func(t *testing.T) {
+		repo, err := db.NewFileAuditRepo(logger, filepath.Join(t.TempDir(), "audit.jsonl"))
+		if err != nil {
+			t.Fatalf("error opening repository: %v", err)
+		}
+		t.Cleanup(func() {
+			if err := repo.Close(); err != nil {
+				t.Errorf("error closing repository: %v", err)
+			}
+		})
+		testAuditRepository(t, repo)
+	})
+
+	t.Run("persists across reopen", func(t *testing.T) {
+		ctx := context.Background()
+		path := filepath.Join(t.TempDir(), "audit.jsonl")
+		repo, err := db.NewFileAuditRepo(logger, path)
+		if err != nil {
+			t.Fatalf("error opening repository: %v", err)
+		}
+		entry := &domain.AuditEntry{
+			At:        time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Actor:     "alice",
+			Operation: domain.AuditUpdate,
+			Changes:   []domain.FieldChange{{Field: "price", Before: "10", After: "42"}},
+			BookID:    uuid.New(),
+			Version:   2,
+		}
+		if err = repo.Append(ctx, entry); err != nil {
+			t.Fatalf("error appending entry: %v", err)
+		}
+		if err = repo.Close(); err != nil {
+			t.Fatalf("error closing repository: %v", err)
+		}
+
+		reopened, err := db.NewFileAuditRepo(logger, path)
+		if err != nil {
+			t.Fatalf("error reopening repository: %v", err)
+		}
+		t.Cleanup(func() {
+			if err := reopened.Close(); err != nil {
+				t.Errorf("error closing repository: %v", err)
+			}
+		})
+		page, err := reopened.ReadPage(ctx, domain.AuditQuery{BookID: entry.BookID})
+		if err != nil {
+			t.Fatalf("error reading entries: %v", err)
+		}
+		if len(page.Entries) != 1 || !reflect.DeepEqual(page.Entries[0], entry) {
+			t.Errorf("expected %+v, got %+v", entry, page.Entries)
+		}
+	})
+
+	t.Run("fails on a corrupted log", func(t *testing.T) {
+		corrupted := filepath.Join(t.TempDir(), "audit.jsonl")
+		if err := os.WriteFile(corrupted, []byte("{not json\n"), 0o600); err != nil {
+			t.Fatalf("error writing log: %v", err)
+		}
+		if _, err := db.NewFileAuditRepo(logger, corrupted); err == nil {
+			t.Error("NewFileAuditRepo() expected an error")
+		}
+	})
+}
+
+// testAuditRepository asserts the behavior every domain.AuditRepository implementation must share.
+func testAuditRepository(t *testing.T, repo domain.AuditRepository) {
+	t.Helper()
+	ctx := context.Background()
+	bookID, otherID := uuid.New(), uuid.New()
+
+	var appended []*domain.AuditEntry
+	actors := []string{"alice", domain.AnonymousActor, "bob"}
+	for version := 1; version <= 3; version++ {
+		e := &domain.AuditEntry{
+			At:        time.Now().UTC(),
+			Actor:     actors[version-1],
+			Operation: domain.AuditUpdate,
+			Changes:   []domain.FieldChange{{Field: "title", After: "A Book"}},
+			BookID:    bookID,
+			Version:   version,
+		}
+		if err := repo.Append(ctx, e); err != nil {
+			t.Fatalf("error appending entry: %v", err)
+		}
+		if e.ID == uuid.Nil {
+			t.Fatal("Append() expected the entry ID to be set")
+		}
+		appended = append(appended, e)
+	}
+	if err := repo.Append(ctx, &domain.AuditEntry{BookID: otherID, Changes: []domain.FieldChange{}}); err != nil {
+		t.Fatalf("error appending entry: %v", err)
+	}
+
+	first, err := repo.ReadPage(ctx, domain.AuditQuery{BookID: bookID, Limit: 2})
+	if err != nil {
+		t.Fatalf("error reading first page: %v", err)
+	}
+	if first.Total != 3 || first.NextCursor == "" || !reflect.DeepEqual(first.Entries, appended[:2]) {
+		t.Errorf("expected the 2 oldest of 3 entries with a cursor, got %+v", first)
+	}
+
+	last, err := repo.ReadPage(ctx, domain.AuditQuery{BookID: bookID, Limit: 2, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatalf("error reading last page: %v", err)
+	}
+	if last.NextCursor != "" || !reflect.DeepEqual(last.Entries, appended[2:]) {
+		t.Errorf("expected the newest entry without a cursor, got %+v", last)
+	}
+
+	_, err = repo.ReadPage(ctx, domain.AuditQuery{BookID: otherID, Cursor: first.NextCursor})
+	if !errors.Is(err, domain.ErrInvalidCursor) {
+		t.Errorf("ReadPage() expected invalid cursor error, got: %v", err)
+	}
+
+	empty, err := repo.ReadPage(ctx, domain.AuditQuery{BookID: uuid.New()})
+	if err != nil {
+		t.Fatalf("error reading empty log: %v", err)
+	}
+	if empty.Total != 0 || len(empty.Entries) != 0 || empty.Entries == nil {
+		t.Errorf("expected an empty page, got %+v", empty)
+	}
+
+	summary, err := repo.Summary(ctx, bookID)
+	if err != nil {
+		t.Fatalf("error summing up log: %v", err)
+	}
+	want := &domain.AuditSummary{UpdatedAt: appended[2].At, CreatedBy: "alice", UpdatedBy: "bob", Changes: 3}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("Summary() = %+v, want %+v", summary, want)
+	}
+	summary, err = repo.Summary(ctx, uuid.New())
+	if err != nil || *summary != (domain.AuditSummary{}) {
+		t.Errorf("Summary() expected a zero summary without history, got %+v, %v", summary, err)
+	}
+}
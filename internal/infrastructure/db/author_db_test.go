@@ -0,0 +1,132 @@
+package db_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestNewInMemoryAuthorRepo(t *testing.T) {
+	testAuthorRepository(t, db.NewInMemoryAuthorRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteAuthorRepo(t *testing.T) {
+	testAuthorRepository(t, newTestSQLiteBookRepo(t).Authors())
+}
+
+func TestInMemoryBookRepo_Credits(t *testing.T) {
+	testBookRepositoryCredits(t, db.NewInMemoryBookRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteBookRepo_Credits(t *testing.T) {
+	testBookRepositoryCredits(t, newTestSQLiteBookRepo(t))
+}
+
+// testAuthorRepository asserts the behavior every domain.AuthorRepository implementation must share.
+func testAuthorRepository(t *testing.T, repo domain.AuthorRepository) {
+	t.Helper()
+	ctx := context.Background()
+	tolkien := &domain.Author{Name: "Tolkien"}
+	austen := &domain.Author{Name: "Jane Austen"}
+	for _, a := range []*domain.Author{tolkien, austen} {
+		if err := repo.Create(ctx, a); err != nil {
+			t.Fatalf("error creating author: %v", err)
+		}
+		if a.ID == uuid.Nil || a.CreatedAt.IsZero() {
+			t.Fatalf("Create() did not set ID and creation time: %+v", a)
+		}
+	}
+
+	tolkien.Name = "J.R.R. Tolkien"
+	if err := repo.Update(ctx, tolkien); err != nil {
+		t.Fatalf("error updating author: %v", err)
+	}
+	got, err := repo.ReadByID(ctx, tolkien.ID)
+	if err != nil {
+		t.Fatalf("error reading author: %v", err)
+	}
+	if got.Name != "J.R.R. Tolkien" || !got.CreatedAt.Equal(tolkien.CreatedAt) {
+		t.Errorf("expected %+v, got %+v", tolkien, got)
+	}
+
+	all, err := repo.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("error reading authors: %v", err)
+	}
+	if len(all) != 2 || all[0].Name != "J.R.R. Tolkien" || all[1].Name != "Jane Austen" {
+		t.Errorf("ReadAll() expected authors sorted by name, got %+v", all)
+	}
+
+	if err = repo.Delete(ctx, austen.ID); err != nil {
+		t.Fatalf("error deleting author: %v", err)
+	}
+	if _, err = repo.ReadByID(ctx, austen.ID); !db.IsAuthorNotFoundError(err) {
+		t.Errorf("ReadByID() expected not found error, got: %v", err)
+	}
+	if err = repo.Update(ctx, austen); !db.IsAuthorNotFoundError(err) {
+		t.Errorf("Update() expected not found error, got: %v", err)
+	}
+	if err = repo.Delete(ctx, austen.ID); !db.IsAuthorNotFoundError(err) {
+		t.Errorf("Delete() expected not found error, got: %v", err)
+	}
+}
+
+// testBookRepositoryCredits asserts every domain.BookRepository implementation stores credits without names
+// and filters books by credited author.
+func testBookRepositoryCredits(t *testing.T, repo domain.BookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	writer, translator := uuid.New(), uuid.New()
+	credited := &domain.Book{
+		Title: "Il nome della rosa", Author: "Umberto Eco", LanguageTag: "it",
+		Price: domain.Money{Amount: 20, Currency: "EUR"},
+		Authors: []domain.BookAuthor{
+			{AuthorID: writer, Role: domain.RoleAuthor, Name: "Umberto Eco"},
+			{AuthorID: translator, Role: domain.RoleTranslator, Name: "William Weaver"},
+		},
+	}
+	uncredited := &domain.Book{Title: "Emma", Author: "Jane Austen", Price: domain.Money{Amount: 10, Currency: "EUR"}}
+	for _, b := range []*domain.Book{credited, uncredited} {
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatalf("error creating book: %v", err)
+		}
+	}
+
+	got, err := repo.ReadByID(ctx, credited.ID)
+	if err != nil {
+		t.Fatalf("error reading book: %v", err)
+	}
+	want := []domain.BookAuthor{
+		{AuthorID: writer, Role: domain.RoleAuthor},
+		{AuthorID: translator, Role: domain.RoleTranslator},
+	}
+	if !reflect.DeepEqual(got.Authors, want) {
+		t.Errorf("expected credits %+v, got %+v", want, got.Authors)
+	}
+
+	page, err := repo.ReadPage(ctx, domain.BookQuery{Filter: domain.BookFilter{AuthorID: translator}})
+	if err != nil {
+		t.Fatalf("error reading page: %v", err)
+	}
+	if page.Total != 1 || page.Books[0].ID != credited.ID {
+		t.Errorf("expected only the credited book, got %+v", page.Books)
+	}
+
+	got.Authors = got.Authors[:1]
+	if err = repo.Update(ctx, got); err != nil {
+		t.Fatalf("error updating book: %v", err)
+	}
+	page, err = repo.ReadPage(ctx, domain.BookQuery{Filter: domain.BookFilter{AuthorID: translator}})
+	if err != nil {
+		t.Fatalf("error reading page: %v", err)
+	}
+	if page.Total != 0 {
+		t.Errorf("expected no book crediting the removed translator, got %+v", page.Books)
+	}
+}
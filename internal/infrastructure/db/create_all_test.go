@@ -0,0 +1,72 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInMemoryBookRepo_CreateAll(t *testing.T) {
+	repo := db.NewInMemoryBookRepo(testlog.NewTestLogger())
+	testBookRepositoryCreateAll(t, repo, repo.Outbox())
+}
+
+func TestSQLiteBookRepo_CreateAll(t *testing.T) {
+	repo := newTestSQLiteBookRepo(t)
+	testBookRepositoryCreateAll(t, repo, repo.Outbox())
+}
+
+// testBookRepositoryCreateAll asserts every domain.BookRepository implementation creates a batch of books,
+// each with its own events, or none of them when an ISBN is taken, by a stored book or within the batch.
+func testBookRepositoryCreateAll(t *testing.T, repo domain.BookRepository, outbox domain.EventOutbox) {
+	t.Helper()
+	ctx := context.Background()
+	const taken, free = "9780306406157", "9780262033848"
+	if err := repo.Create(ctx, &domain.Book{Title: "Dune", ISBN: taken}); err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
+
+	for name, batch := range map[string][]*domain.Book{
+		"taken by a stored book": {{Title: "SICP", ISBN: free}, {Title: "Dune again", ISBN: taken}},
+		"taken within the batch": {{Title: "CLRS", ISBN: free}, {Title: "CLRS again", ISBN: free}},
+	} {
+		err := repo.CreateAll(ctx, batch, domain.NewBookCreated())
+		if !errors.Is(err, domain.ErrDuplicateISBN) {
+			t.Fatalf("CreateAll() %s: expected duplicate isbn error, got: %v", name, err)
+		}
+	}
+	if _, err := repo.ReadByISBN(ctx, free); !db.IsNotFoundError(err) {
+		t.Fatalf("CreateAll() expected no book of a failed batch to be stored, got: %v", err)
+	}
+
+	batch := []*domain.Book{{Title: "CLRS", ISBN: free}, {Title: "No ISBN"}}
+	if err := repo.CreateAll(ctx, batch, domain.NewBookCreated()); err != nil {
+		t.Fatalf("error creating batch: %v", err)
+	}
+	all, err := repo.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("error reading books: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected 3 books, got %d", len(all))
+	}
+	for _, b := range batch {
+		got, err := repo.ReadByID(ctx, b.ID)
+		if err != nil || got.Title != b.Title || got.Version != 1 {
+			t.Errorf("expected %+v to be stored, got %+v, %v", b, got, err)
+		}
+	}
+
+	pending, err := outbox.Pending(ctx, 10)
+	if err != nil {
+		t.Fatalf("error reading pending events: %v", err)
+	}
+	if len(pending) != 2 || pending[0].BookID != batch[0].ID || pending[1].BookID != batch[1].ID ||
+		pending[0].ID == pending[1].ID {
+		t.Errorf("expected an event per book of the batch, got %+v", pending)
+	}
+}
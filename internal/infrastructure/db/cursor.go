@@ -0,0 +1,91 @@
+package db
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// bookCursor is the keyset position of the last book of a page.
+// Repositories encode it as an opaque string in domain.BookPage.NextCursor.
+type bookCursor struct {
+	Sort domain.BookSortField `json:"s"`
+	Str  string               `json:"k,omitempty"`
+	ID   uuid.UUID            `json:"id"`
+	Num  int64                `json:"n,omitempty"`
+	Desc bool                 `json:"d,omitempty"`
+}
+
+// newBookCursor returns the cursor positioned on b for the sort order of q.
+func newBookCursor(q *domain.BookQuery, b *domain.Book) bookCursor {
+	c := bookCursor{Sort: q.SortBy, Desc: q.Descending, ID: b.ID}
+	switch q.SortBy {
+	case domain.SortByTitle:
+		c.Str = b.Title
+	case domain.SortByAuthor:
+		c.Str = b.Author
+	case domain.SortByPrice:
+		c.Num = b.Price.Amount
+	case domain.SortByCreated:
+		c.Num = b.CreatedAt.UnixNano()
+	}
+	return c
+}
+
+func (c bookCursor) encode() string {
+	data, _ := json.Marshal(c) //nolint:errchkjson // plain struct, cannot fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeBookCursor parses s, which must have been produced for the same sort order as q.
+func decodeBookCursor(q *domain.BookQuery, s string) (bookCursor, error) {
+	var c bookCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, domain.ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, &c); err != nil {
+		return c, domain.ErrInvalidCursor
+	}
+	if c.Sort != q.SortBy || c.Desc != q.Descending {
+		return c, domain.ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// compare orders c and other by sort key, ties broken by ID, honoring the sort direction.
+func (c bookCursor) compare(other bookCursor) int {
+	r := cmp.Or(strings.Compare(c.Str, other.Str), cmp.Compare(c.Num, other.Num))
+	if r == 0 {
+		r = strings.Compare(c.ID.String(), other.ID.String())
+	}
+	if c.Desc {
+		return -r
+	}
+	return r
+}
+
+// matchesBookFilter reports whether b satisfies every set field of f.
+func matchesBookFilter(f *domain.BookFilter, b *domain.Book) bool {
+	switch {
+	case f.Author != "" && !strings.EqualFold(f.Author, b.Author):
+		return false
+	case f.LanguageTag != "" && !strings.EqualFold(f.LanguageTag, b.LanguageTag):
+		return false
+	case f.MinPrice > 0 && b.Price.Amount < int64(f.MinPrice):
+		return false
+	case f.MaxPrice > 0 && b.Price.Amount > int64(f.MaxPrice):
+		return false
+	case f.AuthorID != uuid.Nil && !slices.ContainsFunc(b.Authors, func(c domain.BookAuthor) bool {
+		return c.AuthorID == f.AuthorID
+	}):
+		return false
+	}
+	return true
+}
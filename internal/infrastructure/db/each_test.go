@@ -0,0 +1,88 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInMemoryBookRepo_Each(t *testing.T) {
+	testBookRepositoryEach(t, db.NewInMemoryBookRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteBookRepo_Each(t *testing.T) {
+	testBookRepositoryEach(t, newTestSQLiteBookRepo(t))
+}
+
+// testBookRepositoryEach asserts every domain.BookRepository implementation passes every book to fn,
+// oldest first, trashed books included on request, and stops at the first error of fn.
+// More books are created than SQLite reads at once, so books are read across batches.
+func testBookRepositoryEach(t *testing.T, repo domain.BookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	var created []uuid.UUID
+	for range 1203 {
+		b := &domain.Book{Title: "A Book", Author: "Someone", Price: domain.Money{Amount: 42, Currency: "EUR"}}
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatalf("error creating book: %v", err)
+		}
+		created = append(created, b.ID)
+	}
+	trashed := created[600]
+	if _, err := repo.Delete(ctx, trashed); err != nil {
+		t.Fatalf("error deleting book: %v", err)
+	}
+
+	each := func(withTrashed bool) []*domain.Book {
+		var books []*domain.Book
+		if err := repo.Each(ctx, withTrashed, func(b *domain.Book) error {
+			books = append(books, b)
+			return nil
+		}); err != nil {
+			t.Fatalf("Each() unexpected error: %v", err)
+		}
+		return books
+	}
+
+	all := each(true)
+	ids := make([]uuid.UUID, len(all))
+	for i, b := range all {
+		ids[i] = b.ID
+	}
+	sorted := slices.IsSortedFunc(all, func(a, b *domain.Book) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return slices.Compare(a.ID[:], b.ID[:])
+	})
+	if !sorted || len(ids) != len(created) || !slices.Contains(ids, trashed) {
+		t.Errorf("Each() expected every book, oldest first, got %d books, sorted: %t", len(ids), sorted)
+	}
+	if catalog := each(false); len(catalog) != len(created)-1 ||
+		slices.ContainsFunc(catalog, (*domain.Book).IsDeleted) {
+		t.Errorf("Each() expected every book but the trashed one, got %d books", len(catalog))
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err := repo.Each(ctx, false, func(*domain.Book) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) || calls != 1 {
+		t.Errorf("Each() expected to stop at the first error, got %v after %d calls", err, calls)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err = repo.Each(cancelled, false, func(*domain.Book) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Each() expected context cancelled error, got: %v", err)
+	}
+}
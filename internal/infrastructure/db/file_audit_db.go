@@ -0,0 +1,140 @@
+package db
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// FileAuditRepo implements domain.AuditRepository on top of an append-only JSON Lines file.
+// The repository persists entries across restarts: the file is read into an InMemoryAuditRepo
+// when opened, which then serves every read.
+type FileAuditRepo struct {
+	file   *os.File
+	mem    *InMemoryAuditRepo
+	logger *slog.Logger
+	mu     sync.Mutex
+}
+
+// fileAuditEntry is the stored form of a domain.AuditEntry, one per line.
+type fileAuditEntry struct {
+	At        time.Time             `json:"at"`
+	Actor     string                `json:"actor"`
+	Operation domain.AuditOperation `json:"operation"`
+	Changes   []fileFieldChange     `json:"changes"`
+	ID        uuid.UUID             `json:"id"`
+	BookID    uuid.UUID             `json:"book_id"`
+	Version   int                   `json:"version"`
+}
+
+type fileFieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// NewFileAuditRepo opens the log at path, creating it if it does not exist, and loads its entries.
+func NewFileAuditRepo(logger *slog.Logger, path string) (*FileAuditRepo, error) {
+	//nolint:gosec // the path comes from the service configuration
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	mem := NewInMemoryAuditRepo(logger)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		var stored fileAuditEntry
+		if err = json.Unmarshal(scanner.Bytes(), &stored); err != nil {
+			return nil, errors.Join(fmt.Errorf("%s:%d: %w", path, line, err), f.Close())
+		}
+		mem.append(stored.entry())
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errors.Join(err, f.Close())
+	}
+	return &FileAuditRepo{file: f, mem: mem, logger: logger}, nil
+}
+
+// Close releases the underlying file.
+func (r *FileAuditRepo) Close() error {
+	return r.file.Close()
+}
+
+// Append a new entry to the log of entry.BookID, setting its ID.
+// The entry is synced to disk before it becomes visible to reads.
+func (r *FileAuditRepo) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	stored := newFileAuditEntry(entry)
+	stored.ID = uuid.New()
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err = r.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	if err = r.file.Sync(); err != nil {
+		return err
+	}
+	entry.ID = stored.ID
+	r.mem.append(*entry)
+	return nil
+}
+
+// ReadPage return the page of entries of query.BookID, oldest first.
+func (r *FileAuditRepo) ReadPage(ctx context.Context, query domain.AuditQuery) (*domain.AuditPage, error) {
+	return r.mem.ReadPage(ctx, query)
+}
+
+// Summary return the AuditSummary of the log of a book.
+func (r *FileAuditRepo) Summary(ctx context.Context, bookID uuid.UUID) (*domain.AuditSummary, error) {
+	return r.mem.Summary(ctx, bookID)
+}
+
+func newFileAuditEntry(e *domain.AuditEntry) fileAuditEntry {
+	changes := make([]fileFieldChange, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = fileFieldChange{Field: c.Field, Before: c.Before, After: c.After}
+	}
+	return fileAuditEntry{
+		At:        e.At,
+		Actor:     e.Actor,
+		Operation: e.Operation,
+		Changes:   changes,
+		ID:        e.ID,
+		BookID:    e.BookID,
+		Version:   e.Version,
+	}
+}
+
+func (e *fileAuditEntry) entry() domain.AuditEntry {
+	changes := make([]domain.FieldChange, len(e.Changes))
+	for i, c := range e.Changes {
+		changes[i] = domain.FieldChange{Field: c.Field, Before: c.Before, After: c.After}
+	}
+	return domain.AuditEntry{
+		At:        e.At,
+		Actor:     e.Actor,
+		Operation: e.Operation,
+		Changes:   changes,
+		ID:        e.ID,
+		BookID:    e.BookID,
+		Version:   e.Version,
+	}
+}
@@ -0,0 +1,67 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInMemoryBookRepo_ISBN(t *testing.T) {
+	testBookRepositoryISBN(t, db.NewInMemoryBookRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteBookRepo_ISBN(t *testing.T) {
+	testBookRepositoryISBN(t, newTestSQLiteBookRepo(t))
+}
+
+// testBookRepositoryISBN asserts every domain.BookRepository implementation keeps ISBNs unique
+// while allowing any number of books without one. Trashed books keep their ISBN until purged.
+func testBookRepositoryISBN(t *testing.T, repo domain.BookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	const isbn = "9780306406157"
+	book := &domain.Book{Title: "Dune", Author: "Frank Herbert", ISBN: isbn}
+	if err := repo.Create(ctx, book); err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
+	for range 2 {
+		if err := repo.Create(ctx, &domain.Book{Title: "No ISBN", Author: "Anonymous"}); err != nil {
+			t.Fatalf("error creating book without isbn: %v", err)
+		}
+	}
+
+	err := repo.Create(ctx, &domain.Book{Title: "Dune again", Author: "Frank Herbert", ISBN: isbn})
+	if !errors.Is(err, domain.ErrDuplicateISBN) {
+		t.Fatalf("Create() expected duplicate isbn error, got: %v", err)
+	}
+
+	got, err := repo.ReadByISBN(ctx, isbn)
+	if err != nil {
+		t.Fatalf("error reading book by isbn: %v", err)
+	}
+	if got.ID != book.ID || got.ISBN != isbn {
+		t.Errorf("expected %+v, got %+v", book, got)
+	}
+
+	if _, err = repo.Delete(ctx, book.ID); err != nil {
+		t.Fatalf("error deleting book: %v", err)
+	}
+	if _, err = repo.ReadByISBN(ctx, isbn); !db.IsNotFoundError(err) {
+		t.Errorf("ReadByISBN() expected not found error, got: %v", err)
+	}
+	err = repo.Create(ctx, &domain.Book{Title: "Dune", Author: "Frank Herbert", ISBN: isbn})
+	if !errors.Is(err, domain.ErrDuplicateISBN) {
+		t.Fatalf("Create() expected the isbn of a trashed book to be taken, got: %v", err)
+	}
+	if _, err = repo.Purge(ctx, time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("error purging trash: %v", err)
+	}
+	if err = repo.Create(ctx, &domain.Book{Title: "Dune", Author: "Frank Herbert", ISBN: isbn}); err != nil {
+		t.Errorf("Create() expected the isbn of a purged book to be free, got: %v", err)
+	}
+}
@@ -0,0 +1,136 @@
+package db
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// InMemoryAuditRepo implements domain.AuditRepository as an in-memory log.
+// The repository is wiped with each restart.
+// Like InMemoryBookRepo, it is sharded by book ID and stores entries by value.
+// The entries of a book are kept in the order they were appended.
+type InMemoryAuditRepo struct {
+	logger *slog.Logger
+	shards [shardCount]auditShard
+}
+
+type auditShard struct {
+	entries map[uuid.UUID][]domain.AuditEntry
+	mu      sync.RWMutex
+}
+
+// NewInMemoryAuditRepo creates a new instance of InMemoryAuditRepo, implementing domain.AuditRepository.
+func NewInMemoryAuditRepo(logger *slog.Logger) *InMemoryAuditRepo {
+	r := &InMemoryAuditRepo{logger: logger}
+	for i := range r.shards {
+		r.shards[i].entries = make(map[uuid.UUID][]domain.AuditEntry)
+	}
+	return r
+}
+
+// Append a new entry to the log of entry.BookID, setting its ID.
+func (r *InMemoryAuditRepo) Append(ctx context.Context, entry *domain.AuditEntry) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	entry.ID = uuid.New()
+	r.append(*entry)
+	return nil
+}
+
+// append stores entry as is.
+func (r *InMemoryAuditRepo) append(entry domain.AuditEntry) {
+	entry.Changes = slices.Clone(entry.Changes)
+	s := &r.shards[shardIndex(entry.BookID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.BookID] = append(s.entries[entry.BookID], entry)
+}
+
+// ReadPage return the page of entries of query.BookID, oldest first.
+func (r *InMemoryAuditRepo) ReadPage(ctx context.Context, query domain.AuditQuery) (*domain.AuditPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	q := query.WithDefaults()
+	start := 0
+	if q.Cursor != "" {
+		c, err := decodeAuditCursor(q.BookID, q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		start = c.Next
+	}
+
+	s := &r.shards[shardIndex(q.BookID)]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := s.entries[q.BookID]
+	page := &domain.AuditPage{Total: len(all), Entries: []*domain.AuditEntry{}}
+	end := min(start+q.Limit, len(all))
+	for i := start; i < end; i++ {
+		e := all[i]
+		e.Changes = slices.Clone(e.Changes)
+		page.Entries = append(page.Entries, &e)
+	}
+	if end < len(all) {
+		page.NextCursor = auditCursor{BookID: q.BookID, Next: end}.encode()
+	}
+	return page, nil
+}
+
+// Summary return the AuditSummary of the log of a book.
+func (r *InMemoryAuditRepo) Summary(ctx context.Context, bookID uuid.UUID) (*domain.AuditSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &r.shards[shardIndex(bookID)]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	all := s.entries[bookID]
+	if len(all) == 0 {
+		return &domain.AuditSummary{}, nil
+	}
+	last := &all[len(all)-1]
+	return &domain.AuditSummary{
+		UpdatedAt: last.At,
+		CreatedBy: all[0].Actor,
+		UpdatedBy: last.Actor,
+		Changes:   len(all),
+	}, nil
+}
+
+// auditCursor is the position of the first entry of the next page in the log of a book.
+type auditCursor struct {
+	BookID uuid.UUID `json:"b"`
+	Next   int       `json:"n"`
+}
+
+func (c auditCursor) encode() string {
+	data, _ := json.Marshal(c) //nolint:errchkjson // plain struct, cannot fail
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeAuditCursor parses s, which must have been produced for the log of bookID.
+func decodeAuditCursor(bookID uuid.UUID, s string) (auditCursor, error) {
+	var c auditCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, domain.ErrInvalidCursor
+	}
+	if err = json.Unmarshal(data, &c); err != nil {
+		return c, domain.ErrInvalidCursor
+	}
+	if c.BookID != bookID || c.Next < 0 {
+		return c, domain.ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// errAuthorNotFound is returned by every repository when an author does not exist.
+var errAuthorNotFound = errors.New("author not found")
+
+// InMemoryAuthorRepo implements domain.AuthorRepository as an in-memory database.
+// The repository is wiped with each restart.
+// Like InMemoryBookRepo, it is sharded by author ID and stores authors by value.
+type InMemoryAuthorRepo struct {
+	logger *slog.Logger
+	shards [shardCount]authorShard
+}
+
+type authorShard struct {
+	authors map[uuid.UUID]domain.Author
+	mu      sync.RWMutex
+}
+
+// NewInMemoryAuthorRepo creates a new instance of InMemoryAuthorRepo, implementing domain.AuthorRepository.
+func NewInMemoryAuthorRepo(logger *slog.Logger) *InMemoryAuthorRepo {
+	r := &InMemoryAuthorRepo{logger: logger}
+	for i := range r.shards {
+		r.shards[i].authors = make(map[uuid.UUID]domain.Author)
+	}
+	return r
+}
+
+// Create a new author entry.
+func (r *InMemoryAuthorRepo) Create(ctx context.Context, author *domain.Author) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	author.ID = uuid.New()
+	author.CreatedAt = time.Now().UTC()
+	s := &r.shards[shardIndex(author.ID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authors[author.ID] = *author
+	return nil
+}
+
+// ReadByID return a single author that matches the given ID.
+func (r *InMemoryAuthorRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Author, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &r.shards[shardIndex(id)]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if a, ok := s.authors[id]; ok {
+		return &a, nil
+	}
+	return nil, errAuthorNotFound
+}
+
+// ReadAll return every author, sorted by name and then by ID.
+// Cancellation is checked between shards.
+func (r *InMemoryAuthorRepo) ReadAll(ctx context.Context) ([]*domain.Author, error) {
+	list := []*domain.Author{}
+	for i := range r.shards {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		s := &r.shards[i]
+		s.mu.RLock()
+		for _, a := range s.authors {
+			author := a
+			list = append(list, &author)
+		}
+		s.mu.RUnlock()
+	}
+	slices.SortFunc(list, func(a, b *domain.Author) int {
+		return cmp.Or(strings.Compare(a.Name, b.Name), strings.Compare(a.ID.String(), b.ID.String()))
+	})
+	return list, nil
+}
+
+// Update the name of an author.
+func (r *InMemoryAuthorRepo) Update(ctx context.Context, author *domain.Author) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s := &r.shards[shardIndex(author.ID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.authors[author.ID]
+	if !ok {
+		return errAuthorNotFound
+	}
+	stored.Name = author.Name
+	s.authors[author.ID] = stored
+	author.CreatedAt = stored.CreatedAt
+	return nil
+}
+
+// Delete a single author, matched by ID.
+func (r *InMemoryAuthorRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s := &r.shards[shardIndex(id)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.authors[id]; !ok {
+		return errAuthorNotFound
+	}
+	delete(s.authors, id)
+	return nil
+}
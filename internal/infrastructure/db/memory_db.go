@@ -3,73 +3,410 @@
 package db
 
 import (
+	"context"
 	"errors"
 	"log/slog"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 )
 
+// shardCount is the number of independently locked partitions of the in-memory repositories.
+// It must be a power of two.
+const shardCount = 32
+
+// errBookNotFound is returned by every repository when a book does not exist.
+var errBookNotFound = errors.New("book not found")
+
 // InMemoryBookRepo implements domain.BookRepository as an in-memory database.
 // The repository is wiped with each restart.
+// It is safe for concurrent use: books are spread across shards, each guarded by its own lock,
+// and are stored and returned by value so callers never share state with the repository.
+// ISBNs are unique across shards, so they are kept in a separate index with its own lock,
+// always taken before the lock of a shard. Trashed books keep their ISBN until they are purged.
+// Events passed to a write are queued in the Outbox while the shard of the book is still locked.
 type InMemoryBookRepo struct {
-	books  map[uuid.UUID]*domain.Book
 	logger *slog.Logger
+	isbns  map[string]uuid.UUID
+	outbox *InMemoryOutbox
+	shards [shardCount]bookShard
+	isbnMu sync.RWMutex
+}
+
+type bookShard struct {
+	books map[uuid.UUID]domain.Book
+	mu    sync.RWMutex
 }
 
 // NewInMemoryBookRepo creates a new instance of InMemoryBookRepo, implementing domain.BookRepository.
 func NewInMemoryBookRepo(logger *slog.Logger) *InMemoryBookRepo {
-	return &InMemoryBookRepo{books: make(map[uuid.UUID]*domain.Book), logger: logger}
+	r := &InMemoryBookRepo{
+		logger: logger,
+		isbns:  make(map[string]uuid.UUID),
+		outbox: &InMemoryOutbox{logger: logger},
+	}
+	for i := range r.shards {
+		r.shards[i].books = make(map[uuid.UUID]domain.Book)
+	}
+	return r
 }
 
 // Create a new book entry.
-func (r *InMemoryBookRepo) Create(book *domain.Book) error {
-	book.ID = uuid.New()
-	r.books[book.ID] = book
+// Books with the ISBN of a stored book are rejected with domain.ErrDuplicateISBN.
+func (r *InMemoryBookRepo) Create(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if book.ISBN != "" {
+		r.isbnMu.Lock()
+		defer r.isbnMu.Unlock()
+		if _, ok := r.isbns[book.ISBN]; ok {
+			return domain.ErrDuplicateISBN
+		}
+	}
+	r.store(book, events)
 	return nil
 }
 
+// CreateAll creates every book, or none of them if any fails.
+// Every ISBN is checked before the first book is stored, while the ISBN index is locked,
+// so nothing can make a later book fail once the first one is stored.
+func (r *InMemoryBookRepo) CreateAll(ctx context.Context, books []*domain.Book, events ...*domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.isbnMu.Lock()
+	defer r.isbnMu.Unlock()
+	batch := make(map[string]bool, len(books))
+	for _, b := range books {
+		if b.ISBN == "" {
+			continue
+		}
+		if _, ok := r.isbns[b.ISBN]; ok || batch[b.ISBN] {
+			return domain.ErrDuplicateISBN
+		}
+		batch[b.ISBN] = true
+	}
+	for _, b := range books {
+		r.store(b, copyEvents(events))
+	}
+	return nil
+}
+
+// store sets the bookkeeping fields of a new book and stores it, with its ISBN, queuing events.
+// The caller must hold the write lock of the ISBN index if the book has an ISBN.
+func (r *InMemoryBookRepo) store(book *domain.Book, events []*domain.Event) {
+	book.ID = uuid.New()
+	book.CreatedAt = time.Now().UTC()
+	book.Version = 1
+	stored := *book
+	stored.Authors = storedCredits(book.Authors)
+	s := r.shard(book.ID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.books[book.ID] = stored
+	if book.ISBN != "" {
+		r.isbns[book.ISBN] = book.ID
+	}
+	r.outbox.append(book, events)
+}
+
 // ReadByID return a single book that matches the given ID.
-func (r *InMemoryBookRepo) ReadByID(id uuid.UUID) (*domain.Book, error) {
-	if b, ok := r.books[id]; ok {
-		return b, nil
+func (r *InMemoryBookRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	return nil, errors.New("book not found")
+	s := r.shard(id)
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if b, ok := s.books[id]; ok && !b.IsDeleted() {
+		b.Authors = slices.Clone(b.Authors)
+		return &b, nil
+	}
+	return nil, errBookNotFound
+}
 
+// ReadByISBN return the single book with the given normalized ISBN.
+func (r *InMemoryBookRepo) ReadByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.isbnMu.RLock()
+	id, ok := r.isbns[isbn]
+	r.isbnMu.RUnlock()
+	if !ok {
+		return nil, errBookNotFound
+	}
+	return r.ReadByID(ctx, id)
 }
 
 // ReadAll return a list of books.
-func (r *InMemoryBookRepo) ReadAll() ([]*domain.Book, error) {
+// Cancellation is checked between shards.
+func (r *InMemoryBookRepo) ReadAll(ctx context.Context) ([]*domain.Book, error) {
+	return r.readAll(ctx, false)
+}
+
+// readAll return the books in the trash, or in the catalog when trashed is false.
+func (r *InMemoryBookRepo) readAll(ctx context.Context, trashed bool) ([]*domain.Book, error) {
 	var list []*domain.Book
-	for _, b := range r.books {
-		list = append(list, b)
+	for i := range r.shards {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		s := &r.shards[i]
+		s.mu.RLock()
+		for _, b := range s.books {
+			if b.IsDeleted() != trashed {
+				continue
+			}
+			book := b
+			book.Authors = slices.Clone(b.Authors)
+			list = append(list, &book)
+		}
+		s.mu.RUnlock()
 	}
 	return list, nil
 }
 
-// Update the price of a book.
-func (r *InMemoryBookRepo) Update(book *domain.Book) error {
-	if _, ok := r.books[book.ID]; !ok {
-		return errors.New("book not found")
+// ReadPage return the page of books matching query.
+// Matching books are sorted on every call, which is fine for the catalog sizes an in-memory store can hold.
+func (r *InMemoryBookRepo) ReadPage(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
+	q := query.WithDefaults()
+	var after *bookCursor
+	if q.Cursor != "" {
+		c, err := decodeBookCursor(&q, q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		after = &c
+	}
+
+	all, err := r.readAll(ctx, q.Trashed)
+	if err != nil {
+		return nil, err
+	}
+	matching := slices.DeleteFunc(all, func(b *domain.Book) bool {
+		return !matchesBookFilter(&q.Filter, b)
+	})
+	slices.SortFunc(matching, func(a, b *domain.Book) int {
+		return newBookCursor(&q, a).compare(newBookCursor(&q, b))
+	})
+
+	page := &domain.BookPage{Total: len(matching), Books: []*domain.Book{}}
+	for _, b := range matching {
+		if after != nil && newBookCursor(&q, b).compare(*after) <= 0 {
+			continue
+		}
+		if len(page.Books) == q.Limit {
+			page.NextCursor = newBookCursor(&q, page.Books[len(page.Books)-1]).encode()
+			break
+		}
+		page.Books = append(page.Books, b)
+	}
+	return page, nil
+}
+
+// Each calls fn with every book, oldest first and then by ID, and stops at the first error fn returns.
+// The position of every book is taken up front, then each book is copied only when it is its turn,
+// so fn never runs while a shard is locked.
+func (r *InMemoryBookRepo) Each(ctx context.Context, withTrashed bool, fn func(*domain.Book) error) error {
+	q := &domain.BookQuery{SortBy: domain.SortByCreated}
+	var keys []bookCursor
+	for i := range r.shards {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s := &r.shards[i]
+		s.mu.RLock()
+		for _, b := range s.books {
+			if withTrashed || !b.IsDeleted() {
+				keys = append(keys, newBookCursor(q, &b))
+			}
+		}
+		s.mu.RUnlock()
+	}
+	slices.SortFunc(keys, bookCursor.compare)
+
+	for _, k := range keys {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		s := r.shard(k.ID)
+		s.mu.RLock()
+		b, ok := s.books[k.ID]
+		b.Authors = slices.Clone(b.Authors)
+		s.mu.RUnlock()
+		// Books purged or trashed in the meantime are skipped.
+		if !ok || (b.IsDeleted() && !withTrashed) {
+			continue
+		}
+		if err := fn(&b); err != nil {
+			return err
+		}
 	}
-	r.books[book.ID].Price = book.Price
 	return nil
 }
 
-// Delete a single book, matched by ID.
-func (r *InMemoryBookRepo) Delete(id uuid.UUID) error {
-	if _, ok := r.books[id]; !ok {
-		return errors.New("book not found")
+// Update the title, author, language and price of a book.
+// The update is rejected with domain.ErrVersionConflict if book.Version is set and stale.
+func (r *InMemoryBookRepo) Update(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s := r.shard(book.ID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.books[book.ID]
+	if !ok || stored.IsDeleted() {
+		return errBookNotFound
+	}
+	if book.Version != 0 && book.Version != stored.Version {
+		return domain.ErrVersionConflict
 	}
-	delete(r.books, id)
+	stored.Title = book.Title
+	stored.Author = book.Author
+	stored.LanguageTag = book.LanguageTag
+	stored.Price = book.Price
+	stored.Authors = storedCredits(book.Authors)
+	stored.Version++
+	s.books[book.ID] = stored
+	book.Version = stored.Version
+	r.outbox.append(&stored, events)
 	return nil
 }
 
+// Delete moves a single book, matched by ID, to the trash.
+func (r *InMemoryBookRepo) Delete(ctx context.Context, id uuid.UUID, events ...*domain.Event) (*domain.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := r.shard(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.books[id]
+	if !ok || b.IsDeleted() {
+		return nil, errBookNotFound
+	}
+	b.DeletedAt = time.Now().UTC()
+	b.Version++
+	s.books[id] = b
+	r.outbox.append(&b, events)
+	b.Authors = slices.Clone(b.Authors)
+	return &b, nil
+}
+
+// Restore moves a single trashed book, matched by ID, back to the catalog.
+func (r *InMemoryBookRepo) Restore(
+	ctx context.Context,
+	id uuid.UUID,
+	events ...*domain.Event,
+) (*domain.Book, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := r.shard(id)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	b, ok := s.books[id]
+	if !ok || !b.IsDeleted() {
+		return nil, errBookNotFound
+	}
+	b.DeletedAt = time.Time{}
+	b.Version++
+	s.books[id] = b
+	r.outbox.append(&b, events)
+	b.Authors = slices.Clone(b.Authors)
+	return &b, nil
+}
+
+// Purge removes for good every book trashed before deletedBefore, releasing their ISBNs.
+// Cancellation is checked between shards, the IDs purged until then are returned with the error.
+func (r *InMemoryBookRepo) Purge(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	r.isbnMu.Lock()
+	defer r.isbnMu.Unlock()
+	var purged []uuid.UUID
+	for i := range r.shards {
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+		s := &r.shards[i]
+		s.mu.Lock()
+		for id, b := range s.books {
+			if !b.IsDeleted() || !b.DeletedAt.Before(deletedBefore) {
+				continue
+			}
+			delete(s.books, id)
+			if b.ISBN != "" {
+				delete(r.isbns, b.ISBN)
+			}
+			purged = append(purged, id)
+		}
+		s.mu.Unlock()
+	}
+	return purged, nil
+}
+
+// storedCredits copies credits for storage.
+// Author names are dropped: they belong to the authors and are filled in when books are read.
+func storedCredits(credits []domain.BookAuthor) []domain.BookAuthor {
+	if len(credits) == 0 {
+		return nil
+	}
+	stored := make([]domain.BookAuthor, len(credits))
+	for i, c := range credits {
+		stored[i] = domain.BookAuthor{AuthorID: c.AuthorID, Role: c.Role}
+	}
+	return stored
+}
+
+// shard returns the partition owning id.
+func (r *InMemoryBookRepo) shard(id uuid.UUID) *bookShard {
+	return &r.shards[shardIndex(id)]
+}
+
+// shardIndex spreads ids across shardCount partitions, using FNV-1a over the UUID bytes.
+func shardIndex(id uuid.UUID) uint32 {
+	h := uint32(2166136261)
+	for _, c := range id {
+		h ^= uint32(c)
+		h *= 16777619
+	}
+	return h & (shardCount - 1)
+}
+
 // IsNotFoundError return true if the error is not nil and is a not found error.
 // This is more useful with real DBs, where errors are a bit more cryptic
 // (e.g. [-106] Row to DELETE not found).
 func IsNotFoundError(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "book not found")
 }
+
+// IsAuthorNotFoundError return true if the error is not nil and is an author not found error.
+func IsAuthorNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "author not found")
+}
+
+// IsStockNotFoundError return true if the error is not nil and is a stock not found error.
+func IsStockNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "stock not found")
+}
+
+// IsOrderNotFoundError return true if the error is not nil and is an order not found error.
+func IsOrderNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "order not found")
+}
+
+// IsWebhookNotFoundError return true if the error is not nil and is a webhook not found error.
+func IsWebhookNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "webhook not found")
+}
+
+// IsDeliveryNotFoundError return true if the error is not nil and is a delivery not found error.
+func IsDeliveryNotFoundError(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "delivery not found")
+}
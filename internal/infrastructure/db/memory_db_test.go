@@ -1,9 +1,14 @@
 package db_test
 
 import (
+	"context"
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
 
+	"github.com/google/uuid"
+
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
@@ -12,64 +17,320 @@ import (
 func TestNewInMemoryBookRepo(t *testing.T) {
 	logger := testlog.NewTestLogger()
 	t.Run("crud operations", func(t *testing.T) {
-		repo := db.NewInMemoryBookRepo(logger)
-		book := &domain.Book{
-			Title:       "A Book",
-			Author:      "An Author",
-			LanguageTag: "en",
-			Price:       10,
-		}
+		testBookRepositoryCRUD(t, db.NewInMemoryBookRepo(logger))
+	})
+	t.Run("versioning", func(t *testing.T) {
+		testBookRepositoryVersioning(t, db.NewInMemoryBookRepo(logger))
+	})
+}
 
-		err := repo.Create(book)
-		if err != nil {
-			t.Fatalf("error creating book: %v", err)
-		}
+// testBookRepositoryCRUD asserts the behavior every domain.BookRepository implementation must share.
+func testBookRepositoryCRUD(t *testing.T, repo domain.BookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	book := &domain.Book{
+		Title:       "A Book",
+		Author:      "An Author",
+		LanguageTag: "en",
+		Price:       domain.Money{Amount: 10, Currency: "EUR"},
+	}
 
-		readResult, err := repo.ReadByID(book.ID)
-		if err != nil {
-			t.Fatalf("error reading book: %v", err)
-		}
-		if !reflect.DeepEqual(book, readResult) {
-			t.Errorf("expected %+v, got %+v", book, readResult)
-		}
+	err := repo.Create(ctx, book)
+	if err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
 
-		updatedBook := &domain.Book{
-			ID:          book.ID,
-			Title:       book.Title,
-			Author:      book.Author,
-			LanguageTag: book.LanguageTag,
-			Price:       42,
-		}
+	readResult, err := repo.ReadByID(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("error reading book: %v", err)
+	}
+	if !reflect.DeepEqual(book, readResult) {
+		t.Errorf("expected %+v, got %+v", book, readResult)
+	}
 
-		err = repo.Update(updatedBook)
-		if err != nil {
-			t.Fatalf("error updating book: %v", err)
-		}
+	updatedBook := &domain.Book{
+		ID:          book.ID,
+		CreatedAt:   book.CreatedAt,
+		Title:       book.Title,
+		Author:      book.Author,
+		LanguageTag: book.LanguageTag,
+		Price:       domain.Money{Amount: 42, Currency: "EUR"},
+	}
 
-		readAllResult, err := repo.ReadAll()
-		if err != nil {
-			t.Fatalf("error reading all books: %v", err)
-		}
-		if !reflect.DeepEqual(updatedBook, readAllResult[0]) {
-			t.Errorf("expected %+v, got %+v", updatedBook, readAllResult[0])
-		}
+	err = repo.Update(ctx, updatedBook)
+	if err != nil {
+		t.Fatalf("error updating book: %v", err)
+	}
 
-		err = repo.Delete(updatedBook.ID)
-		if err != nil {
-			t.Fatalf("error deleting book: %v", err)
-		}
+	readAllResult, err := repo.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("error reading all books: %v", err)
+	}
+	if !reflect.DeepEqual(updatedBook, readAllResult[0]) {
+		t.Errorf("expected %+v, got %+v", updatedBook, readAllResult[0])
+	}
 
-		_, err = repo.ReadByID(updatedBook.ID)
-		if !db.IsNotFoundError(err) {
-			t.Fatalf("ReadByID() expected not found error, got: %v", err)
-		}
-		err = repo.Update(updatedBook)
-		if !db.IsNotFoundError(err) {
-			t.Fatalf("Update() expected not found error, got: %v", err)
-		}
-		err = repo.Delete(updatedBook.ID)
-		if !db.IsNotFoundError(err) {
-			t.Fatalf("Delete() expected not found error, got: %v", err)
+	_, err = repo.Delete(ctx, updatedBook.ID)
+	if err != nil {
+		t.Fatalf("error deleting book: %v", err)
+	}
+
+	_, err = repo.ReadByID(ctx, updatedBook.ID)
+	if !db.IsNotFoundError(err) {
+		t.Fatalf("ReadByID() expected not found error, got: %v", err)
+	}
+	err = repo.Update(ctx, updatedBook)
+	if !db.IsNotFoundError(err) {
+		t.Fatalf("Update() expected not found error, got: %v", err)
+	}
+	_, err = repo.Delete(ctx, updatedBook.ID)
+	if !db.IsNotFoundError(err) {
+		t.Fatalf("Delete() expected not found error, got: %v", err)
+	}
+}
+
+// testBookRepositoryVersioning asserts the optimistic concurrency
+// every domain.BookRepository implementation must share.
+func testBookRepositoryVersioning(t *testing.T, repo domain.BookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	book := &domain.Book{
+		Title:       "A Book",
+		Author:      "An Author",
+		LanguageTag: "en",
+		Price:       domain.Money{Amount: 10, Currency: "EUR"},
+	}
+	if err := repo.Create(ctx, book); err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
+	if book.Version != 1 {
+		t.Fatalf("Create() expected version 1, got %d", book.Version)
+	}
+
+	first := &domain.Book{ID: book.ID, Price: domain.Money{Amount: 20, Currency: "EUR"}, Version: 1}
+	if err := repo.Update(ctx, first); err != nil {
+		t.Fatalf("error updating book: %v", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("Update() expected version 2, got %d", first.Version)
+	}
+
+	stale := &domain.Book{ID: book.ID, Price: domain.Money{Amount: 30, Currency: "EUR"}, Version: 1}
+	if err := repo.Update(ctx, stale); !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Update() expected version conflict error, got: %v", err)
+	}
+
+	unconditional := &domain.Book{ID: book.ID, Price: domain.Money{Amount: 40, Currency: "EUR"}}
+	if err := repo.Update(ctx, unconditional); err != nil {
+		t.Fatalf("error updating book: %v", err)
+	}
+
+	got, err := repo.ReadByID(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("error reading book: %v", err)
+	}
+	if got.Price.Amount != 40 || got.Version != 3 {
+		t.Errorf("expected price 40 at version 3, got price %d at version %d", got.Price.Amount, got.Version)
+	}
+
+	missing := &domain.Book{ID: uuid.New(), Price: domain.Money{Amount: 10, Currency: "EUR"}, Version: 1}
+	if err = repo.Update(ctx, missing); !db.IsNotFoundError(err) {
+		t.Errorf("Update() expected not found error, got: %v", err)
+	}
+}
+
+func TestInMemoryBookRepo_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewInMemoryBookRepo(testlog.NewTestLogger())
+	book := &domain.Book{
+		Title:       "A Book",
+		Author:      "An Author",
+		LanguageTag: "en",
+		Price:       domain.Money{Amount: 10, Currency: "EUR"},
+	}
+	if err := repo.Create(ctx, book); err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
+
+	book.Price.Amount = 1
+	got, err := repo.ReadByID(ctx, book.ID)
+	if err != nil {
+		t.Fatalf("error reading book: %v", err)
+	}
+	if got.Price.Amount != 10 {
+		t.Errorf("mutating the created book changed the stored price to %d", got.Price.Amount)
+	}
+
+	got.Title = "Changed"
+	all, err := repo.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("error reading all books: %v", err)
+	}
+	if all[0].Title != "A Book" {
+		t.Errorf("mutating a read book changed the stored title to %q", all[0].Title)
+	}
+}
+
+func TestInMemoryBookRepo_ConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewInMemoryBookRepo(testlog.NewTestLogger())
+	const workers, ops = 8, 200
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range ops {
+				book := &domain.Book{Title: "A Book", Price: domain.Money{Amount: int64(i + 1), Currency: "EUR"}}
+				if err := repo.Create(ctx, book); err != nil {
+					t.Errorf("error creating book: %v", err)
+					return
+				}
+				book.Price.Amount++
+				if err := repo.Update(ctx, book); err != nil {
+					t.Errorf("error updating book: %v", err)
+				}
+				if _, err := repo.ReadByID(ctx, book.ID); err != nil {
+					t.Errorf("error reading book: %v", err)
+				}
+				if _, err := repo.ReadAll(ctx); err != nil {
+					t.Errorf("error reading all books: %v", err)
+				}
+				if i%2 == 0 {
+					if _, err := repo.Delete(ctx, book.ID); err != nil {
+						t.Errorf("error deleting book: %v", err)
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	all, err := repo.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("error reading all books: %v", err)
+	}
+	if want := workers * ops / 2; len(all) != want {
+		t.Errorf("expected %d books, got %d", want, len(all))
+	}
+}
+
+func TestInMemoryBookRepo_CancelledContext(t *testing.T) {
+	repo := db.NewInMemoryBookRepo(testlog.NewTestLogger())
+	book := &domain.Book{Title: "A Book", Price: domain.Money{Amount: 10, Currency: "EUR"}}
+	if err := repo.Create(context.Background(), book); err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := repo.Create(ctx, &domain.Book{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Create() expected context canceled error, got: %v", err)
+	}
+	if _, err := repo.ReadByID(ctx, book.ID); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadByID() expected context canceled error, got: %v", err)
+	}
+	if _, err := repo.ReadAll(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadAll() expected context canceled error, got: %v", err)
+	}
+	if err := repo.Update(ctx, book); !errors.Is(err, context.Canceled) {
+		t.Errorf("Update() expected context canceled error, got: %v", err)
+	}
+	if _, err := repo.Delete(ctx, book.ID); !errors.Is(err, context.Canceled) {
+		t.Errorf("Delete() expected context canceled error, got: %v", err)
+	}
+}
+
+// mutexBookRepo is the previous single-map design, guarded by one lock so it can run concurrently.
+// It is the baseline the sharded InMemoryBookRepo is benchmarked against.
+type mutexBookRepo struct {
+	books map[uuid.UUID]*domain.Book
+	mu    sync.RWMutex
+}
+
+func (r *mutexBookRepo) Create(_ context.Context, book *domain.Book, _ ...*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	book.ID = uuid.New()
+	r.books[book.ID] = book
+	return nil
+}
+
+func (r *mutexBookRepo) ReadByID(_ context.Context, id uuid.UUID) (*domain.Book, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if b, ok := r.books[id]; ok {
+		return b, nil
+	}
+	return nil, errors.New("book not found")
+}
+
+func (r *mutexBookRepo) Update(_ context.Context, book *domain.Book, _ ...*domain.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.books[book.ID]; !ok {
+		return errors.New("book not found")
+	}
+	r.books[book.ID].Price = book.Price
+	return nil
+}
+
+// benchBookRepo is the subset of domain.BookRepository exercised by BenchmarkBookRepo.
+type benchBookRepo interface {
+	Create(ctx context.Context, book *domain.Book, events ...*domain.Event) error
+	ReadByID(ctx context.Context, id uuid.UUID) (*domain.Book, error)
+	Update(ctx context.Context, book *domain.Book, events ...*domain.Event) error
+}
+
+func BenchmarkBookRepo(b *testing.B) {
+	ctx := context.Background()
+	repos := []struct {
+		name string
+		new  func() benchBookRepo
+	}{
+		{name: "mutex", new: func() benchBookRepo {
+			return &mutexBookRepo{books: make(map[uuid.UUID]*domain.Book)}
+		}},
+		{name: "sharded", new: func() benchBookRepo {
+			return db.NewInMemoryBookRepo(testlog.NewTestLogger())
+		}},
+	}
+	workloads := []struct {
+		name       string
+		writeEvery int
+	}{
+		{name: "read-heavy", writeEvery: 10},
+		{name: "write-heavy", writeEvery: 1},
+	}
+
+	for _, wl := range workloads {
+		for _, rp := range repos {
+			b.Run(wl.name+"/"+rp.name, func(b *testing.B) {
+				repo := rp.new()
+				ids := make([]uuid.UUID, 1024)
+				for i := range ids {
+					book := &domain.Book{Title: "A Book", Price: domain.Money{Amount: 1, Currency: "EUR"}}
+					if err := repo.Create(ctx, book); err != nil {
+						b.Fatal(err)
+					}
+					ids[i] = book.ID
+				}
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						id := ids[i%len(ids)]
+						if i%wl.writeEvery == 0 {
+							_ = repo.Update(ctx, &domain.Book{ID: id, Price: domain.Money{Amount: int64(i), Currency: "EUR"}})
+						} else {
+							_, _ = repo.ReadByID(ctx, id)
+						}
+						i++
+					}
+				})
+			})
 		}
-	})
+	}
 }
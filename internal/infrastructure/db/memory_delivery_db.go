@@ -0,0 +1,145 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// errDeliveryNotFound is returned by every repository when a delivery does not exist.
+var errDeliveryNotFound = errors.New("delivery not found")
+
+// InMemoryDeliveryRepo implements domain.DeliveryRepository as an in-memory database.
+// The repository is wiped with each restart.
+// Due deliveries are looked up across every webhook, so deliveries are kept under a single lock
+// rather than sharded; they are stored and returned by value, attempts included.
+type InMemoryDeliveryRepo struct {
+	logger     *slog.Logger
+	deliveries map[uuid.UUID]domain.Delivery
+	// byEvent indexes the deliveries by webhook and event, so an event is queued once per webhook.
+	byEvent map[deliveryKey]uuid.UUID
+	mu      sync.RWMutex
+}
+
+type deliveryKey struct {
+	webhookID uuid.UUID
+	eventID   uuid.UUID
+}
+
+// NewInMemoryDeliveryRepo creates a new instance of InMemoryDeliveryRepo, implementing domain.DeliveryRepository.
+func NewInMemoryDeliveryRepo(logger *slog.Logger) *InMemoryDeliveryRepo {
+	return &InMemoryDeliveryRepo{
+		logger:     logger,
+		deliveries: make(map[uuid.UUID]domain.Delivery),
+		byEvent:    make(map[deliveryKey]uuid.UUID),
+	}
+}
+
+// Create a new delivery entry, unless the event was already queued for the webhook.
+func (r *InMemoryDeliveryRepo) Create(ctx context.Context, delivery *domain.Delivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	key := deliveryKey{webhookID: delivery.WebhookID, eventID: delivery.Event.ID}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id, ok := r.byEvent[key]; ok {
+		stored := r.deliveries[id]
+		*delivery = copyDelivery(&stored)
+		return nil
+	}
+	delivery.ID = uuid.New()
+	delivery.CreatedAt = time.Now().UTC()
+	r.deliveries[delivery.ID] = copyDelivery(delivery)
+	r.byEvent[key] = delivery.ID
+	return nil
+}
+
+// ReadDue return up to limit pending deliveries due at now, earliest first and then by ID.
+func (r *InMemoryDeliveryRepo) ReadDue(ctx context.Context, now time.Time, limit int) ([]*domain.Delivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	due := []*domain.Delivery{}
+	for _, d := range r.deliveries {
+		if d.Status == domain.DeliveryPending && !d.NextAttemptAt.After(now) {
+			delivery := copyDelivery(&d)
+			due = append(due, &delivery)
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(due, func(a, b *domain.Delivery) int {
+		return cmp.Or(a.NextAttemptAt.Compare(b.NextAttemptAt), strings.Compare(a.ID.String(), b.ID.String()))
+	})
+	return due[:min(limit, len(due))], nil
+}
+
+// ReadByWebhook return every delivery to a webhook, newest first and then by ID.
+func (r *InMemoryDeliveryRepo) ReadByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*domain.Delivery, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	list := []*domain.Delivery{}
+	for _, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			delivery := copyDelivery(&d)
+			list = append(list, &delivery)
+		}
+	}
+	r.mu.RUnlock()
+	slices.SortFunc(list, func(a, b *domain.Delivery) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), strings.Compare(a.ID.String(), b.ID.String()))
+	})
+	return list, nil
+}
+
+// Update the status, attempts and next attempt of a delivery.
+func (r *InMemoryDeliveryRepo) Update(ctx context.Context, delivery *domain.Delivery) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.deliveries[delivery.ID]
+	if !ok {
+		return errDeliveryNotFound
+	}
+	stored.Status = delivery.Status
+	stored.NextAttemptAt = delivery.NextAttemptAt
+	stored.Attempts = slices.Clone(delivery.Attempts)
+	r.deliveries[delivery.ID] = stored
+	return nil
+}
+
+// DeleteByWebhook removes every delivery to a webhook.
+func (r *InMemoryDeliveryRepo) DeleteByWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, d := range r.deliveries {
+		if d.WebhookID == webhookID {
+			delete(r.deliveries, id)
+			delete(r.byEvent, deliveryKey{webhookID: webhookID, eventID: d.Event.ID})
+		}
+	}
+	return nil
+}
+
+func copyDelivery(d *domain.Delivery) domain.Delivery {
+	c := *d
+	c.Attempts = slices.Clone(d.Attempts)
+	return c
+}
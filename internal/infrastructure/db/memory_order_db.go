@@ -0,0 +1,128 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// errOrderNotFound is returned by every repository when an order does not exist.
+var errOrderNotFound = errors.New("order not found")
+
+// InMemoryOrderRepo implements domain.OrderRepository as an in-memory database.
+// The repository is wiped with each restart.
+// Like InMemoryBookRepo, it is sharded by ID and stores orders by value, items included.
+// Updates of an order hold its shard lock, so concurrent changes are applied one at a time.
+type InMemoryOrderRepo struct {
+	logger *slog.Logger
+	shards [shardCount]orderShard
+}
+
+type orderShard struct {
+	orders map[uuid.UUID]domain.Order
+	mu     sync.RWMutex
+}
+
+// NewInMemoryOrderRepo creates a new instance of InMemoryOrderRepo, implementing domain.OrderRepository.
+func NewInMemoryOrderRepo(logger *slog.Logger) *InMemoryOrderRepo {
+	r := &InMemoryOrderRepo{logger: logger}
+	for i := range r.shards {
+		r.shards[i].orders = make(map[uuid.UUID]domain.Order)
+	}
+	return r
+}
+
+// Create a new order entry.
+func (r *InMemoryOrderRepo) Create(ctx context.Context, order *domain.Order) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	order.ID = uuid.New()
+	order.CreatedAt = time.Now().UTC()
+	order.UpdatedAt = order.CreatedAt
+	s := &r.shards[shardIndex(order.ID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.orders[order.ID] = copyOrder(order)
+	return nil
+}
+
+// ReadByID return a single order that matches the given ID.
+func (r *InMemoryOrderRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &r.shards[shardIndex(id)]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if o, ok := s.orders[id]; ok {
+		c := copyOrder(&o)
+		return &c, nil
+	}
+	return nil, errOrderNotFound
+}
+
+// ReadAll return every order, oldest first.
+// Cancellation is checked between shards.
+func (r *InMemoryOrderRepo) ReadAll(ctx context.Context) ([]*domain.Order, error) {
+	list := []*domain.Order{}
+	for i := range r.shards {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		s := &r.shards[i]
+		s.mu.RLock()
+		for _, o := range s.orders {
+			c := copyOrder(&o)
+			list = append(list, &c)
+		}
+		s.mu.RUnlock()
+	}
+	slices.SortFunc(list, func(a, b *domain.Order) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID.String(), b.ID.String()))
+	})
+	return list, nil
+}
+
+// Update atomically applies change to an order and returns the stored result.
+// change works on a copy, so a failed change leaves the stored order untouched.
+func (r *InMemoryOrderRepo) Update(
+	ctx context.Context,
+	id uuid.UUID,
+	change func(*domain.Order) error,
+) (*domain.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &r.shards[shardIndex(id)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.orders[id]
+	if !ok {
+		return nil, errOrderNotFound
+	}
+	o := copyOrder(&stored)
+	if err := change(&o); err != nil {
+		return nil, err
+	}
+	o.ID = id
+	o.UpdatedAt = time.Now().UTC()
+	s.orders[id] = copyOrder(&o)
+	return &o, nil
+}
+
+// copyOrder returns a copy of o that shares no memory with it.
+func copyOrder(o *domain.Order) domain.Order {
+	c := *o
+	c.Items = slices.Clone(o.Items)
+	return c
+}
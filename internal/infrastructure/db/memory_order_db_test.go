@@ -0,0 +1,91 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestNewInMemoryOrderRepo(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewInMemoryOrderRepo(testlog.NewTestLogger())
+	older, err := domain.NewOrder([]domain.OrderItem{
+		{BookID: uuid.New(), Quantity: 2, UnitPrice: domain.Money{Amount: 500, Currency: "EUR"}},
+	})
+	if err != nil {
+		t.Fatalf("error creating order: %v", err)
+	}
+	newer := *older
+	newer.Items = []domain.OrderItem{older.Items[0]}
+
+	for _, o := range []*domain.Order{older, &newer} {
+		if err = repo.Create(ctx, o); err != nil {
+			t.Fatalf("error storing order: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	older.Items[0].Quantity = 42
+
+	got, err := repo.ReadByID(ctx, older.ID)
+	if err != nil {
+		t.Fatalf("error reading order: %v", err)
+	}
+	if got.Items[0].Quantity != 2 {
+		t.Errorf("mutating a created order changed the stored order to %+v", got)
+	}
+
+	all, err := repo.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("error reading orders: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != older.ID || all[1].ID != newer.ID {
+		t.Errorf("ReadAll() expected oldest first, got %+v", all)
+	}
+
+	got, err = repo.Update(ctx, older.ID, func(o *domain.Order) error {
+		return o.TransitionTo(domain.OrderPaid)
+	})
+	if err != nil {
+		t.Fatalf("error updating order: %v", err)
+	}
+	if got.Status != domain.OrderPaid || got.UpdatedAt.Before(got.CreatedAt) {
+		t.Errorf("unexpected order after update: %+v", got)
+	}
+
+	_, err = repo.Update(ctx, older.ID, func(o *domain.Order) error {
+		o.Items[0].Quantity = 100
+		return o.TransitionTo(domain.OrderPending)
+	})
+	if !errors.Is(err, domain.ErrIllegalOrderTransition) {
+		t.Fatalf("Update() expected the change error, got: %v", err)
+	}
+	got, err = repo.ReadByID(ctx, older.ID)
+	if err != nil {
+		t.Fatalf("error reading order: %v", err)
+	}
+	if got.Status != domain.OrderPaid || got.Items[0].Quantity != 2 {
+		t.Errorf("failed change modified the stored order to %+v", got)
+	}
+
+	missing := uuid.New()
+	if _, err = repo.ReadByID(ctx, missing); !db.IsOrderNotFoundError(err) {
+		t.Fatalf("ReadByID() expected not found error, got: %v", err)
+	}
+	_, err = repo.Update(ctx, missing, func(*domain.Order) error { return nil })
+	if !db.IsOrderNotFoundError(err) {
+		t.Fatalf("Update() expected not found error, got: %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err = repo.ReadAll(cancelled); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadAll() expected context error, got: %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// InMemoryOutbox implements domain.EventOutbox as an in-memory queue, filled by the writes of an InMemoryBookRepo.
+// The outbox is wiped with each restart, together with the books it belongs to.
+type InMemoryOutbox struct {
+	logger *slog.Logger
+	events []domain.Event
+	mu     sync.Mutex
+}
+
+// Outbox returns the domain.EventOutbox the events passed to the writes of r are written to.
+func (r *InMemoryBookRepo) Outbox() *InMemoryOutbox {
+	return r.outbox
+}
+
+// append stamps events with the stored book and queues them.
+// It is called while the shard of book is locked, so the events become visible together with the write.
+func (o *InMemoryOutbox) append(book *domain.Book, events []*domain.Event) {
+	if len(events) == 0 {
+		return
+	}
+	stampEvents(book, events)
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, e := range events {
+		o.events = append(o.events, *e)
+	}
+}
+
+// Pending return up to limit undelivered events, oldest first.
+func (o *InMemoryOutbox) Pending(ctx context.Context, limit int) ([]*domain.Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	pending := make([]*domain.Event, 0, min(limit, len(o.events)))
+	for _, e := range o.events[:min(limit, len(o.events))] {
+		pending = append(pending, &e)
+	}
+	return pending, nil
+}
+
+// Ack removes delivered events, matched by ID, from the outbox.
+// Unknown IDs are ignored, so acknowledging an event twice is not an error.
+func (o *InMemoryOutbox) Ack(ctx context.Context, ids ...uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = slices.DeleteFunc(o.events, func(e domain.Event) bool {
+		return slices.Contains(ids, e.ID)
+	})
+	return nil
+}
+
+// copyEvents returns a copy of events, so they can be stamped for another book.
+func copyEvents(events []*domain.Event) []*domain.Event {
+	copies := make([]*domain.Event, len(events))
+	for i, e := range events {
+		c := *e
+		copies[i] = &c
+	}
+	return copies
+}
+
+// stampEvents sets the ID, and the BookID, Version, Title and Price of events from the stored book.
+func stampEvents(book *domain.Book, events []*domain.Event) {
+	for _, e := range events {
+		e.ID = uuid.New()
+		e.BookID = book.ID
+		e.Version = book.Version
+		e.Title = book.Title
+		e.Price = book.Price
+	}
+}
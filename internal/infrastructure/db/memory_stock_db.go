@@ -0,0 +1,111 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// errStockNotFound is returned by every repository when a book has no stock.
+var errStockNotFound = errors.New("stock not found")
+
+// InMemoryStockRepo implements domain.StockRepository as an in-memory database.
+// The repository is wiped with each restart.
+// Like InMemoryBookRepo, it is sharded by book ID and stores stocks by value.
+// Updates of a stock hold its shard lock, so concurrent changes are applied one at a time.
+type InMemoryStockRepo struct {
+	logger *slog.Logger
+	shards [shardCount]stockShard
+}
+
+type stockShard struct {
+	stocks map[uuid.UUID]domain.Stock
+	mu     sync.RWMutex
+}
+
+// NewInMemoryStockRepo creates a new instance of InMemoryStockRepo, implementing domain.StockRepository.
+func NewInMemoryStockRepo(logger *slog.Logger) *InMemoryStockRepo {
+	r := &InMemoryStockRepo{logger: logger}
+	for i := range r.shards {
+		r.shards[i].stocks = make(map[uuid.UUID]domain.Stock)
+	}
+	return r
+}
+
+// Create stores stock as the stock of stock.BookID.
+// It is a no-op if the book already has a stock.
+func (r *InMemoryStockRepo) Create(ctx context.Context, stock *domain.Stock) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s := &r.shards[shardIndex(stock.BookID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.stocks[stock.BookID]; ok {
+		return nil
+	}
+	stock.UpdatedAt = time.Now().UTC()
+	s.stocks[stock.BookID] = *stock
+	return nil
+}
+
+// ReadByBookID return the stock of a book.
+func (r *InMemoryStockRepo) ReadByBookID(ctx context.Context, bookID uuid.UUID) (*domain.Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &r.shards[shardIndex(bookID)]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if st, ok := s.stocks[bookID]; ok {
+		return &st, nil
+	}
+	return nil, errStockNotFound
+}
+
+// Update atomically applies change to the stock of a book and returns the stored result.
+// change works on a copy, so a failed change leaves the stored stock untouched.
+func (r *InMemoryStockRepo) Update(
+	ctx context.Context,
+	bookID uuid.UUID,
+	change func(*domain.Stock) error,
+) (*domain.Stock, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &r.shards[shardIndex(bookID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.stocks[bookID]
+	if !ok {
+		return nil, errStockNotFound
+	}
+	if err := change(&st); err != nil {
+		return nil, err
+	}
+	st.BookID = bookID
+	st.UpdatedAt = time.Now().UTC()
+	s.stocks[bookID] = st
+	return &st, nil
+}
+
+// Delete the stock of a book.
+func (r *InMemoryStockRepo) Delete(ctx context.Context, bookID uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s := &r.shards[shardIndex(bookID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.stocks[bookID]; !ok {
+		return errStockNotFound
+	}
+	delete(s.stocks, bookID)
+	return nil
+}
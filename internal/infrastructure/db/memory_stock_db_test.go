@@ -0,0 +1,138 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestNewInMemoryStockRepo(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewInMemoryStockRepo(testlog.NewTestLogger())
+	bookID := uuid.New()
+
+	if err := repo.Create(ctx, &domain.Stock{BookID: bookID}); err != nil {
+		t.Fatalf("error creating stock: %v", err)
+	}
+	got, err := repo.Update(ctx, bookID, func(s *domain.Stock) error {
+		return s.Receive(3)
+	})
+	if err != nil {
+		t.Fatalf("error updating stock: %v", err)
+	}
+	if got.OnHand != 3 || got.BookID != bookID || got.UpdatedAt.IsZero() {
+		t.Errorf("unexpected stock after update: %+v", got)
+	}
+
+	if err = repo.Create(ctx, &domain.Stock{BookID: bookID}); err != nil {
+		t.Fatalf("error creating stock twice: %v", err)
+	}
+	got, err = repo.ReadByBookID(ctx, bookID)
+	if err != nil {
+		t.Fatalf("error reading stock: %v", err)
+	}
+	if got.OnHand != 3 {
+		t.Errorf("creating an existing stock reset it to %+v", got)
+	}
+
+	_, err = repo.Update(ctx, bookID, func(s *domain.Stock) error {
+		s.OnHand = 100
+		return domain.ErrInsufficientStock
+	})
+	if !errors.Is(err, domain.ErrInsufficientStock) {
+		t.Fatalf("Update() expected the change error, got: %v", err)
+	}
+	got.OnHand = 42
+	got, err = repo.ReadByBookID(ctx, bookID)
+	if err != nil {
+		t.Fatalf("error reading stock: %v", err)
+	}
+	if got.OnHand != 3 {
+		t.Errorf("failed change or mutating a read stock changed the stored stock to %+v", got)
+	}
+
+	if err = repo.Delete(ctx, bookID); err != nil {
+		t.Fatalf("error deleting stock: %v", err)
+	}
+	_, err = repo.ReadByBookID(ctx, bookID)
+	if !db.IsStockNotFoundError(err) {
+		t.Fatalf("ReadByBookID() expected not found error, got: %v", err)
+	}
+	_, err = repo.Update(ctx, bookID, func(*domain.Stock) error { return nil })
+	if !db.IsStockNotFoundError(err) {
+		t.Fatalf("Update() expected not found error, got: %v", err)
+	}
+	if err = repo.Delete(ctx, bookID); !db.IsStockNotFoundError(err) {
+		t.Fatalf("Delete() expected not found error, got: %v", err)
+	}
+}
+
+func TestInMemoryStockRepo_ConcurrentReservations(t *testing.T) {
+	ctx := context.Background()
+	repo := db.NewInMemoryStockRepo(testlog.NewTestLogger())
+	bookID := uuid.New()
+	const onHand, workers, ops = 50, 8, 20
+
+	if err := repo.Create(ctx, &domain.Stock{BookID: bookID, OnHand: onHand}); err != nil {
+		t.Fatalf("error creating stock: %v", err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Int64
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range ops {
+				_, err := repo.Update(ctx, bookID, func(s *domain.Stock) error {
+					return s.Reserve(1)
+				})
+				switch {
+				case err == nil:
+					reserved.Add(1)
+				case !errors.Is(err, domain.ErrInsufficientStock):
+					t.Errorf("error reserving stock: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	got, err := repo.ReadByBookID(ctx, bookID)
+	if err != nil {
+		t.Fatalf("error reading stock: %v", err)
+	}
+	if reserved.Load() != onHand || got.Reserved != onHand || got.Available() != 0 {
+		t.Errorf("expected %d reservations leaving nothing available, got %d and %+v", onHand, reserved.Load(), got)
+	}
+}
+
+func TestInMemoryStockRepo_CancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	repo := db.NewInMemoryStockRepo(testlog.NewTestLogger())
+	id := uuid.New()
+
+	if err := repo.Create(ctx, &domain.Stock{BookID: id}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Create() expected context.Canceled, got: %v", err)
+	}
+	if _, err := repo.ReadByBookID(ctx, id); !errors.Is(err, context.Canceled) {
+		t.Errorf("ReadByBookID() expected context.Canceled, got: %v", err)
+	}
+	if _, err := repo.Update(ctx, id, nil); !errors.Is(err, context.Canceled) {
+		t.Errorf("Update() expected context.Canceled, got: %v", err)
+	}
+	if err := repo.Delete(ctx, id); !errors.Is(err, context.Canceled) {
+		t.Errorf("Delete() expected context.Canceled, got: %v", err)
+	}
+}
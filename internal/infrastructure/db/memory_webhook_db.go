@@ -0,0 +1,110 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// errWebhookNotFound is returned by every repository when a webhook does not exist.
+var errWebhookNotFound = errors.New("webhook not found")
+
+// InMemoryWebhookRepo implements domain.WebhookRepository as an in-memory database.
+// The repository is wiped with each restart.
+// Like InMemoryBookRepo, it is sharded by webhook ID and stores webhooks by value.
+type InMemoryWebhookRepo struct {
+	logger *slog.Logger
+	shards [shardCount]webhookShard
+}
+
+type webhookShard struct {
+	webhooks map[uuid.UUID]domain.Webhook
+	mu       sync.RWMutex
+}
+
+// NewInMemoryWebhookRepo creates a new instance of InMemoryWebhookRepo, implementing domain.WebhookRepository.
+func NewInMemoryWebhookRepo(logger *slog.Logger) *InMemoryWebhookRepo {
+	r := &InMemoryWebhookRepo{logger: logger}
+	for i := range r.shards {
+		r.shards[i].webhooks = make(map[uuid.UUID]domain.Webhook)
+	}
+	return r
+}
+
+// Create a new webhook entry.
+func (r *InMemoryWebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	webhook.ID = uuid.New()
+	webhook.CreatedAt = time.Now().UTC()
+	stored := *webhook
+	stored.Events = slices.Clone(webhook.Events)
+	s := &r.shards[shardIndex(webhook.ID)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhooks[webhook.ID] = stored
+	return nil
+}
+
+// ReadByID return a single webhook that matches the given ID.
+func (r *InMemoryWebhookRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s := &r.shards[shardIndex(id)]
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if w, ok := s.webhooks[id]; ok {
+		w.Events = slices.Clone(w.Events)
+		return &w, nil
+	}
+	return nil, errWebhookNotFound
+}
+
+// ReadAll return every webhook, oldest first and then by ID.
+// Cancellation is checked between shards.
+func (r *InMemoryWebhookRepo) ReadAll(ctx context.Context) ([]*domain.Webhook, error) {
+	list := []*domain.Webhook{}
+	for i := range r.shards {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		s := &r.shards[i]
+		s.mu.RLock()
+		for _, w := range s.webhooks {
+			webhook := w
+			webhook.Events = slices.Clone(w.Events)
+			list = append(list, &webhook)
+		}
+		s.mu.RUnlock()
+	}
+	slices.SortFunc(list, func(a, b *domain.Webhook) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), strings.Compare(a.ID.String(), b.ID.String()))
+	})
+	return list, nil
+}
+
+// Delete a single webhook, matched by ID.
+func (r *InMemoryWebhookRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s := &r.shards[shardIndex(id)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.webhooks[id]; !ok {
+		return errWebhookNotFound
+	}
+	delete(s.webhooks, id)
+	return nil
+}
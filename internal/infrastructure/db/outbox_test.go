@@ -0,0 +1,160 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInMemoryBookRepo_Outbox(t *testing.T) {
+	repo := db.NewInMemoryBookRepo(testlog.NewTestLogger())
+	testBookRepositoryOutbox(t, repo, repo.Outbox())
+}
+
+func TestSQLiteBookRepo_Outbox(t *testing.T) {
+	t.Run("writes events with the changes", func(t *testing.T) {
+		repo := newTestSQLiteBookRepo(t)
+		testBookRepositoryOutbox(t, repo, repo.Outbox())
+	})
+
+	t.Run("persists across reopen", func(t *testing.T) {
+		ctx := context.Background()
+		logger := testlog.NewTestLogger()
+		dsn := filepath.Join(t.TempDir(), "books.db")
+		repo, err := db.NewSQLiteBookRepo(logger, dsn)
+		if err != nil {
+			t.Fatalf("error opening repository: %v", err)
+		}
+		book := &domain.Book{Title: "A Book", Price: domain.Money{Amount: 10, Currency: "EUR"}}
+		event := &domain.Event{
+			At:            time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Type:          domain.EventBookPriceChanged,
+			Title:         "A Book",
+			Price:         domain.Money{Amount: 10, Currency: "EUR"},
+			PreviousPrice: domain.Money{Amount: 8, Currency: "USD"},
+		}
+		if err = repo.Create(ctx, book, event); err != nil {
+			t.Fatalf("error creating book: %v", err)
+		}
+		if err = repo.Close(); err != nil {
+			t.Fatalf("error closing repository: %v", err)
+		}
+
+		reopened, err := db.NewSQLiteBookRepo(logger, dsn)
+		if err != nil {
+			t.Fatalf("error reopening repository: %v", err)
+		}
+		t.Cleanup(func() {
+			if err := reopened.Close(); err != nil {
+				t.Errorf("error closing repository: %v", err)
+			}
+		})
+		pending, err := reopened.Outbox().Pending(ctx, 10)
+		if err != nil {
+			t.Fatalf("error reading pending events: %v", err)
+		}
+		if len(pending) != 1 || !reflect.DeepEqual(pending[0], event) {
+			t.Errorf("expected %+v, got %+v", event, pending)
+		}
+	})
+}
+
+// testBookRepositoryOutbox asserts every domain.BookRepository implementation writes the events
+// of a successful write, and only of a successful write, to its domain.EventOutbox.
+func testBookRepositoryOutbox(t *testing.T, repo domain.BookRepository, outbox domain.EventOutbox) {
+	t.Helper()
+	ctx := context.Background()
+	book := &domain.Book{Title: "A Book", ISBN: "9780306406157", Price: domain.Money{Amount: 10, Currency: "EUR"}}
+	created := domain.NewBookCreated()
+	if err := repo.Create(ctx, book, created); err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
+	if created.BookID != book.ID || created.Version != 1 || created.ID == uuid.Nil ||
+		created.Title != book.Title || created.Price != book.Price {
+		t.Errorf("Create() expected the event to be stamped with the book, got %+v", created)
+	}
+
+	duplicate := &domain.Book{Title: "Another Book", ISBN: book.ISBN}
+	if err := repo.Create(ctx, duplicate, domain.NewBookCreated()); !errors.Is(err, domain.ErrDuplicateISBN) {
+		t.Fatalf("Create() expected duplicate ISBN error, got: %v", err)
+	}
+	stale := &domain.Book{ID: book.ID, Title: book.Title, Version: 7}
+	err := repo.Update(ctx, stale, domain.NewBookPriceChanged(book.Price))
+	if !errors.Is(err, domain.ErrVersionConflict) {
+		t.Fatalf("Update() expected version conflict error, got: %v", err)
+	}
+
+	previous := book.Price
+	book.Price = domain.Money{Amount: 42, Currency: "EUR"}
+	changed := domain.NewBookPriceChanged(previous)
+	if err = repo.Update(ctx, book, changed); err != nil {
+		t.Fatalf("error updating book: %v", err)
+	}
+	if changed.Version != 2 || changed.Price != book.Price || changed.PreviousPrice != previous {
+		t.Errorf("Update() expected the event to be stamped with version 2 and the new price, got %+v", changed)
+	}
+	deleted := domain.NewBookDeleted()
+	if _, err = repo.Delete(ctx, book.ID, deleted); err != nil {
+		t.Fatalf("error deleting book: %v", err)
+	}
+	if _, err = repo.Delete(ctx, book.ID, domain.NewBookDeleted()); !db.IsNotFoundError(err) {
+		t.Fatalf("Delete() expected not found error, got: %v", err)
+	}
+	restored := domain.NewBookRestored()
+	if _, err = repo.Restore(ctx, book.ID, restored); err != nil {
+		t.Fatalf("error restoring book: %v", err)
+	}
+	if deleted.Version != 3 || restored.Version != 4 || restored.Title != book.Title {
+		t.Errorf("expected the delete and restore events to be stamped, got %+v and %+v", deleted, restored)
+	}
+
+	pending, err := outbox.Pending(ctx, 10)
+	if err != nil {
+		t.Fatalf("error reading pending events: %v", err)
+	}
+	want := []*domain.Event{created, changed, deleted, restored}
+	if len(pending) != len(want) {
+		t.Fatalf("expected %d pending events, got %+v", len(want), pending)
+	}
+	for i := range want {
+		// Stored times may lose the monotonic clock reading.
+		if !pending[i].At.Equal(want[i].At) {
+			t.Errorf("event %d: expected time %v, got %v", i, want[i].At, pending[i].At)
+		}
+		pending[i].At = want[i].At
+	}
+	if !reflect.DeepEqual(pending, want) {
+		t.Errorf("expected events %+v, got %+v", want, pending)
+	}
+
+	first, err := outbox.Pending(ctx, 1)
+	if err != nil {
+		t.Fatalf("error reading pending events: %v", err)
+	}
+	if len(first) != 1 || first[0].ID != created.ID {
+		t.Errorf("expected the oldest event only, got %+v", first)
+	}
+
+	if err = outbox.Ack(ctx, created.ID, deleted.ID, restored.ID); err != nil {
+		t.Fatalf("error acknowledging events: %v", err)
+	}
+	if err = outbox.Ack(ctx, created.ID); err != nil {
+		t.Errorf("Ack() expected acknowledging twice to succeed, got: %v", err)
+	}
+	pending, err = outbox.Pending(ctx, 10)
+	if err != nil {
+		t.Fatalf("error reading pending events: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != changed.ID {
+		t.Errorf("expected the unacknowledged event only, got %+v", pending)
+	}
+}
@@ -0,0 +1,143 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInMemoryBookRepo_ReadPage(t *testing.T) {
+	testBookRepositoryReadPage(t, db.NewInMemoryBookRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteBookRepo_ReadPage(t *testing.T) {
+	testBookRepositoryReadPage(t, newTestSQLiteBookRepo(t))
+}
+
+// testBookRepositoryReadPage asserts the paging behavior every domain.BookRepository implementation must share.
+func testBookRepositoryReadPage(t *testing.T, repo domain.BookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	for _, b := range []*domain.Book{
+		{Title: "Dune", Author: "Frank Herbert", LanguageTag: "en", Price: domain.Money{Amount: 30, Currency: "EUR"}},
+		{
+			Title: "Il nome della rosa", Author: "Umberto Eco", LanguageTag: "it",
+			Price: domain.Money{Amount: 20, Currency: "EUR"},
+		},
+		{
+			Title: "Children of Dune", Author: "Frank Herbert", LanguageTag: "en",
+			Price: domain.Money{Amount: 25, Currency: "EUR"},
+		},
+		{Title: "Baudolino", Author: "Umberto Eco", LanguageTag: "it", Price: domain.Money{Amount: 15, Currency: "EUR"}},
+		{Title: "Emma", Author: "Jane Austen", LanguageTag: "en", Price: domain.Money{Amount: 10, Currency: "EUR"}},
+	} {
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatalf("error creating book: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		query      domain.BookQuery
+		wantTitles []string
+		wantTotal  int
+	}{
+		{
+			name:       "sorts by price ascending across pages",
+			query:      domain.BookQuery{SortBy: domain.SortByPrice, Limit: 2},
+			wantTitles: []string{"Emma", "Baudolino", "Il nome della rosa", "Children of Dune", "Dune"},
+			wantTotal:  5,
+		},
+		{
+			name:       "sorts by title descending across pages",
+			query:      domain.BookQuery{SortBy: domain.SortByTitle, Descending: true, Limit: 3},
+			wantTitles: []string{"Il nome della rosa", "Emma", "Dune", "Children of Dune", "Baudolino"},
+			wantTotal:  5,
+		},
+		{
+			name:       "defaults to creation order",
+			query:      domain.BookQuery{},
+			wantTitles: []string{"Dune", "Il nome della rosa", "Children of Dune", "Baudolino", "Emma"},
+			wantTotal:  5,
+		},
+		{
+			name: "filters by author case-insensitively",
+			query: domain.BookQuery{
+				Filter: domain.BookFilter{Author: "frank herbert"},
+				SortBy: domain.SortByTitle,
+				Limit:  1,
+			},
+			wantTitles: []string{"Children of Dune", "Dune"},
+			wantTotal:  2,
+		},
+		{
+			name: "filters by price range and language",
+			query: domain.BookQuery{
+				Filter: domain.BookFilter{LanguageTag: "it", MinPrice: 16, MaxPrice: 20},
+				SortBy: domain.SortByAuthor,
+			},
+			wantTitles: []string{"Il nome della rosa"},
+			wantTotal:  1,
+		},
+		{
+			name: "returns an empty page when nothing matches",
+			query: domain.BookQuery{
+				Filter: domain.BookFilter{Author: "nobody"},
+			},
+			wantTotal: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var titles []string
+			query := tt.query
+			for {
+				page, err := repo.ReadPage(ctx, query)
+				if err != nil {
+					t.Fatalf("ReadPage() error = %v", err)
+				}
+				if page.Total != tt.wantTotal {
+					t.Errorf("ReadPage() total = %d, want %d", page.Total, tt.wantTotal)
+				}
+				for _, b := range page.Books {
+					titles = append(titles, b.Title)
+				}
+				if page.NextCursor == "" {
+					break
+				}
+				query.Cursor = page.NextCursor
+			}
+			if len(titles) != len(tt.wantTitles) {
+				t.Fatalf("ReadPage() titles = %v, want %v", titles, tt.wantTitles)
+			}
+			for i := range titles {
+				if titles[i] != tt.wantTitles[i] {
+					t.Errorf("ReadPage() titles = %v, want %v", titles, tt.wantTitles)
+					break
+				}
+			}
+		})
+	}
+
+	t.Run("rejects malformed cursor", func(t *testing.T) {
+		_, err := repo.ReadPage(ctx, domain.BookQuery{Cursor: "not a cursor"})
+		if !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("ReadPage() expected invalid cursor error, got: %v", err)
+		}
+	})
+
+	t.Run("rejects cursor from another sort order", func(t *testing.T) {
+		page, err := repo.ReadPage(ctx, domain.BookQuery{SortBy: domain.SortByPrice, Limit: 1})
+		if err != nil {
+			t.Fatalf("ReadPage() error = %v", err)
+		}
+		_, err = repo.ReadPage(ctx, domain.BookQuery{SortBy: domain.SortByTitle, Cursor: page.NextCursor})
+		if !errors.Is(err, domain.ErrInvalidCursor) {
+			t.Errorf("ReadPage() expected invalid cursor error, got: %v", err)
+		}
+	})
+}
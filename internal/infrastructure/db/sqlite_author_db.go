@@ -0,0 +1,119 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// SQLiteAuthorRepo implements domain.AuthorRepository on top of the SQLite database of a SQLiteBookRepo.
+type SQLiteAuthorRepo struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// Authors returns a domain.AuthorRepository sharing the database, and its connection, of r.
+func (r *SQLiteBookRepo) Authors() *SQLiteAuthorRepo {
+	return &SQLiteAuthorRepo{db: r.db, logger: r.logger}
+}
+
+// Create a new author entry.
+func (r *SQLiteAuthorRepo) Create(ctx context.Context, author *domain.Author) error {
+	author.ID = uuid.New()
+	author.CreatedAt = time.Now().UTC()
+	_, err := r.db.ExecContext(ctx,
+		`INSERT INTO authors (id, name, created_at) VALUES (?, ?, ?)`,
+		author.ID.String(), author.Name, author.CreatedAt.UnixNano(),
+	)
+	return err
+}
+
+// ReadByID return a single author that matches the given ID.
+func (r *SQLiteAuthorRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Author, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT id, name, created_at FROM authors WHERE id = ?`, id.String())
+	a, err := scanAuthor(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errAuthorNotFound
+	}
+	return a, err
+}
+
+// ReadAll return every author, sorted by name and then by ID.
+func (r *SQLiteAuthorRepo) ReadAll(ctx context.Context) ([]*domain.Author, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, name, created_at FROM authors ORDER BY name, id`)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.With("error", err).Warn("failed to close rows")
+		}
+	}()
+
+	list := []*domain.Author{}
+	for rows.Next() {
+		a, err := scanAuthor(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, rows.Err()
+}
+
+// Update the name of an author.
+func (r *SQLiteAuthorRepo) Update(ctx context.Context, author *domain.Author) error {
+	var createdAt int64
+	err := r.db.QueryRowContext(ctx,
+		`UPDATE authors SET name = ? WHERE id = ? RETURNING created_at`,
+		author.Name, author.ID.String(),
+	).Scan(&createdAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errAuthorNotFound
+	}
+	if err != nil {
+		return err
+	}
+	author.CreatedAt = time.Unix(0, createdAt).UTC()
+	return nil
+}
+
+// Delete a single author, matched by ID.
+func (r *SQLiteAuthorRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM authors WHERE id = ?`, id.String())
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errAuthorNotFound
+	}
+	return nil
+}
+
+func scanAuthor(s scanner) (*domain.Author, error) {
+	var (
+		a         domain.Author
+		id        string
+		createdAt int64
+	)
+	if err := s.Scan(&id, &a.Name, &createdAt); err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	a.ID = uid
+	a.CreatedAt = time.Unix(0, createdAt).UTC()
+	return &a, nil
+}
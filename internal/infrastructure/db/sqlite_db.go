@@ -0,0 +1,573 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// sqliteMigrations are applied in order on startup.
+// The index of the last applied migration is tracked with PRAGMA user_version,
+// so entries must never be edited or reordered, only appended.
+var sqliteMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS books (
+		id           TEXT PRIMARY KEY,
+		title        TEXT NOT NULL,
+		author       TEXT NOT NULL,
+		language_tag TEXT NOT NULL,
+		price        INTEGER NOT NULL
+	)`,
+	`ALTER TABLE books ADD COLUMN created_at INTEGER NOT NULL DEFAULT 0`,
+	`ALTER TABLE books ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+	// Books stored before prices had a currency are priced in euro cents.
+	`ALTER TABLE books ADD COLUMN currency TEXT NOT NULL DEFAULT 'EUR'`,
+	// Author credits are stored as a JSON array of sqliteCredit.
+	`ALTER TABLE books ADD COLUMN authors TEXT NOT NULL DEFAULT '[]'`,
+	`CREATE TABLE IF NOT EXISTS authors (
+		id         TEXT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		created_at INTEGER NOT NULL
+	)`,
+	`ALTER TABLE books ADD COLUMN isbn TEXT`,
+	// Books without an ISBN store NULL, which the unique index ignores.
+	`CREATE UNIQUE INDEX IF NOT EXISTS books_isbn ON books (isbn)`,
+	// Books in the catalog store NULL, trashed books the time they were deleted.
+	`ALTER TABLE books ADD COLUMN deleted_at INTEGER`,
+	// Events waiting to be delivered, in the order they were raised. The payload is a JSON sqliteEventData.
+	`CREATE TABLE IF NOT EXISTS outbox (
+		seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+		id          TEXT NOT NULL UNIQUE,
+		type        TEXT NOT NULL,
+		book_id     TEXT NOT NULL,
+		version     INTEGER NOT NULL,
+		occurred_at INTEGER NOT NULL,
+		data        TEXT NOT NULL
+	)`,
+	// Events is a JSON array of the event types delivered to the webhook, empty for every event.
+	`CREATE TABLE IF NOT EXISTS webhooks (
+		id         TEXT PRIMARY KEY,
+		url        TEXT NOT NULL,
+		secret     TEXT NOT NULL,
+		events     TEXT NOT NULL,
+		created_at INTEGER NOT NULL
+	)`,
+	// The event is stored like in the outbox, attempts as a JSON array of sqliteAttempt.
+	// Deliveries that succeeded or were dead-lettered store a NULL next_attempt_at.
+	`CREATE TABLE IF NOT EXISTS deliveries (
+		id              TEXT PRIMARY KEY,
+		webhook_id      TEXT NOT NULL,
+		status          TEXT NOT NULL,
+		attempts        TEXT NOT NULL,
+		next_attempt_at INTEGER,
+		created_at      INTEGER NOT NULL,
+		event_id        TEXT NOT NULL,
+		event_type      TEXT NOT NULL,
+		book_id         TEXT NOT NULL,
+		version         INTEGER NOT NULL,
+		occurred_at     INTEGER NOT NULL,
+		data            TEXT NOT NULL,
+		UNIQUE (webhook_id, event_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS deliveries_due ON deliveries (status, next_attempt_at)`,
+}
+
+const bookColumns = `id, title, author, language_tag, price, currency, created_at, version, authors, isbn, deleted_at`
+
+// sqliteCredit is the stored form of a domain.BookAuthor.
+// Author names are not stored: they belong to the authors and are filled in when books are read.
+type sqliteCredit struct {
+	Role     domain.AuthorRole `json:"role"`
+	AuthorID uuid.UUID         `json:"author_id"`
+}
+
+// sqliteSortColumns maps each domain.BookSortField to its column.
+var sqliteSortColumns = map[domain.BookSortField]string{
+	domain.SortByTitle:   "title",
+	domain.SortByAuthor:  "author",
+	domain.SortByPrice:   "price",
+	domain.SortByCreated: "created_at",
+}
+
+// SQLiteBookRepo implements domain.BookRepository on top of a SQLite database.
+// The repository persists books across restarts.
+// Events passed to a write are inserted in the outbox table in the same transaction as the write.
+type SQLiteBookRepo struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// NewSQLiteBookRepo opens the SQLite database at dsn and creates the schema if it does not exist.
+func NewSQLiteBookRepo(logger *slog.Logger, dsn string) (*SQLiteBookRepo, error) {
+	conn, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows a single writer at a time, sharing one connection avoids "database is locked" errors.
+	conn.SetMaxOpenConns(1)
+
+	if err = migrate(context.Background(), conn); err != nil {
+		return nil, errors.Join(err, conn.Close())
+	}
+	return &SQLiteBookRepo{db: conn, logger: logger}, nil
+}
+
+// migrate applies the sqliteMigrations that have not been applied yet.
+func migrate(ctx context.Context, conn *sql.DB) error {
+	var version int
+	if err := conn.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	for i := version; i < len(sqliteMigrations); i++ {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx, sqliteMigrations[i]); err != nil {
+			return errors.Join(fmt.Errorf("migration %d: %w", i+1, err), tx.Rollback())
+		}
+		// PRAGMA does not accept bound parameters.
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, i+1)); err != nil {
+			return errors.Join(err, tx.Rollback())
+		}
+		if err = tx.Commit(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close releases the underlying database connection.
+func (r *SQLiteBookRepo) Close() error {
+	return r.db.Close()
+}
+
+// Create a new book entry.
+// Books with the ISBN of a stored book are rejected with domain.ErrDuplicateISBN.
+func (r *SQLiteBookRepo) Create(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		return insertBook(ctx, tx, book, events)
+	})
+}
+
+// CreateAll creates every book in a single transaction, or none of them if any fails.
+func (r *SQLiteBookRepo) CreateAll(ctx context.Context, books []*domain.Book, events ...*domain.Event) error {
+	return r.inTx(ctx, func(tx *sql.Tx) error {
+		for _, b := range books {
+			if err := insertBook(ctx, tx, b, copyEvents(events)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertBook sets the bookkeeping fields of a new book and inserts it, with events, within tx.
+func insertBook(ctx context.Context, tx *sql.Tx, book *domain.Book, events []*domain.Event) error {
+	book.ID = uuid.New()
+	book.CreatedAt = time.Now().UTC()
+	book.Version = 1
+	credits, err := encodeCredits(book.Authors)
+	if err != nil {
+		return err
+	}
+	var isbn sql.NullString
+	if book.ISBN != "" {
+		isbn = sql.NullString{String: book.ISBN, Valid: true}
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO books (`+bookColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, NULL)`,
+		book.ID.String(), book.Title, book.Author, book.LanguageTag,
+		book.Price.Amount, book.Price.Currency, book.CreatedAt.UnixNano(), book.Version, credits, isbn,
+	)
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+		return domain.ErrDuplicateISBN
+	}
+	if err != nil {
+		return err
+	}
+	return insertEvents(ctx, tx, book, events)
+}
+
+func (r *SQLiteBookRepo) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// ReadByID return a single book that matches the given ID.
+func (r *SQLiteBookRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Book, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT `+bookColumns+` FROM books WHERE id = ? AND deleted_at IS NULL`,
+		id.String(),
+	)
+	b, err := scanBook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errBookNotFound
+	}
+	return b, err
+}
+
+// ReadByISBN return the single book with the given normalized ISBN.
+func (r *SQLiteBookRepo) ReadByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	row := r.db.QueryRowContext(ctx, `SELECT `+bookColumns+` FROM books WHERE isbn = ? AND deleted_at IS NULL`, isbn)
+	b, err := scanBook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errBookNotFound
+	}
+	return b, err
+}
+
+// ReadAll return a list of books, in insertion order.
+func (r *SQLiteBookRepo) ReadAll(ctx context.Context) ([]*domain.Book, error) {
+	return r.query(ctx, `SELECT `+bookColumns+` FROM books WHERE deleted_at IS NULL ORDER BY rowid`)
+}
+
+// ReadPage return the page of books matching query.
+// Pages are read with keyset pagination on (sort column, id), so deep pages cost the same as the first one.
+func (r *SQLiteBookRepo) ReadPage(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
+	q := query.WithDefaults()
+	column, ok := sqliteSortColumns[q.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field %q", q.SortBy)
+	}
+
+	where := []string{`deleted_at IS NULL`}
+	if q.Trashed {
+		where[0] = `deleted_at IS NOT NULL`
+	}
+	var args []any
+	if q.Filter.Author != "" {
+		where = append(where, `author = ? COLLATE NOCASE`)
+		args = append(args, q.Filter.Author)
+	}
+	if q.Filter.LanguageTag != "" {
+		where = append(where, `language_tag = ? COLLATE NOCASE`)
+		args = append(args, q.Filter.LanguageTag)
+	}
+	if q.Filter.MinPrice > 0 {
+		where = append(where, `price >= ?`)
+		args = append(args, q.Filter.MinPrice)
+	}
+	if q.Filter.MaxPrice > 0 {
+		where = append(where, `price <= ?`)
+		args = append(args, q.Filter.MaxPrice)
+	}
+	if q.Filter.AuthorID != uuid.Nil {
+		where = append(where, `EXISTS (SELECT 1 FROM json_each(authors) WHERE value ->> '$.author_id' = ?)`)
+		args = append(args, q.Filter.AuthorID.String())
+	}
+
+	page := &domain.BookPage{}
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM books`+whereClause(where), args...,
+	).Scan(&page.Total); err != nil {
+		return nil, err
+	}
+
+	op, dir := ">", "ASC"
+	if q.Descending {
+		op, dir = "<", "DESC"
+	}
+	if q.Cursor != "" {
+		c, err := decodeBookCursor(&q, q.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		var key any = c.Num
+		if q.SortBy == domain.SortByTitle || q.SortBy == domain.SortByAuthor {
+			key = c.Str
+		}
+		// column and op come from fixed allow-lists, never from user input.
+		where = append(where, fmt.Sprintf(`(%[1]s %[2]s ? OR (%[1]s = ? AND id %[2]s ?))`, column, op))
+		args = append(args, key, key, c.ID.String())
+	}
+
+	// Fetch one extra row to know whether there is a next page.
+	args = append(args, q.Limit+1)
+	books, err := r.query(ctx,
+		//nolint:gosec // column and dir come from fixed allow-lists, values are bound parameters
+		fmt.Sprintf(`SELECT %s FROM books%s ORDER BY %s %s, id %s LIMIT ?`,
+			bookColumns, whereClause(where), column, dir, dir),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	page.Books = books
+	if len(books) > q.Limit {
+		page.Books = books[:q.Limit]
+		page.NextCursor = newBookCursor(&q, page.Books[q.Limit-1]).encode()
+	}
+	if page.Books == nil {
+		page.Books = []*domain.Book{}
+	}
+	return page, nil
+}
+
+// eachBatchSize is the number of books Each reads at once.
+const eachBatchSize = 500
+
+// Each calls fn with every book, oldest first and then by ID, and stops at the first error fn returns.
+// Books are read in batches with keyset pagination on (created_at, id), and fn only runs between batches:
+// the repository shares a single connection, which a query left open would hold while fn runs.
+func (r *SQLiteBookRepo) Each(ctx context.Context, withTrashed bool, fn func(*domain.Book) error) error {
+	var (
+		createdAt int64 = math.MinInt64
+		id        string
+	)
+	for {
+		books, err := r.query(ctx,
+			`SELECT `+bookColumns+` FROM books
+			WHERE (? OR deleted_at IS NULL) AND (created_at > ? OR (created_at = ? AND id > ?))
+			ORDER BY created_at, id LIMIT ?`,
+			withTrashed, createdAt, createdAt, id, eachBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+		for _, b := range books {
+			if err = fn(b); err != nil {
+				return err
+			}
+		}
+		if len(books) < eachBatchSize {
+			return nil
+		}
+		last := books[len(books)-1]
+		createdAt, id = last.CreatedAt.UnixNano(), last.ID.String()
+	}
+}
+
+func (r *SQLiteBookRepo) query(ctx context.Context, query string, args ...any) ([]*domain.Book, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.With("error", err).Warn("failed to close rows")
+		}
+	}()
+
+	var list []*domain.Book
+	for rows.Next() {
+		b, err := scanBook(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, b)
+	}
+	return list, rows.Err()
+}
+
+// Update the title, author, credits, language and price of a book.
+// The update is rejected with domain.ErrVersionConflict if book.Version is set and stale.
+func (r *SQLiteBookRepo) Update(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
+	credits, err := encodeCredits(book.Authors)
+	if err != nil {
+		return err
+	}
+	stored := *book
+	err = r.inTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRowContext(ctx,
+			`UPDATE books SET title = ?, author = ?, language_tag = ?, price = ?, currency = ?, authors = ?,
+			version = version + 1
+			WHERE id = ? AND deleted_at IS NULL AND (? = 0 OR version = ?)
+			RETURNING version`,
+			book.Title, book.Author, book.LanguageTag, book.Price.Amount, book.Price.Currency, credits,
+			book.ID.String(), book.Version, book.Version,
+		).Scan(&stored.Version)
+		if err != nil {
+			return err
+		}
+		return insertEvents(ctx, tx, &stored, events)
+	})
+	// The transaction is over, so its connection is free for the follow-up query.
+	if errors.Is(err, sql.ErrNoRows) {
+		return r.missingOrConflict(ctx, book.ID)
+	}
+	if err != nil {
+		return err
+	}
+	book.Version = stored.Version
+	return nil
+}
+
+// missingOrConflict explains why a conditional write matched no rows.
+func (r *SQLiteBookRepo) missingOrConflict(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	err := r.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM books WHERE id = ? AND deleted_at IS NULL)`, id.String()).Scan(&exists)
+	switch {
+	case err != nil:
+		return err
+	case exists:
+		return domain.ErrVersionConflict
+	default:
+		return errBookNotFound
+	}
+}
+
+// Delete moves a single book, matched by ID, to the trash.
+func (r *SQLiteBookRepo) Delete(ctx context.Context, id uuid.UUID, events ...*domain.Event) (*domain.Book, error) {
+	var b *domain.Book
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		b, err = scanBook(tx.QueryRowContext(ctx,
+			`UPDATE books SET deleted_at = ?, version = version + 1 WHERE id = ? AND deleted_at IS NULL
+			RETURNING `+bookColumns,
+			time.Now().UTC().UnixNano(), id.String(),
+		))
+		if err != nil {
+			return err
+		}
+		return insertEvents(ctx, tx, b, events)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Restore moves a single trashed book, matched by ID, back to the catalog.
+func (r *SQLiteBookRepo) Restore(ctx context.Context, id uuid.UUID, events ...*domain.Event) (*domain.Book, error) {
+	var b *domain.Book
+	err := r.inTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		b, err = scanBook(tx.QueryRowContext(ctx,
+			`UPDATE books SET deleted_at = NULL, version = version + 1
+			WHERE id = ? AND deleted_at IS NOT NULL
+			RETURNING `+bookColumns,
+			id.String(),
+		))
+		if err != nil {
+			return err
+		}
+		return insertEvents(ctx, tx, b, events)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Purge removes for good every book trashed before deletedBefore.
+func (r *SQLiteBookRepo) Purge(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`DELETE FROM books WHERE deleted_at < ? RETURNING id`,
+		deletedBefore.UnixNano(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.With("error", err).Warn("failed to close rows")
+		}
+	}()
+
+	var purged []uuid.UUID
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+		purged = append(purged, uid)
+	}
+	return purged, rows.Err()
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBook(s scanner) (*domain.Book, error) {
+	var (
+		b         domain.Book
+		id        string
+		credits   string
+		isbn      sql.NullString
+		deletedAt sql.NullInt64
+		createdAt int64
+	)
+	if err := s.Scan(
+		&id, &b.Title, &b.Author, &b.LanguageTag, &b.Price.Amount, &b.Price.Currency, &createdAt, &b.Version,
+		&credits, &isbn, &deletedAt,
+	); err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	b.ID = uid
+	b.ISBN = isbn.String
+	b.CreatedAt = time.Unix(0, createdAt).UTC()
+	if deletedAt.Valid {
+		b.DeletedAt = time.Unix(0, deletedAt.Int64).UTC()
+	}
+	if b.Authors, err = decodeCredits(credits); err != nil {
+		return nil, err
+	}
+	return &b, nil
+}
+
+func encodeCredits(credits []domain.BookAuthor) (string, error) {
+	stored := make([]sqliteCredit, len(credits))
+	for i, c := range credits {
+		stored[i] = sqliteCredit{AuthorID: c.AuthorID, Role: c.Role}
+	}
+	data, err := json.Marshal(stored)
+	return string(data), err
+}
+
+func decodeCredits(data string) ([]domain.BookAuthor, error) {
+	var stored []sqliteCredit
+	if err := json.Unmarshal([]byte(data), &stored); err != nil {
+		return nil, err
+	}
+	if len(stored) == 0 {
+		return nil, nil
+	}
+	credits := make([]domain.BookAuthor, len(stored))
+	for i, c := range stored {
+		credits[i] = domain.BookAuthor{AuthorID: c.AuthorID, Role: c.Role}
+	}
+	return credits, nil
+}
+
+func whereClause(conditions []string) string {
+	if len(conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(conditions, " AND ")
+}
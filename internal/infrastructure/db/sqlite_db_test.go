@@ -0,0 +1,119 @@
+package db_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestNewSQLiteBookRepo(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	t.Run("crud operations", func(t *testing.T) {
+		testBookRepositoryCRUD(t, newTestSQLiteBookRepo(t))
+	})
+	t.Run("versioning", func(t *testing.T) {
+		testBookRepositoryVersioning(t, newTestSQLiteBookRepo(t))
+	})
+
+	t.Run("persists across reopen", func(t *testing.T) {
+		ctx := context.Background()
+		dsn := filepath.Join(t.TempDir(), "books.db")
+		repo, err := db.NewSQLiteBookRepo(logger, dsn)
+		if err != nil {
+			t.Fatalf("error opening repository: %v", err)
+		}
+		book := &domain.Book{
+			Title:       "A Book",
+			Author:      "An Author",
+			LanguageTag: "en",
+			Price:       domain.Money{Amount: 10, Currency: "EUR"},
+		}
+		if err = repo.Create(ctx, book); err != nil {
+			t.Fatalf("error creating book: %v", err)
+		}
+		if err = repo.Close(); err != nil {
+			t.Fatalf("error closing repository: %v", err)
+		}
+
+		reopened, err := db.NewSQLiteBookRepo(logger, dsn)
+		if err != nil {
+			t.Fatalf("error reopening repository: %v", err)
+		}
+		t.Cleanup(func() {
+			if err := reopened.Close(); err != nil {
+				t.Errorf("error closing repository: %v", err)
+			}
+		})
+		got, err := reopened.ReadByID(ctx, book.ID)
+		if err != nil {
+			t.Fatalf("error reading book: %v", err)
+		}
+		if !reflect.DeepEqual(book, got) {
+			t.Errorf("expected %+v, got %+v", book, got)
+		}
+	})
+}
+
+func TestNewSQLiteBookRepo_MigratesExistingDatabase(t *testing.T) {
+	ctx := context.Background()
+	dsn := filepath.Join(t.TempDir(), "books.db")
+	legacy, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		t.Fatalf("error opening database: %v", err)
+	}
+	id := uuid.New()
+	for _, stmt := range []string{
+		`CREATE TABLE books (
+			id TEXT PRIMARY KEY, title TEXT NOT NULL, author TEXT NOT NULL,
+			language_tag TEXT NOT NULL, price INTEGER NOT NULL
+		)`,
+		`INSERT INTO books VALUES ('` + id.String() + `', 'A Book', 'An Author', 'en', 10)`,
+	} {
+		if _, err = legacy.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("error preparing legacy schema: %v", err)
+		}
+	}
+	if err = legacy.Close(); err != nil {
+		t.Fatalf("error closing database: %v", err)
+	}
+
+	repo, err := db.NewSQLiteBookRepo(testlog.NewTestLogger(), dsn)
+	if err != nil {
+		t.Fatalf("error opening repository: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := repo.Close(); err != nil {
+			t.Errorf("error closing repository: %v", err)
+		}
+	})
+	got, err := repo.ReadByID(ctx, id)
+	if err != nil {
+		t.Fatalf("error reading book: %v", err)
+	}
+	if got.Title != "A Book" || got.Price != (domain.Money{Amount: 10, Currency: "EUR"}) || got.Authors != nil {
+		t.Errorf("unexpected book after migration: %+v", got)
+	}
+}
+
+// newTestSQLiteBookRepo opens a SQLiteBookRepo on a temporary file, closed when the test ends.
+func newTestSQLiteBookRepo(t *testing.T) *db.SQLiteBookRepo {
+	t.Helper()
+	repo, err := db.NewSQLiteBookRepo(testlog.NewTestLogger(), filepath.Join(t.TempDir(), "books.db"))
+	if err != nil {
+		t.Fatalf("error opening repository: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := repo.Close(); err != nil {
+			t.Errorf("error closing repository: %v", err)
+		}
+	})
+	return repo
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// SQLiteOutbox implements domain.EventOutbox on top of the outbox table of a SQLiteBookRepo.
+// Undelivered events survive restarts, together with the writes that raised them.
+type SQLiteOutbox struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// sqliteEventData is the stored form of the payload of a domain.Event.
+type sqliteEventData struct {
+	Title            string `json:"title"`
+	Currency         string `json:"currency"`
+	PreviousCurrency string `json:"previous_currency,omitempty"`
+	Price            int64  `json:"price"`
+	PreviousPrice    int64  `json:"previous_price,omitempty"`
+}
+
+// Outbox returns the domain.EventOutbox the events passed to the writes of r are written to.
+// It shares the database, and its connection, of r.
+func (r *SQLiteBookRepo) Outbox() *SQLiteOutbox {
+	return &SQLiteOutbox{db: r.db, logger: r.logger}
+}
+
+// insertEvents stamps events with the stored book and inserts them as part of tx.
+func insertEvents(ctx context.Context, tx *sql.Tx, book *domain.Book, events []*domain.Event) error {
+	stampEvents(book, events)
+	for _, e := range events {
+		data, err := marshalEventData(e)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.ExecContext(ctx,
+			`INSERT INTO outbox (id, type, book_id, version, occurred_at, data) VALUES (?, ?, ?, ?, ?, ?)`,
+			e.ID.String(), string(e.Type), e.BookID.String(), e.Version, e.At.UnixNano(), data,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Pending return up to limit undelivered events, oldest first.
+func (o *SQLiteOutbox) Pending(ctx context.Context, limit int) ([]*domain.Event, error) {
+	rows, err := o.db.QueryContext(ctx,
+		`SELECT id, type, book_id, version, occurred_at, data FROM outbox ORDER BY seq LIMIT ?`,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			o.logger.With("error", err).Warn("failed to close rows")
+		}
+	}()
+
+	pending := []*domain.Event{}
+	for rows.Next() {
+		e, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		pending = append(pending, e)
+	}
+	return pending, rows.Err()
+}
+
+// Ack removes delivered events, matched by ID, from the outbox.
+// Unknown IDs are ignored, so acknowledging an event twice is not an error.
+func (o *SQLiteOutbox) Ack(ctx context.Context, ids ...uuid.UUID) error {
+	if len(ids) == 0 {
+		return ctx.Err()
+	}
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id.String()
+	}
+	placeholders := strings.Repeat(", ?", len(ids))[2:]
+	//nolint:gosec // only placeholders are concatenated, values are bound parameters
+	_, err := o.db.ExecContext(ctx, `DELETE FROM outbox WHERE id IN (`+placeholders+`)`, args...)
+	return err
+}
+
+func scanEvent(s scanner) (*domain.Event, error) {
+	var (
+		e          domain.Event
+		id, bookID string
+		data       string
+		at         int64
+	)
+	if err := s.Scan(&id, &e.Type, &bookID, &e.Version, &at, &data); err != nil {
+		return nil, err
+	}
+	if err := unmarshalEvent(&e, id, bookID, at, data); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// marshalEventData returns the stored form of the payload of e, a JSON sqliteEventData.
+func marshalEventData(e *domain.Event) (string, error) {
+	data, err := json.Marshal(sqliteEventData{
+		Title:            e.Title,
+		Currency:         e.Price.Currency,
+		PreviousCurrency: e.PreviousPrice.Currency,
+		Price:            e.Price.Amount,
+		PreviousPrice:    e.PreviousPrice.Amount,
+	})
+	return string(data), err
+}
+
+// unmarshalEvent sets the ID, book ID, time and payload of e from their stored form.
+func unmarshalEvent(e *domain.Event, id, bookID string, at int64, data string) error {
+	var err error
+	if e.ID, err = uuid.Parse(id); err != nil {
+		return err
+	}
+	if e.BookID, err = uuid.Parse(bookID); err != nil {
+		return err
+	}
+	var stored sqliteEventData
+	if err = json.Unmarshal([]byte(data), &stored); err != nil {
+		return err
+	}
+	e.At = time.Unix(0, at).UTC()
+	e.Title = stored.Title
+	e.Price = domain.Money{Amount: stored.Price, Currency: stored.Currency}
+	e.PreviousPrice = domain.Money{Amount: stored.PreviousPrice, Currency: stored.PreviousCurrency}
+	return nil
+}
@@ -0,0 +1,302 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// SQLiteWebhookRepo implements domain.WebhookRepository on top of the SQLite database of a SQLiteBookRepo.
+// Subscriptions survive restarts.
+type SQLiteWebhookRepo struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// Webhooks returns a domain.WebhookRepository sharing the database, and its connection, of r.
+func (r *SQLiteBookRepo) Webhooks() *SQLiteWebhookRepo {
+	return &SQLiteWebhookRepo{db: r.db, logger: r.logger}
+}
+
+// Create a new webhook entry.
+func (r *SQLiteWebhookRepo) Create(ctx context.Context, webhook *domain.Webhook) error {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+	webhook.ID = uuid.New()
+	webhook.CreatedAt = time.Now().UTC()
+	_, err = r.db.ExecContext(ctx,
+		`INSERT INTO webhooks (id, url, secret, events, created_at) VALUES (?, ?, ?, ?, ?)`,
+		webhook.ID.String(), webhook.URL, webhook.Secret, string(events), webhook.CreatedAt.UnixNano(),
+	)
+	return err
+}
+
+// ReadByID return a single webhook that matches the given ID.
+func (r *SQLiteWebhookRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Webhook, error) {
+	row := r.db.QueryRowContext(ctx,
+		`SELECT id, url, secret, events, created_at FROM webhooks WHERE id = ?`, id.String(),
+	)
+	w, err := scanWebhook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errWebhookNotFound
+	}
+	return w, err
+}
+
+// ReadAll return every webhook, oldest first and then by ID.
+func (r *SQLiteWebhookRepo) ReadAll(ctx context.Context) ([]*domain.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT id, url, secret, events, created_at FROM webhooks ORDER BY created_at, id`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.With("error", err).Warn("failed to close rows")
+		}
+	}()
+
+	list := []*domain.Webhook{}
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, w)
+	}
+	return list, rows.Err()
+}
+
+// Delete a single webhook, matched by ID.
+func (r *SQLiteWebhookRepo) Delete(ctx context.Context, id uuid.UUID) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id.String())
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errWebhookNotFound
+	}
+	return nil
+}
+
+func scanWebhook(s scanner) (*domain.Webhook, error) {
+	var (
+		w         domain.Webhook
+		id        string
+		events    string
+		createdAt int64
+	)
+	if err := s.Scan(&id, &w.URL, &w.Secret, &events, &createdAt); err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(events), &w.Events); err != nil {
+		return nil, err
+	}
+	w.ID = uid
+	w.CreatedAt = time.Unix(0, createdAt).UTC()
+	return &w, nil
+}
+
+// SQLiteDeliveryRepo implements domain.DeliveryRepository on top of the SQLite database of a SQLiteBookRepo.
+// Pending deliveries survive restarts, and are retried where they were left.
+type SQLiteDeliveryRepo struct {
+	db     *sql.DB
+	logger *slog.Logger
+}
+
+// sqliteAttempt is the stored form of a domain.DeliveryAttempt.
+type sqliteAttempt struct {
+	At         time.Time `json:"at"`
+	Error      string    `json:"error,omitempty"`
+	StatusCode int       `json:"status_code,omitempty"`
+}
+
+const deliveryColumns = `id, webhook_id, status, attempts, next_attempt_at, created_at, ` +
+	`event_id, event_type, book_id, version, occurred_at, data`
+
+// Deliveries returns a domain.DeliveryRepository sharing the database, and its connection, of r.
+func (r *SQLiteBookRepo) Deliveries() *SQLiteDeliveryRepo {
+	return &SQLiteDeliveryRepo{db: r.db, logger: r.logger}
+}
+
+// Create a new delivery entry, unless the event was already queued for the webhook.
+func (r *SQLiteDeliveryRepo) Create(ctx context.Context, delivery *domain.Delivery) error {
+	attempts, err := marshalAttempts(delivery.Attempts)
+	if err != nil {
+		return err
+	}
+	data, err := marshalEventData(&delivery.Event)
+	if err != nil {
+		return err
+	}
+	id, createdAt := uuid.New(), time.Now().UTC()
+	e := &delivery.Event
+	res, err := r.db.ExecContext(ctx,
+		`INSERT INTO deliveries (`+deliveryColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT (webhook_id, event_id) DO NOTHING`,
+		id.String(), delivery.WebhookID.String(), string(delivery.Status), attempts,
+		nullTime(delivery.NextAttemptAt), createdAt.UnixNano(),
+		e.ID.String(), string(e.Type), e.BookID.String(), e.Version, e.At.UnixNano(), data,
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		row := r.db.QueryRowContext(ctx,
+			`SELECT `+deliveryColumns+` FROM deliveries WHERE webhook_id = ? AND event_id = ?`,
+			delivery.WebhookID.String(), e.ID.String(),
+		)
+		stored, err := scanDelivery(row)
+		if err != nil {
+			return err
+		}
+		*delivery = *stored
+		return nil
+	}
+	delivery.ID, delivery.CreatedAt = id, createdAt
+	return nil
+}
+
+// ReadDue return up to limit pending deliveries due at now, earliest first and then by ID.
+func (r *SQLiteDeliveryRepo) ReadDue(ctx context.Context, now time.Time, limit int) ([]*domain.Delivery, error) {
+	return r.query(ctx,
+		`SELECT `+deliveryColumns+` FROM deliveries
+			WHERE status = ? AND next_attempt_at <= ? ORDER BY next_attempt_at, id LIMIT ?`,
+		string(domain.DeliveryPending), now.UnixNano(), limit,
+	)
+}
+
+// ReadByWebhook return every delivery to a webhook, newest first and then by ID.
+func (r *SQLiteDeliveryRepo) ReadByWebhook(ctx context.Context, webhookID uuid.UUID) ([]*domain.Delivery, error) {
+	return r.query(ctx,
+		`SELECT `+deliveryColumns+` FROM deliveries WHERE webhook_id = ? ORDER BY created_at DESC, id`,
+		webhookID.String(),
+	)
+}
+
+// Update the status, attempts and next attempt of a delivery.
+func (r *SQLiteDeliveryRepo) Update(ctx context.Context, delivery *domain.Delivery) error {
+	attempts, err := marshalAttempts(delivery.Attempts)
+	if err != nil {
+		return err
+	}
+	res, err := r.db.ExecContext(ctx,
+		`UPDATE deliveries SET status = ?, attempts = ?, next_attempt_at = ? WHERE id = ?`,
+		string(delivery.Status), attempts, nullTime(delivery.NextAttemptAt), delivery.ID.String(),
+	)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errDeliveryNotFound
+	}
+	return nil
+}
+
+// DeleteByWebhook removes every delivery to a webhook.
+func (r *SQLiteDeliveryRepo) DeleteByWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM deliveries WHERE webhook_id = ?`, webhookID.String())
+	return err
+}
+
+func (r *SQLiteDeliveryRepo) query(ctx context.Context, query string, args ...any) ([]*domain.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			r.logger.With("error", err).Warn("failed to close rows")
+		}
+	}()
+
+	list := []*domain.Delivery{}
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, d)
+	}
+	return list, rows.Err()
+}
+
+func scanDelivery(s scanner) (*domain.Delivery, error) {
+	var (
+		d                          domain.Delivery
+		id, webhookID              string
+		attempts                   string
+		nextAttemptAt              sql.NullInt64
+		createdAt, occurredAt      int64
+		eventID, bookID, eventData string
+	)
+	if err := s.Scan(
+		&id, &webhookID, &d.Status, &attempts, &nextAttemptAt, &createdAt,
+		&eventID, &d.Event.Type, &bookID, &d.Event.Version, &occurredAt, &eventData,
+	); err != nil {
+		return nil, err
+	}
+	var err error
+	if d.ID, err = uuid.Parse(id); err != nil {
+		return nil, err
+	}
+	if d.WebhookID, err = uuid.Parse(webhookID); err != nil {
+		return nil, err
+	}
+	if err = unmarshalEvent(&d.Event, eventID, bookID, occurredAt, eventData); err != nil {
+		return nil, err
+	}
+	var stored []sqliteAttempt
+	if err = json.Unmarshal([]byte(attempts), &stored); err != nil {
+		return nil, err
+	}
+	for _, a := range stored {
+		d.Attempts = append(d.Attempts, domain.DeliveryAttempt{At: a.At, Error: a.Error, StatusCode: a.StatusCode})
+	}
+	if nextAttemptAt.Valid {
+		d.NextAttemptAt = time.Unix(0, nextAttemptAt.Int64).UTC()
+	}
+	d.CreatedAt = time.Unix(0, createdAt).UTC()
+	return &d, nil
+}
+
+// marshalAttempts returns the stored form of attempts, a JSON array of sqliteAttempt.
+func marshalAttempts(attempts []domain.DeliveryAttempt) (string, error) {
+	stored := make([]sqliteAttempt, len(attempts))
+	for i, a := range attempts {
+		stored[i] = sqliteAttempt{At: a.At, Error: a.Error, StatusCode: a.StatusCode}
+	}
+	data, err := json.Marshal(stored)
+	return string(data), err
+}
+
+// nullTime returns the stored form of t, NULL if t is zero.
+func nullTime(t time.Time) sql.NullInt64 {
+	return sql.NullInt64{Int64: t.UnixNano(), Valid: !t.IsZero()}
+}
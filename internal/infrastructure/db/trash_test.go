@@ -0,0 +1,114 @@
+package db_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInMemoryBookRepo_Trash(t *testing.T) {
+	testBookRepositoryTrash(t, db.NewInMemoryBookRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteBookRepo_Trash(t *testing.T) {
+	testBookRepositoryTrash(t, newTestSQLiteBookRepo(t))
+}
+
+// testBookRepositoryTrash asserts every domain.BookRepository implementation hides trashed books
+// from the catalog, lists them in the trash, and can restore or purge them.
+func testBookRepositoryTrash(t *testing.T, repo domain.BookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	kept := &domain.Book{Title: "Kept", Author: "An Author", Price: domain.Money{Amount: 10, Currency: "EUR"}}
+	trashed := &domain.Book{Title: "Trashed", Author: "An Author", Price: domain.Money{Amount: 20, Currency: "EUR"}}
+	for _, b := range []*domain.Book{kept, trashed} {
+		if err := repo.Create(ctx, b); err != nil {
+			t.Fatalf("error creating book: %v", err)
+		}
+	}
+
+	deleted, err := repo.Delete(ctx, trashed.ID)
+	if err != nil {
+		t.Fatalf("error deleting book: %v", err)
+	}
+	if deleted.ID != trashed.ID || deleted.Title != trashed.Title || !deleted.IsDeleted() ||
+		deleted.Version != trashed.Version+1 {
+		t.Errorf("Delete() returned %+v, want the trashed book at version %d", deleted, trashed.Version+1)
+	}
+	if _, err := repo.ReadByID(ctx, trashed.ID); !db.IsNotFoundError(err) {
+		t.Errorf("ReadByID() expected not found error, got: %v", err)
+	}
+	if err := repo.Update(ctx, &domain.Book{ID: trashed.ID}); !db.IsNotFoundError(err) {
+		t.Errorf("Update() expected not found error, got: %v", err)
+	}
+	if _, err := repo.Delete(ctx, trashed.ID); !db.IsNotFoundError(err) {
+		t.Errorf("Delete() expected not found error, got: %v", err)
+	}
+
+	catalog, err := repo.ReadPage(ctx, domain.BookQuery{})
+	if err != nil {
+		t.Fatalf("error reading catalog: %v", err)
+	}
+	if catalog.Total != 1 || catalog.Books[0].ID != kept.ID {
+		t.Errorf("expected only %s in the catalog, got %+v", kept.ID, catalog.Books)
+	}
+	trash, err := repo.ReadPage(ctx, domain.BookQuery{Trashed: true})
+	if err != nil {
+		t.Fatalf("error reading trash: %v", err)
+	}
+	if trash.Total != 1 || trash.Books[0].ID != trashed.ID || !trash.Books[0].IsDeleted() {
+		t.Errorf("expected only %s in the trash, got %+v", trashed.ID, trash.Books)
+	}
+
+	restored, err := repo.Restore(ctx, trashed.ID)
+	if err != nil {
+		t.Fatalf("error restoring book: %v", err)
+	}
+	if restored.IsDeleted() || restored.Version != 3 {
+		t.Errorf("expected a restored book at version 3, got %+v", restored)
+	}
+	got, err := repo.ReadByID(ctx, trashed.ID)
+	if err != nil {
+		t.Fatalf("error reading restored book: %v", err)
+	}
+	if !reflect.DeepEqual(restored, got) {
+		t.Errorf("expected %+v, got %+v", restored, got)
+	}
+	if _, err = repo.Restore(ctx, trashed.ID); !db.IsNotFoundError(err) {
+		t.Errorf("Restore() expected not found error, got: %v", err)
+	}
+	if _, err = repo.Restore(ctx, uuid.New()); !db.IsNotFoundError(err) {
+		t.Errorf("Restore() expected not found error, got: %v", err)
+	}
+
+	if _, err = repo.Delete(ctx, trashed.ID); err != nil {
+		t.Fatalf("error deleting book: %v", err)
+	}
+	purged, err := repo.Purge(ctx, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("error purging trash: %v", err)
+	}
+	if len(purged) != 0 {
+		t.Errorf("expected books within retention to be kept, purged %v", purged)
+	}
+	purged, err = repo.Purge(ctx, time.Now().Add(time.Second))
+	if err != nil {
+		t.Fatalf("error purging trash: %v", err)
+	}
+	if !reflect.DeepEqual(purged, []uuid.UUID{trashed.ID}) {
+		t.Errorf("expected %s to be purged, got %v", trashed.ID, purged)
+	}
+	if _, err = repo.Restore(ctx, trashed.ID); !db.IsNotFoundError(err) {
+		t.Errorf("Restore() expected not found error after purge, got: %v", err)
+	}
+	if _, err = repo.ReadByID(ctx, kept.ID); err != nil {
+		t.Errorf("error reading kept book: %v", err)
+	}
+}
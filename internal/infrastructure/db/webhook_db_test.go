@@ -0,0 +1,158 @@
+package db_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestNewInMemoryWebhookRepo(t *testing.T) {
+	testWebhookRepository(t, db.NewInMemoryWebhookRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteWebhookRepo(t *testing.T) {
+	testWebhookRepository(t, newTestSQLiteBookRepo(t).Webhooks())
+}
+
+func TestNewInMemoryDeliveryRepo(t *testing.T) {
+	testDeliveryRepository(t, db.NewInMemoryDeliveryRepo(testlog.NewTestLogger()))
+}
+
+func TestSQLiteDeliveryRepo(t *testing.T) {
+	testDeliveryRepository(t, newTestSQLiteBookRepo(t).Deliveries())
+}
+
+// testWebhookRepository asserts the behavior every domain.WebhookRepository implementation must share.
+func testWebhookRepository(t *testing.T, repo domain.WebhookRepository) {
+	t.Helper()
+	ctx := context.Background()
+	older := &domain.Webhook{URL: "https://example.com/a", Secret: "0123456789abcdef"}
+	newer := &domain.Webhook{
+		URL:    "https://example.com/b",
+		Secret: "0123456789abcdef",
+		Events: []domain.EventType{domain.EventBookCreated},
+	}
+	for _, w := range []*domain.Webhook{older, newer} {
+		if err := repo.Create(ctx, w); err != nil {
+			t.Fatalf("error storing webhook: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	newer.Events[0] = domain.EventBookDeleted
+
+	got, err := repo.ReadByID(ctx, newer.ID)
+	if err != nil {
+		t.Fatalf("error reading webhook: %v", err)
+	}
+	if got.URL != newer.URL || got.Events[0] != domain.EventBookCreated || got.CreatedAt.IsZero() {
+		t.Errorf("unexpected stored webhook: %+v", got)
+	}
+
+	all, err := repo.ReadAll(ctx)
+	if err != nil {
+		t.Fatalf("error reading webhooks: %v", err)
+	}
+	if len(all) != 2 || all[0].ID != older.ID || all[1].ID != newer.ID {
+		t.Errorf("ReadAll() expected oldest first, got %+v", all)
+	}
+
+	if err = repo.Delete(ctx, older.ID); err != nil {
+		t.Fatalf("error deleting webhook: %v", err)
+	}
+	if _, err = repo.ReadByID(ctx, older.ID); !db.IsWebhookNotFoundError(err) {
+		t.Errorf("ReadByID() expected not found error, got: %v", err)
+	}
+	if err = repo.Delete(ctx, older.ID); !db.IsWebhookNotFoundError(err) {
+		t.Errorf("Delete() expected not found error, got: %v", err)
+	}
+}
+
+// testDeliveryRepository asserts the behavior every domain.DeliveryRepository implementation must share.
+func testDeliveryRepository(t *testing.T, repo domain.DeliveryRepository) {
+	t.Helper()
+	ctx := context.Background()
+	now := time.Now().UTC()
+	webhookID, otherID := uuid.New(), uuid.New()
+	event := domain.Event{
+		At: now, ID: uuid.New(), Type: domain.EventBookCreated, BookID: uuid.New(), Title: "Dune", Version: 1,
+		Price: domain.Money{Amount: 1000, Currency: "EUR"},
+	}
+
+	later := &domain.Delivery{
+		NextAttemptAt: now.Add(-time.Second), Status: domain.DeliveryPending, Event: event, WebhookID: webhookID,
+	}
+	earlier := &domain.Delivery{
+		NextAttemptAt: now.Add(-time.Minute), Status: domain.DeliveryPending, Event: event, WebhookID: otherID,
+	}
+	future := &domain.Delivery{
+		NextAttemptAt: now.Add(time.Hour),
+		Status:        domain.DeliveryPending,
+		Event:         domain.Event{ID: uuid.New(), Type: domain.EventBookDeleted},
+		WebhookID:     webhookID,
+	}
+	for _, d := range []*domain.Delivery{later, earlier, future} {
+		if err := repo.Create(ctx, d); err != nil {
+			t.Fatalf("error storing delivery: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	duplicate := &domain.Delivery{NextAttemptAt: now, Status: domain.DeliveryPending, Event: event, WebhookID: webhookID}
+	if err := repo.Create(ctx, duplicate); err != nil {
+		t.Fatalf("error storing duplicate delivery: %v", err)
+	}
+	if duplicate.ID != later.ID || !duplicate.NextAttemptAt.Equal(later.NextAttemptAt) {
+		t.Errorf("Create() expected the stored delivery for a duplicate, got %+v", duplicate)
+	}
+
+	due, err := repo.ReadDue(ctx, now, 10)
+	if err != nil {
+		t.Fatalf("error reading due deliveries: %v", err)
+	}
+	if len(due) != 2 || due[0].ID != earlier.ID || due[1].ID != later.ID {
+		t.Errorf("ReadDue() expected the due deliveries earliest first, got %+v", due)
+	}
+	if due, err = repo.ReadDue(ctx, now, 1); err != nil || len(due) != 1 || due[0].ID != earlier.ID {
+		t.Errorf("ReadDue() expected the limit to be applied, got %+v, %v", due, err)
+	}
+
+	later.Record(domain.DeliveryAttempt{At: now, StatusCode: 200}, domain.RetryPolicy{MaxAttempts: 1})
+	if err = repo.Update(ctx, later); err != nil {
+		t.Fatalf("error updating delivery: %v", err)
+	}
+	later.Attempts[0].StatusCode = 500
+
+	list, err := repo.ReadByWebhook(ctx, webhookID)
+	if err != nil {
+		t.Fatalf("error reading deliveries: %v", err)
+	}
+	if len(list) != 2 || list[0].ID != future.ID || list[1].ID != later.ID {
+		t.Fatalf("ReadByWebhook() expected the deliveries of the webhook newest first, got %+v", list)
+	}
+	if list[1].Status != domain.DeliverySucceeded || list[1].Attempts[0].StatusCode != 200 {
+		t.Errorf("unexpected updated delivery: %+v", list[1])
+	}
+	if got := list[1].Event; got.ID != event.ID || !got.At.Equal(event.At) || got.Title != event.Title ||
+		got.Price != event.Price || got.BookID != event.BookID || got.Version != event.Version {
+		t.Errorf("unexpected stored event: %+v", got)
+	}
+
+	if err = repo.DeleteByWebhook(ctx, webhookID); err != nil {
+		t.Fatalf("error deleting deliveries: %v", err)
+	}
+	if list, err = repo.ReadByWebhook(ctx, webhookID); err != nil || len(list) != 0 {
+		t.Errorf("ReadByWebhook() expected no deliveries after DeleteByWebhook, got %+v, %v", list, err)
+	}
+	if err = repo.Update(ctx, later); !db.IsDeliveryNotFoundError(err) {
+		t.Errorf("Update() expected not found error, got: %v", err)
+	}
+	if due, err = repo.ReadDue(ctx, now, 10); err != nil || len(due) != 1 || due[0].ID != earlier.ID {
+		t.Errorf("ReadDue() expected the deliveries of other webhooks to be kept, got %+v, %v", due, err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package events delivers [domain] events to their subscribers.
+// Implements the [domain] publishing abstraction with an in-process bus.
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// Bus implements domain.EventPublisher by handing every event to the in-process subscribers of its type.
+// It is safe for concurrent use; subscribers are called synchronously, in the order they subscribed.
+type Bus struct {
+	subscriptions []subscription
+	mu            sync.RWMutex
+}
+
+type subscription struct {
+	subscriber domain.EventSubscriber
+	types      []domain.EventType
+}
+
+// SubscriberFunc adapts a function to a domain.EventSubscriber.
+type SubscriberFunc func(ctx context.Context, event *domain.Event) error
+
+// HandleEvent calls f(ctx, event).
+func (f SubscriberFunc) HandleEvent(ctx context.Context, event *domain.Event) error {
+	return f(ctx, event)
+}
+
+// NewBus creates a new Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers subscriber for the events of the given types, or for every event if no type is given.
+func (b *Bus) Subscribe(subscriber domain.EventSubscriber, types ...domain.EventType) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, subscription{subscriber: subscriber, types: types})
+}
+
+// Publish hands event to every matching subscriber, even if some of them fail.
+// The failures are joined, so the event is published again to every subscriber:
+// subscribers must be idempotent, which they need to be anyway under at-least-once delivery.
+func (b *Bus) Publish(ctx context.Context, event *domain.Event) error {
+	b.mu.RLock()
+	subscriptions := slices.Clone(b.subscriptions)
+	b.mu.RUnlock()
+
+	var err error
+	for i, s := range subscriptions {
+		if len(s.types) > 0 && !slices.Contains(s.types, event.Type) {
+			continue
+		}
+		if subErr := s.subscriber.HandleEvent(ctx, event); subErr != nil {
+			err = errors.Join(err, fmt.Errorf("subscriber %d: %w", i, subErr))
+		}
+	}
+	return err
+}
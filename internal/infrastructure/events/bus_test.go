@@ -0,0 +1,41 @@
+package events_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/events"
+)
+
+func TestBus_Publish(t *testing.T) {
+	ctx := context.Background()
+	bus := events.NewBus()
+	var received []string
+	record := func(name string, err error) events.SubscriberFunc {
+		return func(_ context.Context, e *domain.Event) error {
+			received = append(received, name+":"+string(e.Type))
+			return err
+		}
+	}
+	bus.Subscribe(record("all", nil))
+	bus.Subscribe(record("prices", nil), domain.EventBookPriceChanged)
+	bus.Subscribe(record("failing", errors.New("oops")), domain.EventBookDeleted)
+
+	if err := bus.Publish(ctx, &domain.Event{ID: uuid.New(), Type: domain.EventBookPriceChanged}); err != nil {
+		t.Errorf("Publish() unexpected error: %v", err)
+	}
+	err := bus.Publish(ctx, &domain.Event{ID: uuid.New(), Type: domain.EventBookDeleted})
+	if err == nil {
+		t.Error("Publish() expected the subscriber error")
+	}
+
+	want := []string{"all:book.price_changed", "prices:book.price_changed", "all:book.deleted", "failing:book.deleted"}
+	if !reflect.DeepEqual(received, want) {
+		t.Errorf("expected deliveries %v, got %v", want, received)
+	}
+}
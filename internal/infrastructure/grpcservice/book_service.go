@@ -0,0 +1,156 @@
+package grpcservice
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	bookshopv1 "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/gen/bookshop/v1"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+)
+
+// BookService implements bookshopv1.BookServiceServer on top of a controller.BookInteractor.
+// Requests are validated like their REST counterparts, reusing the controller request types.
+type BookService struct {
+	bookshopv1.UnimplementedBookServiceServer
+
+	interactor controller.BookInteractor
+}
+
+// NewBookService creates a new instance of BookService.
+// Failed calls are logged by the server, see NewServer.
+func NewBookService(interactor controller.BookInteractor) *BookService {
+	return &BookService{interactor: interactor}
+}
+
+// CreateBook adds a book to the catalog and returns it.
+func (s *BookService) CreateBook(
+	ctx context.Context,
+	req *bookshopv1.CreateBookRequest,
+) (*bookshopv1.CreateBookResponse, error) {
+	r := &controller.CreateBookRequest{
+		Title:    req.GetTitle(),
+		Author:   req.GetAuthor(),
+		Language: req.GetLanguage(),
+		Currency: req.GetCurrency(),
+		ISBN:     req.GetIsbn(),
+		Price:    req.GetPrice(),
+	}
+	for _, c := range req.GetAuthors() {
+		r.Authors = append(r.Authors, controller.BookAuthorRequest{AuthorID: c.GetAuthorId(), Role: c.GetRole()})
+	}
+	if err := r.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	book := r.Book()
+	if err := s.interactor.CreateBook(ctx, book); err != nil {
+		return nil, statusFromError(err)
+	}
+	return &bookshopv1.CreateBookResponse{Book: newBook(book)}, nil
+}
+
+// GetBook returns a single book of the catalog.
+func (s *BookService) GetBook(
+	ctx context.Context,
+	req *bookshopv1.GetBookRequest,
+) (*bookshopv1.GetBookResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument(errors.New("book id is required"))
+	}
+	book, err := s.interactor.GetBook(ctx, req.GetId())
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	return &bookshopv1.GetBookResponse{Book: newBook(book)}, nil
+}
+
+// ListBooks returns a page of the books of the catalog matching the request.
+func (s *BookService) ListBooks(
+	ctx context.Context,
+	req *bookshopv1.ListBooksRequest,
+) (*bookshopv1.ListBooksResponse, error) {
+	r := &controller.ListBooksRequest{
+		Cursor:   req.GetPageToken(),
+		Sort:     req.GetSort(),
+		Order:    req.GetOrder(),
+		Author:   req.GetAuthor(),
+		AuthorID: req.GetAuthorId(),
+		Language: req.GetLanguage(),
+		Limit:    int(req.GetPageSize()),
+		MinPrice: int(req.GetMinPrice()),
+		MaxPrice: int(req.GetMaxPrice()),
+	}
+	if err := r.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	page, err := s.interactor.ListBooks(ctx, r.BookQuery())
+	if err != nil {
+		return nil, statusFromError(err)
+	}
+	res := &bookshopv1.ListBooksResponse{
+		Books:         make([]*bookshopv1.Book, len(page.Books)),
+		NextPageToken: page.NextCursor,
+		TotalSize:     int32(page.Total),
+	}
+	for i, b := range page.Books {
+		res.Books[i] = newBook(b)
+	}
+	return res, nil
+}
+
+// UpdateBook changes the price of a book and returns its new version.
+// A version in the request makes the update conditional on the current version of the book.
+func (s *BookService) UpdateBook(
+	ctx context.Context,
+	req *bookshopv1.UpdateBookRequest,
+) (*bookshopv1.UpdateBookResponse, error) {
+	r := &controller.UpdateBookRequest{ID: req.GetId(), Currency: req.GetCurrency(), Price: req.GetPrice()}
+	if err := r.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	book := r.Book(int(req.GetVersion()))
+	if err := s.interactor.UpdateBook(ctx, book); err != nil {
+		return nil, statusFromError(err)
+	}
+	return &bookshopv1.UpdateBookResponse{Version: int64(book.Version)}, nil
+}
+
+// DeleteBook moves a book to the trash.
+func (s *BookService) DeleteBook(
+	ctx context.Context,
+	req *bookshopv1.DeleteBookRequest,
+) (*bookshopv1.DeleteBookResponse, error) {
+	if req.GetId() == "" {
+		return nil, invalidArgument(errors.New("book id is required"))
+	}
+	if err := s.interactor.DeleteBook(ctx, req.GetId()); err != nil {
+		return nil, statusFromError(err)
+	}
+	return &bookshopv1.DeleteBookResponse{}, nil
+}
+
+// newBook returns the message of a domain.Book.
+// IDs are prefixed with their resource type (book:, author:), like the REST API presents them.
+func newBook(book *domain.Book) *bookshopv1.Book {
+	authors := make([]*bookshopv1.BookAuthor, len(book.Authors))
+	for i, c := range book.Authors {
+		authors[i] = &bookshopv1.BookAuthor{Id: domain.AuthorResource.Format(c.AuthorID), Name: c.Name, Role: string(c.Role)}
+	}
+	return &bookshopv1.Book{
+		Id:        domain.BookResource.Format(book.ID),
+		Isbn:      book.ISBN,
+		Title:     book.Title,
+		Author:    book.Author,
+		Authors:   authors,
+		Language:  book.LanguageTag,
+		Price:     book.Price.Amount,
+		Currency:  book.Price.Currency,
+		Version:   int64(book.Version),
+		CreatedAt: timestamppb.New(book.CreatedAt),
+	}
+}
@@ -0,0 +1,271 @@
+package grpcservice_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	bookshopv1 "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/gen/bookshop/v1"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/grpcservice"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+// newTestClient serves interactor over an in-memory connection and returns a client of it.
+func newTestClient(t *testing.T, interactor *mocks.MockBookInteractor) bookshopv1.BookServiceClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpcservice.NewServer(testlog.NewTestLogger(), grpcservice.NewBookService(interactor))
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("error dialing server: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	return bookshopv1.NewBookServiceClient(conn)
+}
+
+func TestBookService(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	client := newTestClient(t, mockBookInteractor)
+	bookID := uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42")
+	authorID := uuid.MustParse("3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c")
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dune := &domain.Book{
+		ID:          bookID,
+		Title:       "Dune",
+		Author:      "Frank Herbert",
+		LanguageTag: "en",
+		ISBN:        "9780306406157",
+		Authors:     []domain.BookAuthor{{AuthorID: authorID, Name: "Frank Herbert", Role: domain.RoleAuthor}},
+		Price:       domain.Money{Amount: 1299, Currency: "EUR"},
+		CreatedAt:   createdAt,
+		Version:     1,
+	}
+	duneMessage := &bookshopv1.Book{
+		Id:        "book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42",
+		Isbn:      "9780306406157",
+		Title:     "Dune",
+		Author:    "Frank Herbert",
+		Authors:   []*bookshopv1.BookAuthor{{Id: "author:" + authorID.String(), Name: "Frank Herbert", Role: "author"}},
+		Language:  "en",
+		Price:     1299,
+		Currency:  "EUR",
+		Version:   1,
+		CreatedAt: timestamppb.New(createdAt),
+	}
+
+	tests := []struct {
+		name             string
+		call             func(ctx context.Context) (proto.Message, error)
+		mockExpectations func()
+		wantCode         codes.Code
+		wantMessage      string
+		want             proto.Message
+	}{
+		{
+			name: "fails to create an invalid book",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.CreateBook(ctx, &bookshopv1.CreateBookRequest{Title: "Dune", Author: "Frank Herbert"})
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "price must be greater than zero; currency is required",
+		},
+		{
+			name: "fails to create a book with a taken isbn",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.CreateBook(ctx, &bookshopv1.CreateBookRequest{
+					Title: "Dune", Author: "Frank Herbert", Price: 1299, Currency: "eur", Isbn: "978-0-306-40615-7",
+				})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().CreateBook(gomock.Any(), gomock.Any()).Return(domain.ErrDuplicateISBN)
+			},
+			wantCode:    codes.AlreadyExists,
+			wantMessage: domain.ErrDuplicateISBN.Error(),
+		},
+		{
+			name: "creates a book on behalf of the actor",
+			call: func(ctx context.Context) (proto.Message, error) {
+				ctx = metadata.AppendToOutgoingContext(ctx, grpcservice.ActorMetadata, "alice")
+				return client.CreateBook(ctx, &bookshopv1.CreateBookRequest{
+					Title:    "Dune",
+					Price:    1299,
+					Currency: "eur",
+					Isbn:     "9780306406157",
+					Authors:  []*bookshopv1.BookCredit{{AuthorId: authorID.String()}},
+				})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					CreateBook(gomock.Cond(func(ctx context.Context) bool { return domain.ActorFrom(ctx) == "alice" }),
+						&domain.Book{
+							Title:   "Dune",
+							ISBN:    "9780306406157",
+							Authors: []domain.BookAuthor{{AuthorID: authorID, Role: domain.RoleAuthor}},
+							Price:   domain.Money{Amount: 1299, Currency: "EUR"},
+						}).
+					DoAndReturn(func(_ context.Context, b *domain.Book) error {
+						*b = *dune
+						return nil
+					})
+			},
+			want: &bookshopv1.CreateBookResponse{Book: duneMessage},
+		},
+		{
+			name: "fails to get a missing book",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetBook(ctx, &bookshopv1.GetBookRequest{Id: bookID.String()})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().GetBook(gomock.Any(), bookID.String()).Return(nil, domain.ErrBookNotFound)
+			},
+			wantCode:    codes.NotFound,
+			wantMessage: domain.ErrBookNotFound.Error(),
+		},
+		{
+			name: "hides internal errors",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetBook(ctx, &bookshopv1.GetBookRequest{Id: bookID.String()})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().GetBook(gomock.Any(), bookID.String()).Return(nil, errors.New("disk on fire"))
+			},
+			wantCode:    codes.Internal,
+			wantMessage: "internal server error",
+		},
+		{
+			name: "gets a book",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.GetBook(ctx, &bookshopv1.GetBookRequest{Id: bookID.String()})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().GetBook(gomock.Any(), bookID.String()).Return(dune, nil)
+			},
+			want: &bookshopv1.GetBookResponse{Book: duneMessage},
+		},
+		{
+			name: "fails to list books with an invalid sort",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.ListBooks(ctx, &bookshopv1.ListBooksRequest{Sort: "isbn"})
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "sort must be one of title, author, price, created",
+		},
+		{
+			name: "lists books",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.ListBooks(ctx, &bookshopv1.ListBooksRequest{
+					PageSize: 1, PageToken: "cursor", Sort: "price", Order: "desc", Language: "en", MaxPrice: 2000,
+				})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ListBooks(gomock.Any(), domain.BookQuery{
+						SortBy:     domain.SortByPrice,
+						Descending: true,
+						Cursor:     "cursor",
+						Limit:      1,
+						Filter:     domain.BookFilter{LanguageTag: "en", MaxPrice: 2000},
+					}).
+					Return(&domain.BookPage{Books: []*domain.Book{dune}, NextCursor: "next", Total: 3}, nil)
+			},
+			want: &bookshopv1.ListBooksResponse{
+				Books: []*bookshopv1.Book{duneMessage}, NextPageToken: "next", TotalSize: 3,
+			},
+		},
+		{
+			name: "fails to update a book the interactor rejects",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.UpdateBook(ctx, &bookshopv1.UpdateBookRequest{Id: bookID.String(), Price: 999, Version: 1})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					UpdateBook(gomock.Any(), &domain.Book{ID: bookID, Price: domain.Money{Amount: 999}, Version: 1}).
+					Return(domain.NewFieldError("currency", "currency is required"))
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "currency is required",
+		},
+		{
+			name: "fails to update a stale version",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.UpdateBook(ctx, &bookshopv1.UpdateBookRequest{Id: bookID.String(), Price: 999, Version: 1})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					UpdateBook(gomock.Any(), &domain.Book{ID: bookID, Price: domain.Money{Amount: 999}, Version: 1}).
+					Return(domain.ErrVersionConflict)
+			},
+			wantCode:    codes.Aborted,
+			wantMessage: domain.ErrVersionConflict.Error(),
+		},
+		{
+			name: "updates a book",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.UpdateBook(ctx, &bookshopv1.UpdateBookRequest{Id: bookID.String(), Price: 999, Currency: "usd"})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					UpdateBook(gomock.Any(), &domain.Book{ID: bookID, Price: domain.Money{Amount: 999, Currency: "USD"}}).
+					DoAndReturn(func(_ context.Context, b *domain.Book) error {
+						b.Version = 2
+						return nil
+					})
+			},
+			want: &bookshopv1.UpdateBookResponse{Version: 2},
+		},
+		{
+			name: "fails to delete without id",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.DeleteBook(ctx, &bookshopv1.DeleteBookRequest{})
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "book id is required",
+		},
+		{
+			name: "deletes a book",
+			call: func(ctx context.Context) (proto.Message, error) {
+				return client.DeleteBook(ctx, &bookshopv1.DeleteBookRequest{Id: bookID.String()})
+			},
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().DeleteBook(gomock.Any(), bookID.String())
+			},
+			want: &bookshopv1.DeleteBookResponse{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			got, err := tt.call(context.Background())
+			if s := status.Convert(err); s.Code() != tt.wantCode || s.Message() != tt.wantMessage {
+				t.Fatalf("want status %s %q, got %s %q", tt.wantCode, tt.wantMessage, s.Code(), s.Message())
+			}
+			if tt.want != nil && !proto.Equal(got, tt.want) {
+				t.Errorf("want %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// Package grpcservice serves the bookshop.v1 gRPC API and maps its calls to the [controller] interactors.
+// It is the gRPC counterpart of the webservice package, sharing the same usecases and domain errors.
+package grpcservice
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	bookshopv1 "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/gen/bookshop/v1"
+)
+
+// ActorMetadata is the metadata key naming the actor changes are attributed to in the audit log,
+// the gRPC counterpart of the X-Actor http header.
+const ActorMetadata = "x-actor"
+
+// NewServer creates a gRPC server serving books as bookshop.v1.BookService.
+// The actor named by the ActorMetadata of each call is carried in its context, and failed calls are logged.
+func NewServer(logger *slog.Logger, books bookshopv1.BookServiceServer) *grpc.Server {
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(withActor, withLogger(logger)))
+	bookshopv1.RegisterBookServiceServer(s, books)
+	return s
+}
+
+// withActor carries the actor named by the ActorMetadata of each call in its context.
+func withActor(
+	ctx context.Context,
+	req any,
+	_ *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	if actors := metadata.ValueFromIncomingContext(ctx, ActorMetadata); len(actors) > 0 {
+		if actor := strings.TrimSpace(actors[0]); actor != "" {
+			ctx = domain.WithActor(ctx, actor)
+		}
+	}
+	return handler(ctx, req)
+}
+
+// withLogger logs every failed call with its method.
+func withLogger(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			logger.With("error", err, "method", info.FullMethod).Error("grpc call failed")
+		}
+		return res, err
+	}
+}
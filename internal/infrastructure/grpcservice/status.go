@@ -0,0 +1,54 @@
+package grpcservice
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// errInternal replaces the message of errors that are not meant to reach the caller.
+const errInternal = "internal server error"
+
+// invalidArgument returns the status of a request that failed validation.
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+// statusFromError returns the status of err, an error returned by an interactor.
+// Domain errors are mapped to the code matching the http status of the REST API,
+// context errors to CANCELED and DEADLINE_EXCEEDED; the message of any other error is hidden as INTERNAL.
+func statusFromError(err error) error {
+	var code codes.Code
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	case errors.Is(err, domain.ErrBookNotFound), errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrAuthorNotFound), errors.Is(err, domain.ErrWebhookNotFound):
+		code = codes.NotFound
+	case errors.Is(err, domain.ErrInvalidBookID), errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidQuantity), errors.Is(err, domain.ErrInvalidOrderID),
+		errors.Is(err, domain.ErrEmptyOrder), errors.Is(err, domain.ErrInvalidAuthorID),
+		errors.Is(err, domain.ErrInvalidISBN), errors.Is(err, domain.ErrInvalidWebhookID),
+		errors.Is(err, domain.ErrInvalidID), errors.Is(err, domain.ErrWrongResourceType),
+		errors.Is(err, domain.ErrValidation):
+		code = codes.InvalidArgument
+	case errors.Is(err, domain.ErrVersionConflict):
+		code = codes.Aborted
+	case errors.Is(err, domain.ErrDuplicateISBN):
+		code = codes.AlreadyExists
+	case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrIllegalOrderTransition),
+		errors.Is(err, domain.ErrAuthorHasBooks), errors.Is(err, domain.ErrCurrencyMismatch):
+		code = codes.FailedPrecondition
+	case errors.Is(err, domain.ErrTooManyImportRows):
+		code = codes.ResourceExhausted
+	default:
+		return status.Error(codes.Internal, errInternal)
+	}
+	return status.Error(code, err.Error())
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// IndexedBookRepo decorates a domain.BookRepository, keeping an InvertedIndex in sync with every successful write.
+type IndexedBookRepo struct {
+	repo  domain.BookRepository
+	index *InvertedIndex
+}
+
+// NewIndexedBookRepo creates a new instance of IndexedBookRepo, implementing domain.BookRepository.
+// The index is populated with the books already stored in repo.
+func NewIndexedBookRepo(ctx context.Context, repo domain.BookRepository, index *InvertedIndex) (*IndexedBookRepo, error) {
+	books, err := repo.ReadAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range books {
+		index.Index(b)
+	}
+	return &IndexedBookRepo{repo: repo, index: index}, nil
+}
+
+// Create a new book entry and index it.
+func (r *IndexedBookRepo) Create(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
+	if err := r.repo.Create(ctx, book, events...); err != nil {
+		return err
+	}
+	r.index.Index(book)
+	return nil
+}
+
+// CreateAll creates every book, or none of them if any fails, and indexes them.
+func (r *IndexedBookRepo) CreateAll(ctx context.Context, books []*domain.Book, events ...*domain.Event) error {
+	if err := r.repo.CreateAll(ctx, books, events...); err != nil {
+		return err
+	}
+	for _, b := range books {
+		r.index.Index(b)
+	}
+	return nil
+}
+
+// ReadByID return a single book that matches the given ID.
+func (r *IndexedBookRepo) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Book, error) {
+	return r.repo.ReadByID(ctx, id)
+}
+
+// ReadByISBN return the single book with the given normalized ISBN.
+func (r *IndexedBookRepo) ReadByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	return r.repo.ReadByISBN(ctx, isbn)
+}
+
+// ReadAll return a list of books.
+func (r *IndexedBookRepo) ReadAll(ctx context.Context) ([]*domain.Book, error) {
+	return r.repo.ReadAll(ctx)
+}
+
+// ReadPage return the page of books matching query.
+func (r *IndexedBookRepo) ReadPage(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
+	return r.repo.ReadPage(ctx, query)
+}
+
+// Each calls fn with every book, oldest first and then by ID, and stops at the first error fn returns.
+func (r *IndexedBookRepo) Each(ctx context.Context, withTrashed bool, fn func(*domain.Book) error) error {
+	return r.repo.Each(ctx, withTrashed, fn)
+}
+
+// Update a book by ID and re-index the stored result.
+func (r *IndexedBookRepo) Update(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
+	if err := r.repo.Update(ctx, book, events...); err != nil {
+		return err
+	}
+	// The underlying repository decides which fields are updated, index what it actually stored.
+	// The write already happened, so the index must follow even if the caller gave up.
+	stored, err := r.repo.ReadByID(context.WithoutCancel(ctx), book.ID)
+	if err != nil {
+		return err
+	}
+	r.index.Index(stored)
+	return nil
+}
+
+// Delete moves a single book, matched by ID, to the trash and removes it from the index.
+func (r *IndexedBookRepo) Delete(ctx context.Context, id uuid.UUID, events ...*domain.Event) (*domain.Book, error) {
+	book, err := r.repo.Delete(ctx, id, events...)
+	if err != nil {
+		return nil, err
+	}
+	r.index.Remove(id)
+	return book, nil
+}
+
+// Restore moves a single trashed book, matched by ID, back to the catalog and indexes it again.
+func (r *IndexedBookRepo) Restore(ctx context.Context, id uuid.UUID, events ...*domain.Event) (*domain.Book, error) {
+	book, err := r.repo.Restore(ctx, id, events...)
+	if err != nil {
+		return nil, err
+	}
+	r.index.Index(book)
+	return book, nil
+}
+
+// Purge removes for good every book trashed before deletedBefore.
+// Trashed books are not indexed, so the index is left untouched.
+func (r *IndexedBookRepo) Purge(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	return r.repo.Purge(ctx, deletedBefore)
+}
@@ -0,0 +1,64 @@
+package search_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/search"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestIndexedBookRepo(t *testing.T) {
+	ctx := context.Background()
+	inner := db.NewInMemoryBookRepo(testlog.NewTestLogger())
+	existing := &domain.Book{Title: "Emma", Author: "Jane Austen", Price: domain.Money{Amount: 10, Currency: "EUR"}}
+	if err := inner.Create(ctx, existing); err != nil {
+		t.Fatalf("error creating book: %v", err)
+	}
+
+	ix := search.NewInvertedIndex()
+	repo, err := search.NewIndexedBookRepo(ctx, inner, ix)
+	if err != nil {
+		t.Fatalf("NewIndexedBookRepo() error = %v", err)
+	}
+
+	assertSearch := func(t *testing.T, query string, want []uuid.UUID) {
+		t.Helper()
+		got, err := ix.Search(ctx, query, 10)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Search(%q) got = %v, want %v", query, got, want)
+		}
+	}
+
+	assertSearch(t, "austen", []uuid.UUID{existing.ID})
+
+	created := &domain.Book{Title: "Persuasion", Author: "Jane Austen", Price: domain.Money{Amount: 12, Currency: "EUR"}}
+	if err = repo.Create(ctx, created); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	assertSearch(t, "persuasion", []uuid.UUID{created.ID})
+
+	updated := *created
+	updated.Title = "Persuasion (Annotated)"
+	if err = repo.Update(ctx, &updated); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	assertSearch(t, "annotated", []uuid.UUID{created.ID})
+
+	if _, err = repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	assertSearch(t, "persuasion", []uuid.UUID{})
+
+	if _, err = repo.Delete(ctx, created.ID); !db.IsNotFoundError(err) {
+		t.Errorf("Delete() expected not found error, got: %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+// Package search provides full-text search over the catalog.
+// Implements the [domain] search abstraction with an in-memory inverted index.
+package search
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// Field weights: a match in the title is worth more than a match in the author.
+const (
+	titleWeight  = 2
+	authorWeight = 1
+)
+
+// InvertedIndex implements domain.BookSearchIndex, mapping normalized terms to the books containing them.
+// It is safe for concurrent use.
+type InvertedIndex struct {
+	// postings maps a term to the weighted term frequency of each book containing it.
+	postings map[string]map[uuid.UUID]float64
+	// terms maps a book to its indexed terms, so it can be removed from postings.
+	terms map[uuid.UUID][]string
+	mu    sync.RWMutex
+}
+
+// NewInvertedIndex creates a new empty InvertedIndex.
+func NewInvertedIndex() *InvertedIndex {
+	return &InvertedIndex{
+		postings: make(map[string]map[uuid.UUID]float64),
+		terms:    make(map[uuid.UUID][]string),
+	}
+}
+
+// Index adds book to the index, replacing any previous entry with the same ID.
+func (ix *InvertedIndex) Index(book *domain.Book) {
+	weights := make(map[string]float64)
+	for _, t := range Tokenize(book.Title) {
+		weights[t] += titleWeight
+	}
+	for _, t := range Tokenize(book.Author) {
+		weights[t] += authorWeight
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(book.ID)
+	terms := make([]string, 0, len(weights))
+	for t, w := range weights {
+		if ix.postings[t] == nil {
+			ix.postings[t] = make(map[uuid.UUID]float64)
+		}
+		ix.postings[t][book.ID] = w
+		terms = append(terms, t)
+	}
+	ix.terms[book.ID] = terms
+}
+
+// Remove deletes the book matching id from the index.
+func (ix *InvertedIndex) Remove(id uuid.UUID) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(id)
+}
+
+func (ix *InvertedIndex) remove(id uuid.UUID) {
+	for _, t := range ix.terms[id] {
+		delete(ix.postings[t], id)
+		if len(ix.postings[t]) == 0 {
+			delete(ix.postings, t)
+		}
+	}
+	delete(ix.terms, id)
+}
+
+// Search returns the IDs of at most limit books matching any term of query, most relevant first.
+// Relevance is the sum, over the query terms, of the weighted term frequency times
+// the inverse document frequency of the term. Ties are broken by ID.
+func (ix *InvertedIndex) Search(ctx context.Context, query string, limit int) ([]uuid.UUID, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ix.mu.RLock()
+	scores := make(map[uuid.UUID]float64)
+	total := float64(len(ix.terms))
+	for _, t := range slices.Compact(slices.Sorted(slices.Values(Tokenize(query)))) {
+		docs := ix.postings[t]
+		idf := math.Log(1 + total/float64(len(docs)))
+		for id, w := range docs {
+			scores[id] += w * idf
+		}
+	}
+	ix.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b uuid.UUID) int {
+		return cmp.Or(cmp.Compare(scores[b], scores[a]), strings.Compare(a.String(), b.String()))
+	})
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	return ids, nil
+}
+
+// Tokenize splits s into search terms.
+// Text is Unicode-normalized, stripped of diacritics and lowercased,
+// then split on anything that is not a letter or a number.
+func Tokenize(s string) []string {
+	t := transform.Chain(norm.NFKD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(t, s)
+	if err != nil {
+		normalized = s
+	}
+	return strings.FieldsFunc(strings.ToLower(normalized), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
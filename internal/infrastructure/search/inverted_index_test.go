@@ -0,0 +1,97 @@
+package search_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/search"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{name: "lowercases and splits on punctuation", in: "The Lord of the Rings: Vol. 1", want: []string{
+			"the", "lord", "of", "the", "rings", "vol", "1",
+		}},
+		{name: "strips diacritics", in: "Gabriel García Márquez", want: []string{"gabriel", "garcia", "marquez"}},
+		{name: "normalizes compatibility characters", in: "ﬁnal Ⅳ", want: []string{"final", "iv"}},
+		{name: "keeps non latin scripts", in: "Преступление и наказание", want: []string{"преступление", "и", "наказание"}},
+		{name: "returns nothing for symbols only", in: "!!! ---", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := search.Tokenize(tt.in); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() got = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInvertedIndex_Search(t *testing.T) {
+	ctx := context.Background()
+	dune := &domain.Book{ID: uuid.New(), Title: "Dune", Author: "Frank Herbert"}
+	messiah := &domain.Book{ID: uuid.New(), Title: "Dune Messiah", Author: "Frank Herbert"}
+	rose := &domain.Book{ID: uuid.New(), Title: "Il nome della rosa", Author: "Umberto Eco"}
+	frank := &domain.Book{ID: uuid.New(), Title: "The Diary of Anne Frank", Author: "Anne Frank"}
+
+	ix := search.NewInvertedIndex()
+	for _, b := range []*domain.Book{dune, messiah, rose, frank} {
+		ix.Index(b)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []uuid.UUID
+	}{
+		{name: "matches title terms", query: "messiah", limit: 10, want: []uuid.UUID{messiah.ID}},
+		{name: "matches author terms ignoring case and accents", query: "ÉCO", limit: 10, want: []uuid.UUID{rose.ID}},
+		{name: "ranks title matches above author matches", query: "frank", limit: 1, want: []uuid.UUID{frank.ID}},
+		{
+			name:  "ranks books matching more terms first",
+			query: "dune messiah",
+			limit: 10,
+			want:  []uuid.UUID{messiah.ID, dune.ID},
+		},
+		{name: "returns nothing for unknown terms", query: "tolkien", limit: 10, want: []uuid.UUID{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ix.Search(ctx, tt.query, tt.limit)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("forgets removed and re-indexed books", func(t *testing.T) {
+		ix.Remove(rose.ID)
+		ix.Index(&domain.Book{ID: dune.ID, Title: "Dune (Deluxe Edition)", Author: "Frank Herbert"})
+
+		got, err := ix.Search(ctx, "eco", 10)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if len(got) != 0 {
+			t.Errorf("Search() expected removed book to be gone, got %v", got)
+		}
+		got, err = ix.Search(ctx, "deluxe", 10)
+		if err != nil {
+			t.Fatalf("Search() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, []uuid.UUID{dune.ID}) {
+			t.Errorf("Search() got = %v, want re-indexed book", got)
+		}
+	})
+}
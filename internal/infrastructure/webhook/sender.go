@@ -0,0 +1,125 @@
+// Package webhook delivers [domain] events to the URL of external webhooks.
+// Implements the [domain] webhook sending abstraction over HTTP.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// Headers set on every delivery.
+const (
+	// SignatureHeader carries the signature of the body, computed by Sign with the secret of the webhook.
+	SignatureHeader = "X-Bookshop-Signature"
+	// EventHeader carries the type of the delivered event.
+	EventHeader = "X-Bookshop-Event"
+	// DeliveryHeader carries the ID of the delivery, which stays the same across retries.
+	DeliveryHeader = "X-Bookshop-Delivery"
+)
+
+// HTTPSender implements domain.WebhookSender by POSTing a signed JSON payload to the URL of the webhook.
+// Receivers answering with a 2xx status accept the delivery, any other outcome is a failed attempt.
+type HTTPSender struct {
+	client *http.Client
+	logger *slog.Logger
+}
+
+// NewHTTPSender creates a new instance of HTTPSender, giving up on each attempt after timeout.
+func NewHTTPSender(logger *slog.Logger, timeout time.Duration) *HTTPSender {
+	return &HTTPSender{client: &http.Client{Timeout: timeout}, logger: logger}
+}
+
+// payload is the JSON body of a delivery.
+type payload struct {
+	PreviousPrice    *int64           `json:"previous_price,omitempty"`
+	ID               string           `json:"id"`
+	Type             domain.EventType `json:"type"`
+	OccurredAt       string           `json:"occurred_at"`
+	BookID           string           `json:"book_id"`
+	Title            string           `json:"title"`
+	Currency         string           `json:"currency"`
+	PreviousCurrency string           `json:"previous_currency,omitempty"`
+	Price            int64            `json:"price"`
+	Version          int              `json:"version"`
+}
+
+// Send makes a single attempt to deliver the event of delivery to webhook and returns its outcome.
+// The body is signed with the secret of the webhook, see Sign.
+func (s *HTTPSender) Send(
+	ctx context.Context,
+	webhook *domain.Webhook,
+	delivery *domain.Delivery,
+) domain.DeliveryAttempt {
+	body, err := json.Marshal(newPayload(&delivery.Event))
+	if err != nil {
+		return failed(err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return failed(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+	req.Header.Set(EventHeader, string(delivery.Event.Type))
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return failed(err)
+	}
+	defer func() {
+		if err = res.Body.Close(); err != nil {
+			s.logger.With("error", err, "delivery_id", delivery.ID).Warn("failed to close response body")
+		}
+	}()
+	// Draining the body lets the connection be reused; receivers are not expected to answer with anything.
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	attempt := domain.DeliveryAttempt{At: time.Now().UTC(), StatusCode: res.StatusCode}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		attempt.Error = "unexpected status " + strconv.Itoa(res.StatusCode)
+	}
+	return attempt
+}
+
+// Sign returns the signature of body with secret, as sent in the SignatureHeader:
+// the hex encoded HMAC-SHA256 of body, prefixed with "sha256=".
+// Receivers verify a delivery by signing the raw body with their copy of the secret and comparing the two.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newPayload(e *domain.Event) payload {
+	p := payload{
+		ID:         domain.EventResource.Format(e.ID),
+		Type:       e.Type,
+		OccurredAt: e.At.Format(time.RFC3339Nano),
+		BookID:     domain.BookResource.Format(e.BookID),
+		Version:    e.Version,
+		Title:      e.Title,
+		Price:      e.Price.Amount,
+		Currency:   e.Price.Currency,
+	}
+	if e.Type == domain.EventBookPriceChanged {
+		p.PreviousPrice = &e.PreviousPrice.Amount
+		p.PreviousCurrency = e.PreviousPrice.Currency
+	}
+	return p
+}
+
+func failed(err error) domain.DeliveryAttempt {
+	return domain.DeliveryAttempt{At: time.Now().UTC(), Error: err.Error()}
+}
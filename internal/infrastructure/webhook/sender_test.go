@@ -0,0 +1,119 @@
+package webhook_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webhook"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestHTTPSender_Send(t *testing.T) {
+	const secret = "0123456789abcdef"
+	bookID := uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42")
+	eventID := uuid.MustParse("0b7c4b2e-6d1f-4f0a-8d3a-7e9c2a1b5c33")
+	delivery := &domain.Delivery{
+		ID: uuid.MustParse("9d2e6f10-3b7a-4c5d-8e1f-2a3b4c5d6e7f"),
+		Event: domain.Event{
+			At:            time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+			Type:          domain.EventBookPriceChanged,
+			Title:         "Dune",
+			Price:         domain.Money{Amount: 1299, Currency: "EUR"},
+			PreviousPrice: domain.Money{Amount: 999, Currency: "EUR"},
+			ID:            eventID,
+			BookID:        bookID,
+			Version:       2,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		wantCode int
+		wantErr  bool
+	}{
+		{name: "2xx is a success", status: http.StatusNoContent, wantCode: http.StatusNoContent},
+		{name: "other status is a failure", status: http.StatusBadGateway, wantCode: http.StatusBadGateway, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received map[string]any
+			receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					t.Errorf("failed to read delivery: %v", err)
+				}
+				want := webhook.Sign(secret, body)
+				if got := r.Header.Get(webhook.SignatureHeader); !hmac.Equal([]byte(got), []byte(want)) {
+					t.Errorf("expected signature %q, got %q", want, got)
+				}
+				if got := r.Header.Get(webhook.EventHeader); got != string(domain.EventBookPriceChanged) {
+					t.Errorf("unexpected event header %q", got)
+				}
+				if got := r.Header.Get(webhook.DeliveryHeader); got != delivery.ID.String() {
+					t.Errorf("unexpected delivery header %q", got)
+				}
+				if err = json.Unmarshal(body, &received); err != nil {
+					t.Errorf("failed to decode delivery: %v", err)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer receiver.Close()
+
+			sender := webhook.NewHTTPSender(testlog.NewTestLogger(), time.Second)
+			attempt := sender.Send(context.Background(), &domain.Webhook{URL: receiver.URL, Secret: secret}, delivery)
+			if attempt.StatusCode != tt.wantCode || attempt.Failed() != tt.wantErr || attempt.At.IsZero() {
+				t.Errorf("unexpected attempt: %+v", attempt)
+			}
+
+			want := map[string]any{
+				"id":                domain.EventResource.Format(eventID),
+				"type":              "book.price_changed",
+				"occurred_at":       "2024-05-01T12:00:00Z",
+				"book_id":           "book:" + bookID.String(),
+				"version":           float64(2),
+				"title":             "Dune",
+				"price":             float64(1299),
+				"currency":          "EUR",
+				"previous_price":    float64(999),
+				"previous_currency": "EUR",
+			}
+			if !reflect.DeepEqual(received, want) {
+				t.Errorf("expected payload %v, got %v", want, received)
+			}
+		})
+	}
+}
+
+func TestHTTPSender_Send_Unreachable(t *testing.T) {
+	receiver := httptest.NewServer(http.NotFoundHandler())
+	receiver.Close()
+
+	sender := webhook.NewHTTPSender(testlog.NewTestLogger(), time.Second)
+	attempt := sender.Send(
+		context.Background(),
+		&domain.Webhook{URL: receiver.URL, Secret: "0123456789abcdef"},
+		&domain.Delivery{ID: uuid.New(), Event: domain.Event{ID: uuid.New(), Type: domain.EventBookCreated}},
+	)
+	if !attempt.Failed() || attempt.StatusCode != 0 {
+		t.Errorf("expected a failed attempt without status, got %+v", attempt)
+	}
+}
+
+func TestSign(t *testing.T) {
+	// Reference value computed with: printf '{}' | openssl dgst -sha256 -hmac 0123456789abcdef
+	want := "sha256=f91e3e9f05cc2df64ac1c26f8adccdffda8d1e4a7a8c50a1a08eeadac6ddfec5"
+	if got := webhook.Sign("0123456789abcdef", []byte("{}")); got != want {
+		t.Errorf("Sign() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,554 @@
+package webservice
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+)
+
+// GraphQLErrorPresenter is the interface a presenter must implement
+// to be used by GraphQL to report the errors of an operation.
+type GraphQLErrorPresenter interface {
+	// Status returns the HTTP status code of err, and the message it can be reported with.
+	// code is the status of errors that are not one of the known types.
+	Status(err error, code int) (int, string)
+	// Code returns the stable code err is reported with under status.
+	Code(err error, status int) string
+}
+
+// GraphQL serves the catalog as a GraphQL API on top of a controller.BookInteractor,
+// along with the authors and the stock of the books, so they can be fetched in a single round trip.
+// Arguments are validated like their REST counterparts, reusing the controller request types.
+type GraphQL struct {
+	interactor   controller.BookInteractor
+	authors      controller.AuthorInteractor
+	inventory    controller.InventoryInteractor
+	errPresenter GraphQLErrorPresenter
+	logger       *slog.Logger
+	schema       graphql.Schema
+}
+
+// graphQLRequest is the body of a GraphQL request.
+type graphQLRequest struct {
+	Variables     map[string]any `json:"variables"`
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName"`
+}
+
+// graphQLError is the error of a failed GraphQL field, carrying in its extensions
+// the code and the HTTP status the REST API would have answered with.
+type graphQLError struct {
+	message string
+	code    string
+	status  int
+}
+
+// Error implements error.
+func (e *graphQLError) Error() string {
+	return e.message
+}
+
+// Extensions implements gqlerrors.ExtendedError.
+func (e *graphQLError) Extensions() map[string]any {
+	return map[string]any{
+		"code":   e.code,
+		"status": e.status,
+	}
+}
+
+// NewGraphQL creates a new instance of GraphQL.
+// Queries read a book by ID, a page of the books matching some filters, with their stock,
+// and authors by ID or all of them; mutations create, update and delete books.
+func NewGraphQL(
+	logger *slog.Logger,
+	interactor controller.BookInteractor,
+	authors controller.AuthorInteractor,
+	inventory controller.InventoryInteractor,
+	errPresenter GraphQLErrorPresenter,
+) (*GraphQL, error) {
+	g := &GraphQL{
+		interactor:   interactor,
+		authors:      authors,
+		inventory:    inventory,
+		errPresenter: errPresenter,
+		logger:       logger,
+	}
+	schema, err := g.newSchema()
+	if err != nil {
+		return nil, err
+	}
+	g.schema = schema
+	return g, nil
+}
+
+// ServeHTTP executes the GraphQL operation in the JSON body of r.
+// Malformed requests are rejected with http.StatusBadRequest, while the operations that were executed
+// are answered with http.StatusOK, reporting their errors next to the data they could resolve.
+func (g *GraphQL) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	switch {
+	case err != nil:
+		g.logger.With("error", err).Error("unable to decode request body")
+		g.writeError(w, err)
+		return
+	case req.Query == "":
+		g.writeError(w, errors.New("query is required"))
+		return
+	}
+
+	res := graphql.Do(graphql.Params{
+		Schema:         g.schema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        r.Context(),
+	})
+	g.writeJSON(w, http.StatusOK, res)
+}
+
+// writeError rejects a malformed request, reporting err like the errors of an operation.
+func (g *GraphQL) writeError(w http.ResponseWriter, err error) {
+	g.writeJSON(w, http.StatusBadRequest, map[string]any{"errors": []map[string]any{{"message": err.Error()}}})
+}
+
+func (g *GraphQL) writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		g.logger.With("error", err).Error("failed to write graphql response")
+	}
+}
+
+// newSchema defines the types of the schema and binds its fields to the interactors.
+func (g *GraphQL) newSchema() (graphql.Schema, error) {
+	author := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Author",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":      &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+	stock := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Stock",
+		Fields: graphql.Fields{
+			"onHand":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"reserved":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"available": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"updatedAt": &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+	bookAuthor := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BookAuthor",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"role": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	book := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Book",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"isbn":      &graphql.Field{Type: graphql.String},
+			"title":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"author":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"authors":   &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(bookAuthor)))},
+			"language":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"price":     &graphql.Field{Type: graphql.NewNonNull(long)},
+			"currency":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"version":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"stock":     &graphql.Field{Type: graphql.NewNonNull(stock), Resolve: g.resolveStock},
+		},
+	})
+	bookPage := graphql.NewObject(graphql.ObjectConfig{
+		Name: "BookPage",
+		Fields: graphql.Fields{
+			"books":      &graphql.Field{Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(book)))},
+			"nextCursor": &graphql.Field{Type: graphql.String},
+			"total":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	bookCredit := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "BookCreditInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"authorId": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.ID)},
+			"role":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	createBook := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateBookInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"author":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"authors":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(bookCredit))},
+			"language": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"price":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(long)},
+			"currency": &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"isbn":     &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+	updateBook := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "UpdateBookInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":    &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"author":   &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"authors":  &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(bookCredit))},
+			"language": &graphql.InputObjectFieldConfig{Type: graphql.String},
+			"price":    &graphql.InputObjectFieldConfig{Type: long},
+			"currency": &graphql.InputObjectFieldConfig{Type: graphql.String},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"book": &graphql.Field{
+					Type:    book,
+					Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+					Resolve: g.resolveBook,
+				},
+				"books": &graphql.Field{
+					Type: graphql.NewNonNull(bookPage),
+					Args: graphql.FieldConfigArgument{
+						"limit":    {Type: graphql.Int},
+						"cursor":   {Type: graphql.String},
+						"sort":     {Type: graphql.String},
+						"order":    {Type: graphql.String},
+						"author":   {Type: graphql.String},
+						"authorId": {Type: graphql.ID},
+						"language": {Type: graphql.String},
+						"minPrice": {Type: long},
+						"maxPrice": {Type: long},
+					},
+					Resolve: g.resolveBooks,
+				},
+				"author": &graphql.Field{
+					Type:    author,
+					Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+					Resolve: g.resolveAuthor,
+				},
+				"authors": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(author))),
+					Resolve: g.resolveAuthors,
+				},
+			},
+		}),
+		Mutation: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Mutation",
+			Fields: graphql.Fields{
+				"createBook": &graphql.Field{
+					Type:    graphql.NewNonNull(book),
+					Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(createBook)}},
+					Resolve: g.resolveCreateBook,
+				},
+				"updateBook": &graphql.Field{
+					Type: graphql.NewNonNull(book),
+					Args: graphql.FieldConfigArgument{
+						"id":      {Type: graphql.NewNonNull(graphql.ID)},
+						"input":   {Type: graphql.NewNonNull(updateBook)},
+						"version": {Type: graphql.Int},
+					},
+					Resolve: g.resolveUpdateBook,
+				},
+				"deleteBook": &graphql.Field{
+					Type:    graphql.NewNonNull(graphql.ID),
+					Args:    graphql.FieldConfigArgument{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+					Resolve: g.resolveDeleteBook,
+				},
+			},
+		}),
+	})
+}
+
+// resolveBook reads a single book by its ID.
+func (g *GraphQL) resolveBook(p graphql.ResolveParams) (any, error) {
+	id := stringArg(p.Args, "id")
+	b, err := g.interactor.GetBook(p.Context, id)
+	if err != nil {
+		g.logger.With("book_id", id, "error", err).Error("error getting book")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	return bookView(b), nil
+}
+
+// resolveBooks reads a page of the books matching the arguments.
+func (g *GraphQL) resolveBooks(p graphql.ResolveParams) (any, error) {
+	r := &controller.ListBooksRequest{
+		Cursor:   stringArg(p.Args, "cursor"),
+		Sort:     stringArg(p.Args, "sort"),
+		Order:    stringArg(p.Args, "order"),
+		Author:   stringArg(p.Args, "author"),
+		AuthorID: stringArg(p.Args, "authorId"),
+		Language: stringArg(p.Args, "language"),
+		Limit:    intArg(p.Args, "limit"),
+		MinPrice: int(longArg(p.Args, "minPrice")),
+		MaxPrice: int(longArg(p.Args, "maxPrice")),
+	}
+	if err := r.Validate(); err != nil {
+		return nil, g.fail(err, http.StatusBadRequest)
+	}
+
+	page, err := g.interactor.ListBooks(p.Context, r.BookQuery())
+	if err != nil {
+		g.logger.With("error", err).Error("error listing books")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	books := make([]map[string]any, len(page.Books))
+	for i, b := range page.Books {
+		books[i] = bookView(b)
+	}
+	var next any
+	if page.NextCursor != "" {
+		next = page.NextCursor
+	}
+	return map[string]any{"books": books, "nextCursor": next, "total": page.Total}, nil
+}
+
+// resolveStock reads the stock of the book the field belongs to.
+func (g *GraphQL) resolveStock(p graphql.ResolveParams) (any, error) {
+	b, _ := p.Source.(map[string]any)
+	id := stringArg(b, "id")
+	s, err := g.inventory.GetStock(p.Context, id)
+	if err != nil {
+		g.logger.With("book_id", id, "error", err).Error("error getting stock")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	var updatedAt any
+	if !s.UpdatedAt.IsZero() {
+		updatedAt = s.UpdatedAt
+	}
+	return map[string]any{
+		"onHand":    s.OnHand,
+		"reserved":  s.Reserved,
+		"available": s.Available(),
+		"updatedAt": updatedAt,
+	}, nil
+}
+
+// resolveAuthor reads a single author by its ID.
+func (g *GraphQL) resolveAuthor(p graphql.ResolveParams) (any, error) {
+	id := stringArg(p.Args, "id")
+	a, err := g.authors.GetAuthor(p.Context, id)
+	if err != nil {
+		g.logger.With("author_id", id, "error", err).Error("error getting author")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	return authorView(a), nil
+}
+
+// resolveAuthors reads every author, sorted by name.
+func (g *GraphQL) resolveAuthors(p graphql.ResolveParams) (any, error) {
+	list, err := g.authors.ListAuthors(p.Context)
+	if err != nil {
+		g.logger.With("error", err).Error("error listing authors")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	authors := make([]map[string]any, len(list))
+	for i, a := range list {
+		authors[i] = authorView(a)
+	}
+	return authors, nil
+}
+
+// resolveCreateBook adds the book of the input to the catalog.
+func (g *GraphQL) resolveCreateBook(p graphql.ResolveParams) (any, error) {
+	input, _ := p.Args["input"].(map[string]any)
+	r := &controller.CreateBookRequest{
+		Title:    stringArg(input, "title"),
+		Author:   stringArg(input, "author"),
+		Language: stringArg(input, "language"),
+		Currency: stringArg(input, "currency"),
+		ISBN:     stringArg(input, "isbn"),
+		Authors:  creditsArg(input),
+		Price:    longArg(input, "price"),
+	}
+	if err := r.Validate(); err != nil {
+		return nil, g.fail(err, http.StatusBadRequest)
+	}
+
+	b := r.Book()
+	if err := g.interactor.CreateBook(p.Context, b); err != nil {
+		g.logger.With("error", err).Error("error creating book")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	return bookView(b), nil
+}
+
+// resolveUpdateBook changes the fields of a book set in the input, like a JSON merge patch would.
+// A version makes the update conditional on the current version of the book.
+func (g *GraphQL) resolveUpdateBook(p graphql.ResolveParams) (any, error) {
+	id := stringArg(p.Args, "id")
+	input, _ := p.Args["input"].(map[string]any)
+	r := &controller.PatchBookRequest{
+		Title:    optionalString(input, "title"),
+		Author:   optionalString(input, "author"),
+		Language: optionalString(input, "language"),
+		Currency: optionalString(input, "currency"),
+	}
+	if price, ok := input["price"].(int64); ok {
+		r.Price = &price
+	}
+	if _, ok := input["authors"].([]any); ok {
+		authors := creditsArg(input)
+		r.Authors = &authors
+	}
+	if err := r.Validate(); err != nil {
+		return nil, g.fail(err, http.StatusBadRequest)
+	}
+
+	b, err := g.interactor.PatchBook(p.Context, id, r.BookPatch(), intArg(p.Args, "version"))
+	if err != nil {
+		g.logger.With("book_id", id, "error", err).Error("error patching book")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	return bookView(b), nil
+}
+
+// resolveDeleteBook moves a book to the trash and returns its ID.
+func (g *GraphQL) resolveDeleteBook(p graphql.ResolveParams) (any, error) {
+	id := stringArg(p.Args, "id")
+	if err := g.interactor.DeleteBook(p.Context, id); err != nil {
+		g.logger.With("book_id", id, "error", err).Error("error deleting book")
+		return nil, g.fail(err, http.StatusInternalServerError)
+	}
+	// The book was found, so its id is valid.
+	return domain.BookResource.Format(domain.BookResource.MustParse(id)), nil
+}
+
+// fail converts err to a graphQLError, with the status, message and code the error presenter reports it with.
+// Errors that are not one of the known types have the status code.
+func (g *GraphQL) fail(err error, code int) error {
+	status, msg := g.errPresenter.Status(err, code)
+	return &graphQLError{message: msg, code: g.errPresenter.Code(err, status), status: status}
+}
+
+// bookView returns the GraphQL object of a domain.Book.
+// IDs are prefixed with their resource type (book:, author:), like the REST API presents them.
+func bookView(b *domain.Book) map[string]any {
+	authors := make([]map[string]any, len(b.Authors))
+	for i, c := range b.Authors {
+		authors[i] = map[string]any{"id": domain.AuthorResource.Format(c.AuthorID), "name": c.Name, "role": string(c.Role)}
+	}
+	var isbn any
+	if b.ISBN != "" {
+		isbn = b.ISBN
+	}
+	return map[string]any{
+		"id":        domain.BookResource.Format(b.ID),
+		"isbn":      isbn,
+		"title":     b.Title,
+		"author":    b.Author,
+		"authors":   authors,
+		"language":  b.LanguageTag,
+		"price":     b.Price.Amount,
+		"currency":  b.Price.Currency,
+		"version":   b.Version,
+		"createdAt": b.CreatedAt,
+	}
+}
+
+// authorView returns the GraphQL object of a domain.Author.
+func authorView(a *domain.Author) map[string]any {
+	return map[string]any{
+		"id":        domain.AuthorResource.Format(a.ID),
+		"name":      a.Name,
+		"createdAt": a.CreatedAt,
+	}
+}
+
+// long is a 64-bit integer scalar, for the amounts that do not fit the 32 bits of the GraphQL Int,
+// such as prices in minor units. It is written as a JSON number; since JSON numbers are read as
+// float64, variables beyond ±2^53 must be given as strings.
+var long = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Long",
+	Description: "A 64-bit signed integer, e.g. a price in minor units.",
+	Serialize:   coerceLong,
+	ParseValue:  coerceLong,
+	ParseLiteral: func(v ast.Value) any {
+		switch v := v.(type) {
+		case *ast.IntValue:
+			return coerceLong(v.Value)
+		case *ast.StringValue:
+			return coerceLong(v.Value)
+		}
+		return nil
+	},
+})
+
+// coerceLong returns v as an int64, or nil if it is not an integer that fits in 64 bits.
+func coerceLong(v any) any {
+	switch v := v.(type) {
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int64:
+		return v
+	case float64:
+		if v != math.Trunc(v) || math.Abs(v) > 1<<53 {
+			return nil
+		}
+		return int64(v)
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	}
+	return nil
+}
+
+// stringArg returns the string argument name, or an empty string if it is missing.
+func stringArg(args map[string]any, name string) string {
+	v, _ := args[name].(string)
+	return v
+}
+
+// optionalString returns the string argument name, or nil if it is missing.
+func optionalString(args map[string]any, name string) *string {
+	v, ok := args[name].(string)
+	if !ok {
+		return nil
+	}
+	return &v
+}
+
+// intArg returns the integer argument name, or zero if it is missing.
+func intArg(args map[string]any, name string) int {
+	v, _ := args[name].(int)
+	return v
+}
+
+// longArg returns the Long argument name, or zero if it is missing.
+func longArg(args map[string]any, name string) int64 {
+	v, _ := args[name].(int64)
+	return v
+}
+
+// creditsArg returns the authors argument as controller credits.
+func creditsArg(args map[string]any) []controller.BookAuthorRequest {
+	list, _ := args["authors"].([]any)
+	credits := make([]controller.BookAuthorRequest, 0, len(list))
+	for _, c := range list {
+		credit, _ := c.(map[string]any)
+		credits = append(credits, controller.BookAuthorRequest{
+			AuthorID: stringArg(credit, "authorId"),
+			Role:     stringArg(credit, "role"),
+		})
+	}
+	return credits
+}
@@ -0,0 +1,275 @@
+package webservice_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webservice"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestGraphQL(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	mockAuthorInteractor := mocks.NewMockAuthorInteractor(mockCtl)
+	mockInventoryInteractor := mocks.NewMockInventoryInteractor(mockCtl)
+	logger := testlog.NewTestLogger()
+	gql, err := webservice.NewGraphQL(
+		logger, mockBookInteractor, mockAuthorInteractor, mockInventoryInteractor, presenter.NewErrorPresenter(logger),
+	)
+	if err != nil {
+		t.Fatal("failed to build schema:", err)
+	}
+	bookID := uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42")
+	authorID := uuid.MustParse("3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c")
+	dune := &domain.Book{
+		ID:          bookID,
+		Title:       "Dune",
+		Author:      "Frank Herbert",
+		LanguageTag: "en",
+		Authors:     []domain.BookAuthor{{AuthorID: authorID, Name: "Frank Herbert", Role: domain.RoleAuthor}},
+		Price:       domain.Money{Amount: 1299, Currency: "EUR"},
+		CreatedAt:   time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		Version:     1,
+	}
+
+	tests := []struct {
+		name             string
+		body             string
+		mockExpectations func()
+		want             string
+		wantCode         int
+	}{
+		{
+			name:     "rejects malformed requests",
+			body:     `{"query":`,
+			wantCode: http.StatusBadRequest,
+			want:     `{"errors":[{"message":"unexpected EOF"}]}`,
+		},
+		{
+			name:     "rejects requests without query",
+			body:     `{}`,
+			wantCode: http.StatusBadRequest,
+			want:     `{"errors":[{"message":"query is required"}]}`,
+		},
+		{
+			name:     "reports invalid queries",
+			body:     `{"query":"{ book(id: \"x\") { isbn, shelf } }"}`,
+			wantCode: http.StatusOK,
+			want: `{"data":null,"errors":[{"message":"Cannot query field \"shelf\" on type \"Book\".",` +
+				`"locations":[{"line":1,"column":25}]}]}`,
+		},
+		{
+			name: "gets a book by prefixed id",
+			body: `{"query":"query($id: ID!) { book(id: $id) { id isbn title authors { id name role } price ` +
+				`currency version createdAt } }","variables":{"id":"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42"}}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().GetBook(gomock.Any(), domain.BookResource.Format(bookID)).Return(dune, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"data":{"book":{"authors":[{"id":"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c",` +
+				`"name":"Frank Herbert","role":"author"}],"createdAt":"2024-05-01T12:00:00Z","currency":"EUR",` +
+				`"id":"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42","isbn":null,"price":1299,"title":"Dune",` +
+				`"version":1}}}`,
+		},
+		{
+			name: "gets a book with its stock",
+			body: `{"query":"{ book(id: \"5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42\") { title stock { onHand reserved ` +
+				`available updatedAt } } }"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().GetBook(gomock.Any(), bookID.String()).Return(dune, nil)
+				mockInventoryInteractor.EXPECT().
+					GetStock(gomock.Any(), domain.BookResource.Format(bookID)).
+					Return(&domain.Stock{BookID: bookID, OnHand: 5, Reserved: 2}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"data":{"book":{"stock":{"available":3,"onHand":5,"reserved":2,"updatedAt":null},` +
+				`"title":"Dune"}}}`,
+		},
+		{
+			name: "gets prices beyond 32 bits",
+			body: `{"query":"{ book(id: \"5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42\") { price } }"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					GetBook(gomock.Any(), bookID.String()).
+					Return(&domain.Book{ID: bookID, Price: domain.Money{Amount: 1 << 40, Currency: "EUR"}}, nil)
+			},
+			wantCode: http.StatusOK,
+			want:     `{"data":{"book":{"price":1099511627776}}}`,
+		},
+		{
+			name: "gets an author",
+			body: `{"query":"{ author(id: \"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c\") { id name createdAt } }"}`,
+			mockExpectations: func() {
+				mockAuthorInteractor.EXPECT().
+					GetAuthor(gomock.Any(), domain.AuthorResource.Format(authorID)).
+					Return(&domain.Author{ID: authorID, Name: "Frank Herbert", CreatedAt: dune.CreatedAt}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"data":{"author":{"createdAt":"2024-05-01T12:00:00Z",` +
+				`"id":"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c","name":"Frank Herbert"}}}`,
+		},
+		{
+			name: "fails to get a missing author",
+			body: `{"query":"{ author(id: \"3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c\") { name } }"}`,
+			mockExpectations: func() {
+				mockAuthorInteractor.EXPECT().
+					GetAuthor(gomock.Any(), authorID.String()).
+					Return(nil, domain.ErrAuthorNotFound)
+			},
+			wantCode: http.StatusOK,
+			want: `{"data":{"author":null},"errors":[{"message":"author not found",` +
+				`"locations":[{"line":1,"column":3}],"path":["author"],` +
+				`"extensions":{"code":"AUTHOR_NOT_FOUND","status":404}}]}`,
+		},
+		{
+			name: "lists authors",
+			body: `{"query":"{ authors { name } }"}`,
+			mockExpectations: func() {
+				mockAuthorInteractor.EXPECT().
+					ListAuthors(gomock.Any()).
+					Return([]*domain.Author{{ID: authorID, Name: "Frank Herbert"}}, nil)
+			},
+			wantCode: http.StatusOK,
+			want:     `{"data":{"authors":[{"name":"Frank Herbert"}]}}`,
+		},
+		{
+			name: "reports domain errors with their status",
+			body: `{"query":"{ book(id: \"5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42\") { title } }"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().GetBook(gomock.Any(), bookID.String()).Return(nil, domain.ErrBookNotFound)
+			},
+			wantCode: http.StatusOK,
+			want: `{"data":{"book":null},"errors":[{"message":"book not found","locations":[{"line":1,"column":3}],` +
+				`"path":["book"],"extensions":{"code":"BOOK_NOT_FOUND","status":404}}]}`,
+		},
+		{
+			name: "hides internal errors",
+			body: `{"query":"{ book(id: \"5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42\") { title } }"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().GetBook(gomock.Any(), bookID.String()).Return(nil, errors.New("disk on fire"))
+			},
+			wantCode: http.StatusOK,
+			want: `{"data":{"book":null},"errors":[{"message":"internal server error",` +
+				`"locations":[{"line":1,"column":3}],"path":["book"],` +
+				`"extensions":{"code":"INTERNAL_SERVER_ERROR","status":500}}]}`,
+		},
+		{
+			name:     "fails to list books with invalid arguments",
+			body:     `{"query":"{ books(order: \"up\") { total } }"}`,
+			wantCode: http.StatusOK,
+			want: `{"data":null,"errors":[{"message":"order must be asc or desc","locations":[{"line":1,"column":3}],` +
+				`"path":["books"],"extensions":{"code":"VALIDATION_FAILED","status":400}}]}`,
+		},
+		{
+			name: "lists books",
+			body: `{"query":"{ books(limit: 1, sort: \"price\", order: \"desc\", authorId: ` +
+				`\"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c\", maxPrice: 2000) { books { title } nextCursor total } }"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ListBooks(gomock.Any(), domain.BookQuery{
+						SortBy:     domain.SortByPrice,
+						Descending: true,
+						Limit:      1,
+						Filter:     domain.BookFilter{AuthorID: authorID, MaxPrice: 2000},
+					}).
+					Return(&domain.BookPage{Books: []*domain.Book{dune}, NextCursor: "next", Total: 3}, nil)
+			},
+			wantCode: http.StatusOK,
+			want:     `{"data":{"books":{"books":[{"title":"Dune"}],"nextCursor":"next","total":3}}}`,
+		},
+		{
+			name:     "fails to create an invalid book",
+			body:     `{"query":"mutation { createBook(input: {title: \"Dune\", price: 0, currency: \"EUR\"}) { id } }"}`,
+			wantCode: http.StatusOK,
+			want: `{"data":null,"errors":[{"message":"author is required; price must be greater than zero",` +
+				`"locations":[{"line":1,"column":12}],` +
+				`"path":["createBook"],"extensions":{"code":"VALIDATION_FAILED","status":400}}]}`,
+		},
+		{
+			name: "creates a book",
+			body: `{"query":"mutation($input: CreateBookInput!) { createBook(input: $input) { id version } }",` +
+				`"variables":{"input":{"title":"Dune","price":1299,"currency":"eur",` +
+				`"authors":[{"authorId":"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c"}]}}}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					CreateBook(gomock.Any(), &domain.Book{
+						Title:   "Dune",
+						Authors: []domain.BookAuthor{{AuthorID: authorID, Role: domain.RoleAuthor}},
+						Price:   domain.Money{Amount: 1299, Currency: "EUR"},
+					}).
+					DoAndReturn(func(_ context.Context, b *domain.Book) error {
+						*b = *dune
+						return nil
+					})
+			},
+			wantCode: http.StatusOK,
+			want:     `{"data":{"createBook":{"id":"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42","version":1}}}`,
+		},
+		{
+			name: "fails to update a stale version",
+			body: `{"query":"mutation { updateBook(id: \"5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42\", ` +
+				`input: {price: 3000000000}, version: 1) { version } }"}`,
+			mockExpectations: func() {
+				price := int64(3000000000)
+				mockBookInteractor.EXPECT().
+					PatchBook(gomock.Any(), bookID.String(), &domain.BookPatch{Price: &price}, 1).
+					Return(nil, domain.ErrVersionConflict)
+			},
+			wantCode: http.StatusOK,
+			want: `{"data":null,"errors":[{"message":"` + domain.ErrVersionConflict.Error() + `",` +
+				`"locations":[{"line":1,"column":12}],"path":["updateBook"],` +
+				`"extensions":{"code":"VERSION_CONFLICT","status":412}}]}`,
+		},
+		{
+			name: "updates a book",
+			body: `{"query":"mutation { updateBook(id: \"5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42\", ` +
+				`input: {title: \"Dune Messiah\", currency: \"usd\", authors: []}) { title version } }"}`,
+			mockExpectations: func() {
+				title, currency, authors := "Dune Messiah", "USD", []domain.BookAuthor(nil)
+				mockBookInteractor.EXPECT().
+					PatchBook(gomock.Any(), bookID.String(),
+						&domain.BookPatch{Title: &title, Currency: &currency, Authors: &authors}, 0).
+					Return(&domain.Book{ID: bookID, Title: title, Version: 2}, nil)
+			},
+			wantCode: http.StatusOK,
+			want:     `{"data":{"updateBook":{"title":"Dune Messiah","version":2}}}`,
+		},
+		{
+			name: "deletes a book",
+			body: `{"query":"mutation { deleteBook(id: \"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42\") }"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().DeleteBook(gomock.Any(), domain.BookResource.Format(bookID))
+			},
+			wantCode: http.StatusOK,
+			want:     `{"data":{"deleteBook":"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			res := httptest.NewRecorder()
+			gql.ServeHTTP(res, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body)))
+
+			if res.Code != tt.wantCode {
+				t.Errorf("want status %d, got %d", tt.wantCode, res.Code)
+			}
+			if got := strings.TrimSpace(res.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
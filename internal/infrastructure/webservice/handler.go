@@ -4,29 +4,155 @@ package webservice
 
 import (
 	"net/http"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 )
 
+// ActorHeader is the request header naming the actor changes are attributed to in the audit log.
+const ActorHeader = "X-Actor"
+
 // BookController is the interface abstraction of an HTTP controller.
 type BookController interface {
 	// CreateBook handles create requests over http.
 	CreateBook(w http.ResponseWriter, r *http.Request)
 	// GetBook handles read book by ID requests over http.
 	GetBook(w http.ResponseWriter, r *http.Request)
+	// GetBookByISBN handles read book by ISBN requests over http.
+	GetBookByISBN(w http.ResponseWriter, r *http.Request)
 	// ListBooks handles read books requests over http.
-	ListBooks(w http.ResponseWriter, _ *http.Request)
+	ListBooks(w http.ResponseWriter, r *http.Request)
 	// UpdateBook handles update requests over http.
 	UpdateBook(w http.ResponseWriter, r *http.Request)
+	// PatchBook handles partial update by ID requests over http.
+	PatchBook(w http.ResponseWriter, r *http.Request)
 	// DeleteBook handles delete book by ID requests over http.
 	DeleteBook(w http.ResponseWriter, r *http.Request)
+	// ListTrash handles read trashed books requests over http.
+	ListTrash(w http.ResponseWriter, r *http.Request)
+	// RestoreBook handles restore trashed book by ID requests over http.
+	RestoreBook(w http.ResponseWriter, r *http.Request)
+	// GetBookHistory handles read book changes by ID requests over http.
+	GetBookHistory(w http.ResponseWriter, r *http.Request)
+	// ImportBooks handles bulk create requests over http.
+	ImportBooks(w http.ResponseWriter, r *http.Request)
+	// ExportBooks handles catalog export requests over http.
+	ExportBooks(w http.ResponseWriter, r *http.Request)
+}
+
+// BookSearchController is the interface abstraction of an HTTP search controller.
+type BookSearchController interface {
+	// SearchBooks handles full-text search requests over http.
+	SearchBooks(w http.ResponseWriter, r *http.Request)
+}
+
+// InventoryController is the interface abstraction of an HTTP stock management controller.
+type InventoryController interface {
+	// GetStock handles read stock by book ID requests over http.
+	GetStock(w http.ResponseWriter, r *http.Request)
+	// ReceiveStock handles delivered copies requests over http.
+	ReceiveStock(w http.ResponseWriter, r *http.Request)
+	// AdjustStock handles stock correction requests over http.
+	AdjustStock(w http.ResponseWriter, r *http.Request)
+	// ReserveStock handles reservation requests over http.
+	ReserveStock(w http.ResponseWriter, r *http.Request)
+}
+
+// OrderController is the interface abstraction of an HTTP checkout controller.
+type OrderController interface {
+	// PlaceOrder handles checkout requests over http.
+	PlaceOrder(w http.ResponseWriter, r *http.Request)
+	// GetOrder handles read order by ID requests over http.
+	GetOrder(w http.ResponseWriter, r *http.Request)
+	// ListOrders handles read orders requests over http.
+	ListOrders(w http.ResponseWriter, r *http.Request)
+	// PayOrder handles order payment requests over http.
+	PayOrder(w http.ResponseWriter, r *http.Request)
+	// ShipOrder handles order shipment requests over http.
+	ShipOrder(w http.ResponseWriter, r *http.Request)
+	// CancelOrder handles order cancellation requests over http.
+	CancelOrder(w http.ResponseWriter, r *http.Request)
+}
+
+// AuthorController is the interface abstraction of an HTTP author controller.
+type AuthorController interface {
+	// CreateAuthor handles create author requests over http.
+	CreateAuthor(w http.ResponseWriter, r *http.Request)
+	// GetAuthor handles read author by ID requests over http.
+	GetAuthor(w http.ResponseWriter, r *http.Request)
+	// ListAuthors handles read authors requests over http.
+	ListAuthors(w http.ResponseWriter, r *http.Request)
+	// RenameAuthor handles rename author requests over http.
+	RenameAuthor(w http.ResponseWriter, r *http.Request)
+	// DeleteAuthor handles delete author requests over http.
+	DeleteAuthor(w http.ResponseWriter, r *http.Request)
+}
+
+// WebhookController is the interface abstraction of an HTTP webhook subscription controller.
+type WebhookController interface {
+	// CreateWebhook handles subscribe webhook requests over http.
+	CreateWebhook(w http.ResponseWriter, r *http.Request)
+	// GetWebhook handles read webhook by ID requests over http.
+	GetWebhook(w http.ResponseWriter, r *http.Request)
+	// ListWebhooks handles read webhooks requests over http.
+	ListWebhooks(w http.ResponseWriter, r *http.Request)
+	// DeleteWebhook handles unsubscribe webhook requests over http.
+	DeleteWebhook(w http.ResponseWriter, r *http.Request)
+	// ListDeliveries handles read deliveries by webhook ID requests over http.
+	ListDeliveries(w http.ResponseWriter, r *http.Request)
 }
 
-// NewHandler creates a new webservice serving CRUD operation on the /books endpoint.
-func NewHandler(bc BookController) http.Handler {
+// NewHandler creates a new webservice serving CRUD operation on the /books and /authors endpoints,
+// the trash of deleted books, stock management, checkout and webhook subscriptions.
+// GraphQL operations are served by gql on the /graphql endpoint,
+// and the OpenAPI document of the routes on /openapi.json, rendered on /docs.
+// The routes are registered from operations, the table OpenAPI documents.
+// Requests are attributed to the actor named by their ActorHeader.
+func NewHandler(
+	bc BookController,
+	sc BookSearchController,
+	ic InventoryController,
+	oc OrderController,
+	ac AuthorController,
+	wc WebhookController,
+	gql http.Handler,
+) http.Handler {
+	c := controllers{books: bc, search: sc, inventory: ic, orders: oc, authors: ac, webhooks: wc, graphQL: gql}
 	mux := http.NewServeMux()
-	mux.HandleFunc("PUT /v1/books", bc.CreateBook)
-	mux.HandleFunc("GET /v1/books/{id}", bc.GetBook)
-	mux.HandleFunc("GET /v1/books", bc.ListBooks)
-	mux.HandleFunc("PATCH /v1/books", bc.UpdateBook)
-	mux.HandleFunc("DELETE /v1/books/{id}", bc.DeleteBook)
-	return mux
+	for _, op := range operations {
+		mux.HandleFunc(op.pattern, op.handle(c))
+	}
+	return withActor(mux)
+}
+
+// controllers are the handlers of the routes of NewHandler.
+type controllers struct {
+	books     BookController
+	search    BookSearchController
+	inventory InventoryController
+	orders    OrderController
+	authors   AuthorController
+	webhooks  WebhookController
+	graphQL   http.Handler
+}
+
+// getBookByISBN serves the books read by ISBN.
+// ServeMux rejects a literal isbn segment next to the {id}/stock sub-resource, so it is matched here.
+func getBookByISBN(c controllers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "isbn" {
+			http.NotFound(w, r)
+			return
+		}
+		c.books.GetBookByISBN(w, r)
+	}
+}
+
+// withActor carries the actor named by the ActorHeader of each request in its context.
+func withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actor := r.Header.Get(ActorHeader); actor != "" {
+			r = r.WithContext(domain.WithActor(r.Context(), actor))
+		}
+		next.ServeHTTP(w, r)
+	})
 }
@@ -2,12 +2,16 @@ package webservice_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"strings"
 	"testing"
 
 	"go.uber.org/mock/gomock"
 
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webservice"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
 )
@@ -15,7 +19,15 @@ import (
 func TestNewHandler(t *testing.T) {
 	mockCtl := gomock.NewController(t)
 	mockBooksController := mocks.NewMockBookController(mockCtl)
-	handler := webservice.NewHandler(mockBooksController)
+	mockSearchController := mocks.NewMockBookSearchController(mockCtl)
+	mockInventoryController := mocks.NewMockInventoryController(mockCtl)
+	mockOrderController := mocks.NewMockOrderController(mockCtl)
+	mockAuthorController := mocks.NewMockAuthorController(mockCtl)
+	mockWebhookController := mocks.NewMockWebhookController(mockCtl)
+	handler := webservice.NewHandler(
+		mockBooksController, mockSearchController, mockInventoryController, mockOrderController, mockAuthorController,
+		mockWebhookController, http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}),
+	)
 	server := httptest.NewServer(handler)
 
 	tests := []struct {
@@ -32,6 +44,30 @@ func TestNewHandler(t *testing.T) {
 				mockBooksController.EXPECT().CreateBook(gomock.Any(), gomock.Any())
 			},
 		},
+		{
+			name:     "POST /v1/books:import",
+			method:   http.MethodPost,
+			endpoint: "/v1/books:import",
+			mockExpectations: func() {
+				mockBooksController.EXPECT().ImportBooks(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/books:export",
+			method:   http.MethodGet,
+			endpoint: "/v1/books:export?format=csv",
+			mockExpectations: func() {
+				mockBooksController.EXPECT().ExportBooks(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/books/search",
+			method:   http.MethodGet,
+			endpoint: "/v1/books/search?q=dune",
+			mockExpectations: func() {
+				mockSearchController.EXPECT().SearchBooks(gomock.Any(), gomock.Any())
+			},
+		},
 		{
 			name:     "GET /v1/books/{id}",
 			method:   http.MethodGet,
@@ -40,6 +76,14 @@ func TestNewHandler(t *testing.T) {
 				mockBooksController.EXPECT().GetBook(gomock.Any(), gomock.Any())
 			},
 		},
+		{
+			name:     "GET /v1/books/isbn/{isbn}",
+			method:   http.MethodGet,
+			endpoint: "/v1/books/isbn/9780306406157",
+			mockExpectations: func() {
+				mockBooksController.EXPECT().GetBookByISBN(gomock.Any(), gomock.Any())
+			},
+		},
 		{
 			name:     "GET /v1/books",
 			method:   http.MethodGet,
@@ -56,6 +100,14 @@ func TestNewHandler(t *testing.T) {
 				mockBooksController.EXPECT().UpdateBook(gomock.Any(), gomock.Any())
 			},
 		},
+		{
+			name:     "PATCH /v1/books/{id}",
+			method:   http.MethodPatch,
+			endpoint: "/v1/books/book-id",
+			mockExpectations: func() {
+				mockBooksController.EXPECT().PatchBook(gomock.Any(), gomock.Any())
+			},
+		},
 		{
 			name:     "DELETE /v1/books/{id}",
 			method:   http.MethodDelete,
@@ -64,6 +116,208 @@ func TestNewHandler(t *testing.T) {
 				mockBooksController.EXPECT().DeleteBook(gomock.Any(), gomock.Any())
 			},
 		},
+		{
+			name:     "POST /v1/books/{id}/restore",
+			method:   http.MethodPost,
+			endpoint: "/v1/books/book-id/restore",
+			mockExpectations: func() {
+				mockBooksController.EXPECT().RestoreBook(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/books/{id}/history",
+			method:   http.MethodGet,
+			endpoint: "/v1/books/book-id/history",
+			mockExpectations: func() {
+				mockBooksController.EXPECT().GetBookHistory(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/trash/books",
+			method:   http.MethodGet,
+			endpoint: "/v1/trash/books",
+			mockExpectations: func() {
+				mockBooksController.EXPECT().ListTrash(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/books/{id}/stock",
+			method:   http.MethodGet,
+			endpoint: "/v1/books/book-id/stock",
+			mockExpectations: func() {
+				mockInventoryController.EXPECT().GetStock(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/books/{id}/stock/receive",
+			method:   http.MethodPost,
+			endpoint: "/v1/books/book-id/stock/receive",
+			mockExpectations: func() {
+				mockInventoryController.EXPECT().ReceiveStock(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/books/{id}/stock/adjust",
+			method:   http.MethodPost,
+			endpoint: "/v1/books/book-id/stock/adjust",
+			mockExpectations: func() {
+				mockInventoryController.EXPECT().AdjustStock(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/books/{id}/stock/reserve",
+			method:   http.MethodPost,
+			endpoint: "/v1/books/book-id/stock/reserve",
+			mockExpectations: func() {
+				mockInventoryController.EXPECT().ReserveStock(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/authors",
+			method:   http.MethodPost,
+			endpoint: "/v1/authors",
+			mockExpectations: func() {
+				mockAuthorController.EXPECT().CreateAuthor(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/authors",
+			method:   http.MethodGet,
+			endpoint: "/v1/authors",
+			mockExpectations: func() {
+				mockAuthorController.EXPECT().ListAuthors(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/authors/{id}",
+			method:   http.MethodGet,
+			endpoint: "/v1/authors/author-id",
+			mockExpectations: func() {
+				mockAuthorController.EXPECT().GetAuthor(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "PUT /v1/authors/{id}",
+			method:   http.MethodPut,
+			endpoint: "/v1/authors/author-id",
+			mockExpectations: func() {
+				mockAuthorController.EXPECT().RenameAuthor(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "DELETE /v1/authors/{id}",
+			method:   http.MethodDelete,
+			endpoint: "/v1/authors/author-id",
+			mockExpectations: func() {
+				mockAuthorController.EXPECT().DeleteAuthor(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/orders",
+			method:   http.MethodPost,
+			endpoint: "/v1/orders",
+			mockExpectations: func() {
+				mockOrderController.EXPECT().PlaceOrder(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/orders",
+			method:   http.MethodGet,
+			endpoint: "/v1/orders",
+			mockExpectations: func() {
+				mockOrderController.EXPECT().ListOrders(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/orders/{id}",
+			method:   http.MethodGet,
+			endpoint: "/v1/orders/order-id",
+			mockExpectations: func() {
+				mockOrderController.EXPECT().GetOrder(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/orders/{id}/pay",
+			method:   http.MethodPost,
+			endpoint: "/v1/orders/order-id/pay",
+			mockExpectations: func() {
+				mockOrderController.EXPECT().PayOrder(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/orders/{id}/ship",
+			method:   http.MethodPost,
+			endpoint: "/v1/orders/order-id/ship",
+			mockExpectations: func() {
+				mockOrderController.EXPECT().ShipOrder(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/orders/{id}/cancel",
+			method:   http.MethodPost,
+			endpoint: "/v1/orders/order-id/cancel",
+			mockExpectations: func() {
+				mockOrderController.EXPECT().CancelOrder(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "POST /v1/webhooks",
+			method:   http.MethodPost,
+			endpoint: "/v1/webhooks",
+			mockExpectations: func() {
+				mockWebhookController.EXPECT().CreateWebhook(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/webhooks",
+			method:   http.MethodGet,
+			endpoint: "/v1/webhooks",
+			mockExpectations: func() {
+				mockWebhookController.EXPECT().ListWebhooks(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/webhooks/{id}",
+			method:   http.MethodGet,
+			endpoint: "/v1/webhooks/webhook-id",
+			mockExpectations: func() {
+				mockWebhookController.EXPECT().GetWebhook(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "DELETE /v1/webhooks/{id}",
+			method:   http.MethodDelete,
+			endpoint: "/v1/webhooks/webhook-id",
+			mockExpectations: func() {
+				mockWebhookController.EXPECT().DeleteWebhook(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:     "GET /v1/webhooks/{id}/deliveries",
+			method:   http.MethodGet,
+			endpoint: "/v1/webhooks/webhook-id/deliveries",
+			mockExpectations: func() {
+				mockWebhookController.EXPECT().ListDeliveries(gomock.Any(), gomock.Any())
+			},
+		},
+		{
+			name:             "POST /graphql",
+			method:           http.MethodPost,
+			endpoint:         "/graphql",
+			mockExpectations: func() {},
+		},
+		{
+			name:             "GET /openapi.json",
+			method:           http.MethodGet,
+			endpoint:         "/openapi.json",
+			mockExpectations: func() {},
+		},
+		{
+			name:             "GET /docs",
+			method:           http.MethodGet,
+			endpoint:         "/docs",
+			mockExpectations: func() {},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -88,4 +342,72 @@ func TestNewHandler(t *testing.T) {
 			}
 		})
 	}
+
+	// NewHandler registers the routes the OpenAPI document is generated from,
+	// so every operation it documents must be routed to its controller above.
+	t.Run("every route is tested", func(t *testing.T) {
+		tested := make([]string, 0, len(tests))
+		for _, tt := range tests {
+			tested = append(tested, tt.name)
+		}
+		documented := documentedOperations(t, server.URL)
+		slices.Sort(tested)
+		if !slices.Equal(tested, documented) {
+			t.Errorf("tested routes = %v, want the documented operations %v", tested, documented)
+		}
+	})
+}
+
+// documentedOperations returns the sorted "METHOD path" operations of the OpenAPI document served by url.
+func documentedOperations(t *testing.T, url string) []string {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url+"/openapi.json", http.NoBody)
+	if err != nil {
+		t.Fatal("failed to create request:", err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal("failed to make request:", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		t.Fatal("failed to decode the OpenAPI document:", err)
+	}
+	var operations []string
+	for path, methods := range doc.Paths {
+		for method := range methods {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+	slices.Sort(operations)
+	return operations
+}
+
+func TestNewHandler_Actor(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBooksController := mocks.NewMockBookController(mockCtl)
+	handler := webservice.NewHandler(
+		mockBooksController, mocks.NewMockBookSearchController(mockCtl), mocks.NewMockInventoryController(mockCtl),
+		mocks.NewMockOrderController(mockCtl), mocks.NewMockAuthorController(mockCtl),
+		mocks.NewMockWebhookController(mockCtl), http.NotFoundHandler(),
+	)
+
+	for header, want := range map[string]string{"": domain.AnonymousActor, "alice": "alice"} {
+		mockBooksController.EXPECT().
+			DeleteBook(gomock.Any(), gomock.Any()).
+			Do(func(_ http.ResponseWriter, r *http.Request) {
+				if got := domain.ActorFrom(r.Context()); got != want {
+					t.Errorf("want actor %q, got %q", want, got)
+				}
+			})
+		r := httptest.NewRequest(http.MethodDelete, "/v1/books/book-id", http.NoBody)
+		if header != "" {
+			r.Header.Set(webservice.ActorHeader, header)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
 }
@@ -0,0 +1,531 @@
+package webservice
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+
+	_ "embed" // Embeds the OpenAPI document and the docs page.
+)
+
+// openAPISpec is the OpenAPI document served by the service, generated by OpenAPI.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// docsPage renders openAPISpec for humans.
+//
+//go:embed docs.html
+var docsPage []byte
+
+// content is a request or response body in other media types than JSON,
+// mapping each media type to the zero value of its Go type; nil is a plain string.
+type content map[string]any
+
+// viewMediaTypes are the media types presenter.ResponsePresenter encodes view models in,
+// besides text/csv for lists.
+var viewMediaTypes = []string{
+	"application/json", "application/xml", "text/xml", "application/msgpack", "application/x-msgpack",
+}
+
+// operation is a route registered by NewHandler, served by the controller method handle returns,
+// and documented by OpenAPI.
+// Request bodies are the zero value of their Go type, or a content for other media types than JSON.
+// Response bodies are the zero value of a view model, offered in every media type of viewMediaTypes,
+// or a content for responses that are not negotiated. list marks view models that are lists,
+// also offered as text/csv.
+// The query parameters are the fields of a struct tagged with query.
+type operation struct {
+	query     any
+	handle    func(c controllers) http.HandlerFunc
+	body      any
+	responses map[int]any
+	pattern   string
+	path      string
+	id        string
+	summary   string
+	tag       string
+	headers   []string
+	etag      bool
+	list      bool
+}
+
+// operations lists every route of NewHandler, which registers them in this order.
+// path is only set when the documented path differs from the one of the pattern.
+var operations = []operation{
+	{
+		pattern: "PUT /v1/books", id: "createBook", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.CreateBook },
+		summary:   "Add a book to the catalog",
+		body:      controller.CreateBookRequest{},
+		responses: map[int]any{http.StatusCreated: nil},
+	},
+	{
+		pattern: "POST /v1/books:import", id: "importBooks", tag: "books",
+		handle:  func(c controllers) http.HandlerFunc { return c.books.ImportBooks },
+		summary: "Add books in bulk from a CSV or NDJSON file",
+		query:   controller.ImportBooksRequest{},
+		body:    content{"text/csv": nil, "application/x-ndjson": nil},
+		list:    true,
+		responses: map[int]any{
+			http.StatusOK:                  presenter.ImportReportView{},
+			http.StatusUnprocessableEntity: presenter.ImportReportView{},
+		},
+	},
+	{
+		pattern: "GET /v1/books:export", id: "exportBooks", tag: "books",
+		handle:  func(c controllers) http.HandlerFunc { return c.books.ExportBooks },
+		summary: "Stream the whole catalog",
+		query:   controller.ExportBooksRequest{},
+		responses: map[int]any{http.StatusOK: content{
+			"application/json":     []presenter.ExportedBookView{},
+			"application/x-ndjson": presenter.ExportedBookView{},
+			"text/csv":             nil,
+		}},
+	},
+	{
+		pattern: "GET /v1/books/search", id: "searchBooks", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.search.SearchBooks },
+		summary:   "Search the catalog, most relevant books first",
+		query:     controller.SearchBooksRequest{},
+		list:      true,
+		responses: map[int]any{http.StatusOK: []presenter.BookView{}},
+	},
+	{
+		pattern: "GET /v1/books/{id}", id: "getBook", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.GetBook },
+		summary:   "Read a book",
+		headers:   []string{"Accept-Language"},
+		responses: map[int]any{http.StatusOK: presenter.BookView{}},
+		etag:      true,
+	},
+	{
+		pattern: "GET /v1/books/{id}/{isbn}", path: "/v1/books/isbn/{isbn}", id: "getBookByISBN", tag: "books",
+		handle:    getBookByISBN,
+		summary:   "Read a book by its ISBN-10 or ISBN-13",
+		headers:   []string{"Accept-Language"},
+		responses: map[int]any{http.StatusOK: presenter.BookView{}},
+		etag:      true,
+	},
+	{
+		pattern: "GET /v1/books", id: "listBooks", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.ListBooks },
+		summary:   "List a page of the catalog",
+		query:     controller.ListBooksRequest{},
+		headers:   []string{"Accept-Language"},
+		list:      true,
+		responses: map[int]any{http.StatusOK: presenter.BookPageView{}},
+	},
+	{
+		pattern: "PATCH /v1/books", id: "updateBook", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.UpdateBook },
+		summary:   "Change the price of a book",
+		body:      controller.UpdateBookRequest{},
+		headers:   []string{"If-Match"},
+		responses: map[int]any{http.StatusAccepted: nil},
+		etag:      true,
+	},
+	{
+		pattern: "PATCH /v1/books/{id}", id: "patchBook", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.PatchBook },
+		summary:   "Change a book with a JSON merge patch",
+		body:      content{"application/merge-patch+json": controller.PatchBookRequest{}},
+		headers:   []string{"If-Match"},
+		responses: map[int]any{http.StatusOK: presenter.BookView{}},
+		etag:      true,
+	},
+	{
+		pattern: "DELETE /v1/books/{id}", id: "deleteBook", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.DeleteBook },
+		summary:   "Move a book to the trash",
+		responses: map[int]any{http.StatusOK: nil},
+	},
+	{
+		pattern: "POST /v1/books/{id}/restore", id: "restoreBook", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.RestoreBook },
+		summary:   "Move a trashed book back to the catalog",
+		headers:   []string{"Accept-Language"},
+		responses: map[int]any{http.StatusOK: presenter.BookView{}},
+		etag:      true,
+	},
+	{
+		pattern: "GET /v1/books/{id}/history", id: "getBookHistory", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.GetBookHistory },
+		summary:   "List the changes of a book, oldest first",
+		query:     controller.BookHistoryRequest{},
+		list:      true,
+		responses: map[int]any{http.StatusOK: presenter.AuditPageView{}},
+	},
+	{
+		pattern: "GET /v1/trash/books", id: "listTrash", tag: "books",
+		handle:    func(c controllers) http.HandlerFunc { return c.books.ListTrash },
+		summary:   "List a page of the trashed books",
+		query:     controller.ListBooksRequest{},
+		list:      true,
+		responses: map[int]any{http.StatusOK: presenter.BookPageView{}},
+	},
+	{
+		pattern: "GET /v1/books/{id}/stock", id: "getStock", tag: "stock",
+		handle:    func(c controllers) http.HandlerFunc { return c.inventory.GetStock },
+		summary:   "Read the stock of a book",
+		responses: map[int]any{http.StatusOK: presenter.StockView{}},
+	},
+	{
+		pattern: "POST /v1/books/{id}/stock/receive", id: "receiveStock", tag: "stock",
+		handle:    func(c controllers) http.HandlerFunc { return c.inventory.ReceiveStock },
+		summary:   "Add delivered copies of a book",
+		body:      controller.StockQuantityRequest{},
+		responses: map[int]any{http.StatusOK: presenter.StockView{}},
+	},
+	{
+		pattern: "POST /v1/books/{id}/stock/adjust", id: "adjustStock", tag: "stock",
+		handle:    func(c controllers) http.HandlerFunc { return c.inventory.AdjustStock },
+		summary:   "Correct the copies on hand of a book",
+		body:      controller.AdjustStockRequest{},
+		responses: map[int]any{http.StatusOK: presenter.StockView{}},
+	},
+	{
+		pattern: "POST /v1/books/{id}/stock/reserve", id: "reserveStock", tag: "stock",
+		handle:    func(c controllers) http.HandlerFunc { return c.inventory.ReserveStock },
+		summary:   "Reserve copies of a book",
+		body:      controller.StockQuantityRequest{},
+		responses: map[int]any{http.StatusOK: presenter.StockView{}},
+	},
+	{
+		pattern: "POST /v1/authors", id: "createAuthor", tag: "authors",
+		handle:    func(c controllers) http.HandlerFunc { return c.authors.CreateAuthor },
+		summary:   "Add an author",
+		body:      controller.AuthorRequest{},
+		responses: map[int]any{http.StatusCreated: presenter.AuthorView{}},
+	},
+	{
+		pattern: "GET /v1/authors", id: "listAuthors", tag: "authors",
+		handle:    func(c controllers) http.HandlerFunc { return c.authors.ListAuthors },
+		summary:   "List every author",
+		list:      true,
+		responses: map[int]any{http.StatusOK: presenter.AuthorListView{}},
+	},
+	{
+		pattern: "GET /v1/authors/{id}", id: "getAuthor", tag: "authors",
+		handle:    func(c controllers) http.HandlerFunc { return c.authors.GetAuthor },
+		summary:   "Read an author",
+		responses: map[int]any{http.StatusOK: presenter.AuthorView{}},
+	},
+	{
+		pattern: "PUT /v1/authors/{id}", id: "renameAuthor", tag: "authors",
+		handle:    func(c controllers) http.HandlerFunc { return c.authors.RenameAuthor },
+		summary:   "Rename an author",
+		body:      controller.AuthorRequest{},
+		responses: map[int]any{http.StatusOK: presenter.AuthorView{}},
+	},
+	{
+		pattern: "DELETE /v1/authors/{id}", id: "deleteAuthor", tag: "authors",
+		handle:    func(c controllers) http.HandlerFunc { return c.authors.DeleteAuthor },
+		summary:   "Delete an author no book credits",
+		responses: map[int]any{http.StatusOK: nil},
+	},
+	{
+		pattern: "POST /v1/orders", id: "placeOrder", tag: "orders",
+		handle:    func(c controllers) http.HandlerFunc { return c.orders.PlaceOrder },
+		summary:   "Place an order, reserving its copies",
+		body:      controller.PlaceOrderRequest{},
+		responses: map[int]any{http.StatusCreated: presenter.OrderView{}},
+	},
+	{
+		pattern: "GET /v1/orders", id: "listOrders", tag: "orders",
+		handle:    func(c controllers) http.HandlerFunc { return c.orders.ListOrders },
+		summary:   "List every order",
+		list:      true,
+		responses: map[int]any{http.StatusOK: presenter.OrderListView{}},
+	},
+	{
+		pattern: "GET /v1/orders/{id}", id: "getOrder", tag: "orders",
+		handle:    func(c controllers) http.HandlerFunc { return c.orders.GetOrder },
+		summary:   "Read an order",
+		responses: map[int]any{http.StatusOK: presenter.OrderView{}},
+	},
+	{
+		pattern: "POST /v1/orders/{id}/pay", id: "payOrder", tag: "orders",
+		handle:    func(c controllers) http.HandlerFunc { return c.orders.PayOrder },
+		summary:   "Mark a pending order as paid",
+		responses: map[int]any{http.StatusOK: presenter.OrderView{}},
+	},
+	{
+		pattern: "POST /v1/orders/{id}/ship", id: "shipOrder", tag: "orders",
+		handle:    func(c controllers) http.HandlerFunc { return c.orders.ShipOrder },
+		summary:   "Ship a paid order",
+		responses: map[int]any{http.StatusOK: presenter.OrderView{}},
+	},
+	{
+		pattern: "POST /v1/orders/{id}/cancel", id: "cancelOrder", tag: "orders",
+		handle:    func(c controllers) http.HandlerFunc { return c.orders.CancelOrder },
+		summary:   "Cancel an order, releasing its copies",
+		responses: map[int]any{http.StatusOK: presenter.OrderView{}},
+	},
+	{
+		pattern: "POST /v1/webhooks", id: "createWebhook", tag: "webhooks",
+		handle:    func(c controllers) http.HandlerFunc { return c.webhooks.CreateWebhook },
+		summary:   "Subscribe a webhook to catalog changes",
+		body:      controller.WebhookRequest{},
+		responses: map[int]any{http.StatusCreated: presenter.WebhookView{}},
+	},
+	{
+		pattern: "GET /v1/webhooks", id: "listWebhooks", tag: "webhooks",
+		handle:    func(c controllers) http.HandlerFunc { return c.webhooks.ListWebhooks },
+		summary:   "List every webhook",
+		list:      true,
+		responses: map[int]any{http.StatusOK: presenter.WebhookListView{}},
+	},
+	{
+		pattern: "GET /v1/webhooks/{id}", id: "getWebhook", tag: "webhooks",
+		handle:    func(c controllers) http.HandlerFunc { return c.webhooks.GetWebhook },
+		summary:   "Read a webhook",
+		responses: map[int]any{http.StatusOK: presenter.WebhookView{}},
+	},
+	{
+		pattern: "DELETE /v1/webhooks/{id}", id: "deleteWebhook", tag: "webhooks",
+		handle:    func(c controllers) http.HandlerFunc { return c.webhooks.DeleteWebhook },
+		summary:   "Unsubscribe a webhook",
+		responses: map[int]any{http.StatusOK: nil},
+	},
+	{
+		pattern: "GET /v1/webhooks/{id}/deliveries", id: "listDeliveries", tag: "webhooks",
+		handle:    func(c controllers) http.HandlerFunc { return c.webhooks.ListDeliveries },
+		summary:   "List the deliveries to a webhook, newest first",
+		list:      true,
+		responses: map[int]any{http.StatusOK: presenter.DeliveryListView{}},
+	},
+	{
+		pattern: "POST /graphql", id: "graphql", tag: "graphql",
+		handle:  func(c controllers) http.HandlerFunc { return c.graphQL.ServeHTTP },
+		summary: "Execute a GraphQL operation on the catalog",
+		body:    graphQLRequest{},
+		responses: map[int]any{
+			http.StatusOK:         content{"application/json": graphql.Result{}},
+			http.StatusBadRequest: content{"application/json": graphql.Result{}},
+		},
+	},
+	{
+		pattern: "GET /openapi.json", id: "getOpenAPI", tag: "docs",
+		handle:    func(controllers) http.HandlerFunc { return serveOpenAPI },
+		summary:   "Read this document",
+		responses: map[int]any{http.StatusOK: content{"application/json": map[string]any{}}},
+	},
+	{
+		pattern: "GET /docs", id: "getDocs", tag: "docs",
+		handle:    func(controllers) http.HandlerFunc { return serveDocs },
+		summary:   "Read this document as a web page",
+		responses: map[int]any{http.StatusOK: content{"text/html": nil}},
+	},
+}
+
+// requestHeaders are the request headers operations can read, besides the ActorHeader of every change.
+var requestHeaders = map[string]string{
+	"If-Match":        "ETag the book must still have, for the request to succeed",
+	"Accept-Language": "languages the client prefers, matched against the language of the books",
+	ActorHeader:       "actor the change is attributed to in the audit log",
+}
+
+// pathParam matches the parameters of a path.
+var pathParam = regexp.MustCompile(`{(\w+)}`)
+
+type openAPIDocument struct {
+	OpenAPI    string                                  `json:"openapi"`
+	Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+	Components openAPIComponents                       `json:"components"`
+	Info       openAPIInfo                             `json:"info"`
+}
+
+type openAPIInfo struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Version     string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*schema `json:"schemas"`
+}
+
+type openAPIOperation struct {
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Tags        []string                    `json:"tags"`
+	Parameters  []*openAPIParameter         `json:"parameters,omitempty"`
+}
+
+type openAPIParameter struct {
+	Schema      *schema `json:"schema"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+}
+
+type openAPIRequestBody struct {
+	Content  map[string]*openAPIMediaType `json:"content"`
+	Required bool                         `json:"required"`
+}
+
+type openAPIMediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+type openAPIResponse struct {
+	Headers     map[string]*openAPIHeader    `json:"headers,omitempty"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+	Description string                       `json:"description"`
+}
+
+type openAPIHeader struct {
+	Schema      *schema `json:"schema"`
+	Description string  `json:"description"`
+}
+
+// OpenAPI generates the OpenAPI 3.1 document of the routes of NewHandler from the Go types
+// of their requests and responses. The service serves the copy embedded from openapi.json,
+// which is kept up to date with it.
+func OpenAPI() ([]byte, error) {
+	g := &schemaGenerator{components: map[string]*schema{}}
+	doc := &openAPIDocument{
+		OpenAPI: "3.1.0",
+		Info: openAPIInfo{
+			Title:       "Bookshop",
+			Description: "Catalog, stock, checkout and webhooks of the bookshop.",
+			Version:     "v1",
+		},
+		Paths:      map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{Schemas: g.components},
+	}
+	for _, op := range operations {
+		method, path, _ := strings.Cut(op.pattern, " ")
+		if op.path != "" {
+			path = op.path
+		}
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*openAPIOperation{}
+		}
+		doc.Paths[path][strings.ToLower(method)] = g.operation(op, method, path)
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// operation returns the OpenAPI operation of op, served with method on path.
+func (g *schemaGenerator) operation(op operation, method, path string) *openAPIOperation {
+	o := &openAPIOperation{
+		OperationID: op.id,
+		Summary:     op.summary,
+		Tags:        []string{op.tag},
+		Responses: map[string]*openAPIResponse{
+			"default": {
+				Description: "The request failed",
+				Content: g.content(content{
+					"application/problem+json": presenter.ProblemView{},
+					"application/json":         presenter.ErrorView{},
+				}, false),
+			},
+		},
+	}
+	for _, m := range pathParam.FindAllStringSubmatch(path, -1) {
+		o.Parameters = append(o.Parameters, &openAPIParameter{
+			Name: m[1], In: "path", Required: true, Schema: &schema{Type: "string"},
+		})
+	}
+	if op.query != nil {
+		t := reflect.TypeOf(op.query)
+		for i := range t.NumField() {
+			f := t.Field(i)
+			p := &openAPIParameter{
+				Name:        f.Tag.Get("query"),
+				In:          "query",
+				Description: f.Tag.Get("doc"),
+				Required:    f.Tag.Get("required") == "true",
+				Schema:      g.schemaOf(f.Type),
+			}
+			o.Parameters = append(o.Parameters, p)
+		}
+	}
+	headers := op.headers
+	if method != http.MethodGet {
+		headers = append(headers, ActorHeader)
+	}
+	for _, h := range headers {
+		o.Parameters = append(o.Parameters, &openAPIParameter{
+			Name: h, In: "header", Description: requestHeaders[h], Schema: &schema{Type: "string"},
+		})
+	}
+	if op.body != nil {
+		o.RequestBody = &openAPIRequestBody{Required: true, Content: g.content(op.body, true)}
+	}
+	for code, body := range op.responses {
+		res := &openAPIResponse{Description: http.StatusText(code)}
+		if body != nil {
+			res.Content = g.content(views(body, op.list), false)
+		}
+		if op.etag && code < http.StatusMultipleChoices {
+			res.Headers = map[string]*openAPIHeader{
+				"ETag": {Description: "version of the book", Schema: &schema{Type: "string"}},
+			}
+		}
+		o.Responses[strconv.Itoa(code)] = res
+	}
+	return o
+}
+
+// content returns the media types of a request or response body.
+func (g *schemaGenerator) content(body any, request bool) map[string]*openAPIMediaType {
+	media, ok := body.(content)
+	if !ok {
+		media = content{"application/json": body}
+	}
+	res := make(map[string]*openAPIMediaType, len(media))
+	for mediaType, v := range media {
+		s := &schema{Type: "string"}
+		switch {
+		case v == nil:
+		case request:
+			s = g.requestSchemaOf(reflect.TypeOf(v))
+		default:
+			s = g.schemaOf(reflect.TypeOf(v))
+		}
+		res[mediaType] = &openAPIMediaType{Schema: s}
+	}
+	return res
+}
+
+// views returns the media types body is offered in: every one of viewMediaTypes if it is a view model,
+// along with text/csv if it is a list.
+func views(body any, list bool) content {
+	if media, ok := body.(content); ok {
+		return media
+	}
+	media := content{}
+	for _, mediaType := range viewMediaTypes {
+		media[mediaType] = body
+	}
+	if list {
+		media["text/csv"] = nil
+	}
+	return media
+}
+
+// serveOpenAPI serves the OpenAPI document of the service.
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openAPISpec)
+}
+
+// serveDocs serves the page rendering the OpenAPI document of the service.
+func serveDocs(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = w.Write(docsPage)
+}
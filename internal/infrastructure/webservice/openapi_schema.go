@@ -0,0 +1,158 @@
+package webservice
+
+import (
+	"path"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// modulePath prefixes the package path of the types of the service, named after their type alone in the document.
+const modulePath = "github.com/CanobbioE/strict-clean-arch-go-webservice/"
+
+// schema is a JSON Schema, as used by OpenAPI 3.1.
+// Type is either a type name, or a list of them for nullable types.
+type schema struct {
+	Type                 any                `json:"type,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	OneOf                []*schema          `json:"oneOf,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+}
+
+// schemaGenerator derives the schemas of Go types from their JSON encoding.
+// Named struct types are collected as components, and referenced where they are used.
+// The fields of responses are required unless they are omitempty, while the fields of requests
+// are only required if they are tagged so, see requestSchemaOf.
+type schemaGenerator struct {
+	components map[string]*schema
+	requests   bool
+}
+
+// requestSchemaOf returns the schema of the JSON encoding of the request type t.
+func (g *schemaGenerator) requestSchemaOf(t reflect.Type) *schema {
+	g.requests = true
+	defer func() { g.requests = false }()
+	return g.schemaOf(t)
+}
+
+// schemaOf returns the schema of the JSON encoding of t.
+func (g *schemaGenerator) schemaOf(t reflect.Type) *schema {
+	if t == reflect.TypeFor[time.Time]() {
+		return &schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		if g.requests {
+			// Pointers tell missing fields of requests apart, rather than null ones.
+			return g.schemaOf(t.Elem())
+		}
+		return nullable(g.schemaOf(t.Elem()))
+	case reflect.String:
+		return &schema{Type: "string"}
+	case reflect.Bool:
+		return &schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		name := componentName(t)
+		if _, ok := g.components[name]; !ok {
+			// The placeholder ends the recursion of self referencing types.
+			g.components[name] = &schema{}
+			g.components[name] = g.object(t)
+		}
+		return &schema{Ref: "#/components/schemas/" + name}
+	default:
+		return &schema{}
+	}
+}
+
+// object returns the schema of the fields of the struct type t.
+func (g *schemaGenerator) object(t reflect.Type) *schema {
+	s := &schema{Type: "object", Properties: map[string]*schema{}}
+	g.fields(s, t, true)
+	return s
+}
+
+// fields adds the properties of the fields of the struct type t to s.
+// The fields of embedded structs are promoted like encoding/json does, optional if the struct is a pointer.
+func (g *schemaGenerator) fields(s *schema, t reflect.Type, required bool) {
+	var embedded []reflect.StructField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+			continue
+		case f.Anonymous && name == "":
+			embedded = append(embedded, f)
+			continue
+		case !f.IsExported():
+			continue
+		case name == "":
+			name = f.Name
+		}
+		if _, ok := s.Properties[name]; ok {
+			continue
+		}
+
+		p := g.schemaOf(f.Type)
+		if format := f.Tag.Get("format"); format != "" {
+			p.Format = format
+		}
+		p.Description = f.Tag.Get("doc")
+		s.Properties[name] = p
+		if f.Tag.Get("required") == "true" || (!g.requests && required && !strings.Contains(opts, "omitempty")) {
+			s.Required = append(s.Required, name)
+		}
+	}
+	for _, f := range embedded {
+		if f.Type.Kind() == reflect.Pointer {
+			g.fields(s, f.Type.Elem(), false)
+			continue
+		}
+		g.fields(s, f.Type, required)
+	}
+}
+
+// nullable returns s, also accepting null.
+func nullable(s *schema) *schema {
+	if t, ok := s.Type.(string); ok {
+		s.Type = []string{t, "null"}
+		return s
+	}
+	return &schema{OneOf: []*schema{s, {Type: "null"}}}
+}
+
+// componentName returns the name the schema of the named type t is a component with.
+// Types of other modules are prefixed with their package name, e.g. GraphqlResult.
+func componentName(t reflect.Type) string {
+	name := t.Name()
+	if !strings.HasPrefix(t.PkgPath(), modulePath) {
+		name = capitalize(path.Base(t.PkgPath())) + capitalize(name)
+	}
+	return capitalize(name)
+}
+
+func capitalize(s string) string {
+	r := []rune(s)
+	if len(r) > 0 {
+		r[0] = unicode.ToUpper(r[0])
+	}
+	return string(r)
+}
@@ -0,0 +1,54 @@
+package webservice_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webservice"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+)
+
+func TestOpenAPI(t *testing.T) {
+	generated, err := webservice.OpenAPI()
+	if err != nil {
+		t.Fatal("failed to generate the OpenAPI document:", err)
+	}
+
+	mockCtl := gomock.NewController(t)
+	handler := webservice.NewHandler(
+		mocks.NewMockBookController(mockCtl), mocks.NewMockBookSearchController(mockCtl),
+		mocks.NewMockInventoryController(mockCtl), mocks.NewMockOrderController(mockCtl),
+		mocks.NewMockAuthorController(mockCtl), mocks.NewMockWebhookController(mockCtl), http.NotFoundHandler(),
+	)
+	for _, tt := range []struct {
+		path        string
+		contentType string
+	}{
+		{path: "/openapi.json", contentType: "application/json"},
+		{path: "/docs", contentType: "text/html; charset=utf-8"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, http.NoBody))
+
+			if rec.Code != http.StatusOK {
+				t.Errorf("status code = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if tt.path != "/openapi.json" {
+				return
+			}
+			served, _ := io.ReadAll(rec.Body)
+			if !bytes.Equal(bytes.TrimSpace(served), generated) {
+				t.Error("the served OpenAPI document is out of date with the Go types, run make generate-openapi")
+			}
+		})
+	}
+}
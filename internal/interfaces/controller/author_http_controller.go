@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// AuthorInteractor is the interface an interactor must implement
+// to be used by the AuthorController to execute business logic.
+type AuthorInteractor interface {
+	// CreateAuthor sends the author to be created to the underlying repository.
+	CreateAuthor(ctx context.Context, author *domain.Author) error
+	// GetAuthor retrieves a domain.Author by its ID.
+	GetAuthor(ctx context.Context, id string) (*domain.Author, error)
+	// ListAuthors retrieves every author, sorted by name.
+	ListAuthors(ctx context.Context) ([]*domain.Author, error)
+	// RenameAuthor changes the name of the author matching id and returns the updated author.
+	RenameAuthor(ctx context.Context, id, name string) (*domain.Author, error)
+	// DeleteAuthor removes an author without books from the repository.
+	DeleteAuthor(ctx context.Context, id string) error
+}
+
+// AuthorPresenter is the interface a presenter must implement
+// to be used by the AuthorController to return successful responses.
+type AuthorPresenter interface {
+	// Present prepares the domain.Author message to be returned.
+	Present(author *domain.Author) map[string]any
+}
+
+// AuthorController handles author requests over http.
+type AuthorController struct {
+	interactor      AuthorInteractor
+	authorPresenter AuthorPresenter
+	errPresenter    ErrorPresenter
+	resPresenter    ResponsePresenter
+	logger          *slog.Logger
+}
+
+// NewAuthorController creates a new instance of AuthorController.
+func NewAuthorController(
+	logger *slog.Logger,
+	interactor AuthorInteractor,
+	authorPresenter AuthorPresenter,
+	errPresenter ErrorPresenter,
+	resPresenter ResponsePresenter,
+) *AuthorController {
+	return &AuthorController{
+		interactor:      interactor,
+		logger:          logger,
+		authorPresenter: authorPresenter,
+		errPresenter:    errPresenter,
+		resPresenter:    resPresenter,
+	}
+}
+
+// CreateAuthor handles AuthorRequest over http.
+// Responds with the created author.
+func (ac *AuthorController) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+	req, ok := ac.decode(w, r)
+	if !ok {
+		return
+	}
+
+	author := &domain.Author{Name: strings.TrimSpace(req.Name)}
+	if err := ac.interactor.CreateAuthor(r.Context(), author); err != nil {
+		ac.logger.With("error", err).Error("unable to create author")
+		ac.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	ac.present(w, r, http.StatusCreated, author)
+}
+
+// GetAuthor handles read author by ID requests over http.
+func (ac *AuthorController) GetAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := ac.id(w, r)
+	if !ok {
+		return
+	}
+
+	author, err := ac.interactor.GetAuthor(r.Context(), id)
+	if err != nil {
+		ac.logger.With("author_id", id, "error", err).Error("error getting author")
+		ac.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	ac.present(w, r, http.StatusOK, author)
+}
+
+// ListAuthors handles read authors requests over http.
+func (ac *AuthorController) ListAuthors(w http.ResponseWriter, r *http.Request) {
+	authors, err := ac.interactor.ListAuthors(r.Context())
+	if err != nil {
+		ac.logger.With("error", err).Error("error listing authors")
+		ac.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]map[string]any, len(authors))
+	for i, author := range authors {
+		res[i] = ac.authorPresenter.Present(author)
+	}
+	ac.resPresenter.PresentList(w, r, http.StatusOK, map[string]any{"authors": res}, "authors")
+}
+
+// RenameAuthor handles AuthorRequest for an existing author over http.
+// Responds with the renamed author.
+func (ac *AuthorController) RenameAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := ac.id(w, r)
+	if !ok {
+		return
+	}
+	req, ok := ac.decode(w, r)
+	if !ok {
+		return
+	}
+
+	author, err := ac.interactor.RenameAuthor(r.Context(), id, strings.TrimSpace(req.Name))
+	if err != nil {
+		ac.logger.With("author_id", id, "error", err).Error("error renaming author")
+		ac.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	ac.present(w, r, http.StatusOK, author)
+}
+
+// DeleteAuthor handles delete author by ID requests over http.
+// Authors still credited on books are not deleted.
+func (ac *AuthorController) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
+	id, ok := ac.id(w, r)
+	if !ok {
+		return
+	}
+
+	if err := ac.interactor.DeleteAuthor(r.Context(), id); err != nil {
+		ac.logger.With("author_id", id, "error", err).Error("error deleting author")
+		ac.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+	}
+}
+
+// id reads the author id path value, presenting an error if it is missing.
+func (ac *AuthorController) id(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := r.PathValue("id")
+	if id == "" {
+		ac.errPresenter.Present(w, r, errors.New("author id is required"), http.StatusBadRequest)
+		return "", false
+	}
+	return id, true
+}
+
+// decode reads and validates an AuthorRequest from the body of r, presenting an error if it fails.
+func (ac *AuthorController) decode(w http.ResponseWriter, r *http.Request) (*AuthorRequest, bool) {
+	var req AuthorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		ac.logger.With("error", err).Error("unable to decode request body")
+		ac.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return nil, false
+	}
+	if err := req.Validate(); err != nil {
+		ac.logger.With("error", err).Error("invalid request body")
+		ac.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return nil, false
+	}
+	return &req, true
+}
+
+func (ac *AuthorController) present(w http.ResponseWriter, r *http.Request, status int, author *domain.Author) {
+	ac.resPresenter.Present(w, r, status, ac.authorPresenter.Present(author))
+}
@@ -0,0 +1,130 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestAuthorController(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockAuthorInteractor := mocks.NewMockAuthorInteractor(mockCtl)
+	authorID := uuid.New()
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ac := controller.NewAuthorController(
+		logger,
+		mockAuthorInteractor,
+		presenter.NewAuthorPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		id               string
+		body             string
+		handler          http.HandlerFunc
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:     "fails to create without name",
+			body:     `{"name":"  "}`,
+			handler:  ac.CreateAuthor,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"name is required","status":"Bad Request"}`,
+		},
+		{
+			name:    "creates an author",
+			body:    `{"name":" Jane Austen "}`,
+			handler: ac.CreateAuthor,
+			mockExpectations: func() {
+				mockAuthorInteractor.EXPECT().
+					CreateAuthor(gomock.Any(), &domain.Author{Name: "Jane Austen"}).
+					DoAndReturn(func(_ any, a *domain.Author) error {
+						a.ID, a.CreatedAt = authorID, createdAt
+						return nil
+					})
+			},
+			wantCode: http.StatusCreated,
+			want: `{"created_at":"2024-05-01T12:00:00Z","id":"author:` + authorID.String() +
+				`","name":"Jane Austen"}`,
+		},
+		{
+			name:    "fails to get a missing author",
+			id:      authorID.String(),
+			handler: ac.GetAuthor,
+			mockExpectations: func() {
+				mockAuthorInteractor.EXPECT().
+					GetAuthor(gomock.Any(), authorID.String()).
+					Return(nil, domain.ErrAuthorNotFound)
+			},
+			wantCode: http.StatusNotFound,
+			want:     `{"message":"author not found","status":"Not Found"}`,
+		},
+		{
+			name:    "renames an author",
+			id:      authorID.String(),
+			body:    `{"name":"J.R.R. Tolkien"}`,
+			handler: ac.RenameAuthor,
+			mockExpectations: func() {
+				mockAuthorInteractor.EXPECT().
+					RenameAuthor(gomock.Any(), authorID.String(), "J.R.R. Tolkien").
+					Return(&domain.Author{ID: authorID, Name: "J.R.R. Tolkien", CreatedAt: createdAt}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"created_at":"2024-05-01T12:00:00Z","id":"author:` + authorID.String() +
+				`","name":"J.R.R. Tolkien"}`,
+		},
+		{
+			name:    "refuses to delete an author with books",
+			id:      authorID.String(),
+			handler: ac.DeleteAuthor,
+			mockExpectations: func() {
+				mockAuthorInteractor.EXPECT().
+					DeleteAuthor(gomock.Any(), authorID.String()).
+					Return(domain.ErrAuthorHasBooks)
+			},
+			wantCode: http.StatusConflict,
+			want:     `{"message":"author still has books","status":"Conflict"}`,
+		},
+		{
+			name:     "fails to delete without id",
+			handler:  ac.DeleteAuthor,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"author id is required","status":"Bad Request"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/authors/"+tt.id, strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			tt.handler(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// AuthorRequest defines the expected request to create or rename an author.
+type AuthorRequest struct {
+	Name string `json:"name" required:"true"`
+}
+
+// Validate an AuthorRequest.
+func (r *AuthorRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return domain.NewFieldError("name", "name is required")
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// exportTimeout is how long a catalog export has to stream its body,
+// past the write timeout of the server, which is sized for single book requests.
+const exportTimeout = time.Hour
+
+// exportColumns are the CSV columns of a catalog export, named after the fields of BookPresenter.PresentExport.
+var exportColumns = []string{
+	"id", "isbn", "title", "author", "authors", "language", "price", "currency", "formatted_price",
+	"created_at", "version", "deleted_at",
+}
+
+// exportAuditColumns are the CSV columns added to exportColumns by exports including audit metadata.
+var exportAuditColumns = []string{"created_by", "updated_by", "updated_at", "changes"}
+
+// ExportBooks handles catalog export requests over http.
+// The catalog is streamed as CSV, NDJSON or a JSON array, as told by the format query parameter,
+// one book at a time as it is read, each presented with BookPresenter.PresentExport.
+// Soft-deleted books and audit metadata are included on request.
+// Errors occurring once the response started abort it, so clients can't mistake a partial export for a whole one.
+func (bc *BookController) ExportBooks(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseExportBooksRequest(r.URL.Query())
+	if err == nil {
+		err = q.Validate()
+	}
+	if err != nil {
+		bc.logger.With("error", err).Error("unable to validate request")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(exportTimeout))
+	exporter := newBookExporter(w, q)
+	started := false
+	start := func() error {
+		started = true
+		w.Header().Set("Content-Type", exporter.contentType())
+		w.Header().Set("Content-Disposition", `attachment; filename="books.`+q.Format+`"`)
+		return exporter.begin()
+	}
+
+	opts := domain.ExportOptions{WithTrashed: q.IncludeDeleted, WithAudit: q.IncludeAudit}
+	err = bc.interactor.ExportBooks(r.Context(), opts, func(exported *domain.ExportedBook) error {
+		if !started {
+			if err := start(); err != nil {
+				return err
+			}
+		}
+		return exporter.write(bc.bookPresenter.PresentExport(exported))
+	})
+	if err == nil && !started {
+		err = start()
+	}
+	if err == nil {
+		err = exporter.end()
+	}
+	switch {
+	case err == nil:
+	case !started:
+		bc.logger.With("error", err).Error("unable to export books")
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+	default:
+		bc.logger.With("error", err).Error("catalog export interrupted")
+		panic(http.ErrAbortHandler)
+	}
+}
+
+// bookExporter writes the books of a catalog export in one of the export formats.
+type bookExporter interface {
+	contentType() string
+	// begin writes what comes before the first book.
+	begin() error
+	write(book map[string]any) error
+	// end writes what comes after the last book and flushes the export.
+	end() error
+}
+
+// newBookExporter returns the bookExporter of the format of q, writing to w.
+func newBookExporter(w io.Writer, q *ExportBooksRequest) bookExporter {
+	switch q.Format {
+	case exportCSV:
+		columns := exportColumns
+		if q.IncludeAudit {
+			columns = append(columns[:len(columns):len(columns)], exportAuditColumns...)
+		}
+		return &csvExporter{w: csv.NewWriter(w), columns: columns}
+	case exportNDJSON:
+		return &ndjsonExporter{enc: json.NewEncoder(w)}
+	default:
+		return &jsonExporter{w: w}
+	}
+}
+
+// csvExporter writes a header row, then a row per book.
+// Credits are listed in the authors column like a bulk import expects them, see ReadImportRows.
+type csvExporter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func (e *csvExporter) contentType() string {
+	return csvContentType + "; charset=utf-8"
+}
+
+func (e *csvExporter) begin() error {
+	return e.w.Write(e.columns)
+}
+
+func (e *csvExporter) write(book map[string]any) error {
+	record := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		record[i] = csvValue(book[c])
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExporter) end() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvValue returns the text form of a value of BookPresenter.PresentExport; null values are empty.
+func csvValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case []map[string]any:
+		credits := make([]string, len(v))
+		for i, c := range v {
+			credits[i] = fmt.Sprint(c["id"]) + ":" + fmt.Sprint(c["role"])
+		}
+		return strings.Join(credits, ";")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ndjsonExporter writes a JSON object per line.
+type ndjsonExporter struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonExporter) contentType() string {
+	return ndjsonContentType
+}
+
+func (e *ndjsonExporter) begin() error {
+	return nil
+}
+
+func (e *ndjsonExporter) write(book map[string]any) error {
+	return e.enc.Encode(book)
+}
+
+func (e *ndjsonExporter) end() error {
+	return nil
+}
+
+// jsonExporter writes a JSON array, with a book per line.
+type jsonExporter struct {
+	w       io.Writer
+	written bool
+}
+
+func (e *jsonExporter) contentType() string {
+	return "application/json"
+}
+
+func (e *jsonExporter) begin() error {
+	_, err := io.WriteString(e.w, "[")
+	return err
+}
+
+func (e *jsonExporter) write(book map[string]any) error {
+	data, err := json.Marshal(book)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if !e.written {
+		sep, e.written = "\n", true
+	}
+	if _, err = io.WriteString(e.w, sep); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExporter) end() error {
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
@@ -0,0 +1,190 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestBookController_ExportBooks(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	bc := controller.NewBookController(
+		logger,
+		mockBookInteractor,
+		presenter.NewBookPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	dune := &domain.ExportedBook{Book: &domain.Book{
+		ID:          uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42"),
+		Title:       "dune",
+		Author:      "Frank Herbert",
+		LanguageTag: "en",
+		ISBN:        "9780306406157",
+		Authors: []domain.BookAuthor{{
+			AuthorID: uuid.MustParse("3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c"), Name: "Frank Herbert", Role: domain.RoleAuthor,
+		}},
+		Price:     domain.Money{Amount: 1299, Currency: "EUR"},
+		CreatedAt: createdAt,
+		Version:   2,
+	}}
+	trashed := &domain.ExportedBook{
+		Book: &domain.Book{
+			ID:          uuid.MustParse("0b7c4b2e-6d1f-4f0a-8d3a-7e9c2a1b5c33"),
+			Title:       "gone",
+			Author:      "Someone",
+			LanguageTag: "en",
+			Price:       domain.Money{Amount: 500, Currency: "EUR"},
+			CreatedAt:   createdAt,
+			DeletedAt:   createdAt.Add(time.Hour),
+			Version:     3,
+		},
+		Audit: &domain.AuditSummary{
+			UpdatedAt: createdAt.Add(time.Hour), CreatedBy: "alice", UpdatedBy: "bob", Changes: 2,
+		},
+	}
+	// export stands in for the interactor, passing books to fn and then failing with err.
+	export := func(err error, books ...*domain.ExportedBook) func(
+		context.Context, domain.ExportOptions, func(*domain.ExportedBook) error,
+	) error {
+		return func(_ context.Context, _ domain.ExportOptions, fn func(*domain.ExportedBook) error) error {
+			for _, b := range books {
+				if err := fn(b); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+	duneJSON := `{"author":"Frank Herbert","authors":[{"id":"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c",` +
+		`"name":"Frank Herbert","role":"author"}],"created_at":"2024-05-01T12:00:00Z","currency":"EUR",` +
+		`"deleted_at":null,"formatted_price":"€ 12.99","id":"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42",` +
+		`"isbn":"9780306406157","language":"en","price":1299,"title":"Dune","version":2}`
+
+	tests := []struct {
+		name             string
+		query            string
+		mockExpectations func()
+		wantCode         int
+		wantContentType  string
+		want             string
+	}{
+		{
+			name:     "fails with an unsupported format",
+			query:    "?format=xml",
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"format must be one of csv, ndjson, json","status":"Bad Request"}`,
+		},
+		{
+			name:     "fails with an invalid query parameter",
+			query:    "?include_deleted=maybe",
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"include_deleted must be a boolean","status":"Bad Request"}`,
+		},
+		{
+			name: "fails if the interactor fails before the first book",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().ExportBooks(gomock.Any(), domain.ExportOptions{}, gomock.Any()).
+					DoAndReturn(export(errors.New("oops")))
+			},
+			wantCode: http.StatusInternalServerError,
+			want:     `{"message":"internal server error","status":"Internal Server Error"}`,
+		},
+		{
+			name: "exports an empty json array",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().ExportBooks(gomock.Any(), domain.ExportOptions{}, gomock.Any()).
+					DoAndReturn(export(nil))
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "application/json",
+			want:            "[\n]",
+		},
+		{
+			name: "exports json",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().ExportBooks(gomock.Any(), domain.ExportOptions{}, gomock.Any()).
+					DoAndReturn(export(nil, dune, dune))
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "application/json",
+			want:            "[\n" + duneJSON + ",\n" + duneJSON + "\n]",
+		},
+		{
+			name:  "exports ndjson",
+			query: "?format=ndjson",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().ExportBooks(gomock.Any(), domain.ExportOptions{}, gomock.Any()).
+					DoAndReturn(export(nil, dune, dune))
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "application/x-ndjson",
+			want:            duneJSON + "\n" + duneJSON,
+		},
+		{
+			name:  "exports csv with trashed books and audit metadata",
+			query: "?format=csv&include_deleted=true&include_audit=1",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ExportBooks(gomock.Any(), domain.ExportOptions{WithTrashed: true, WithAudit: true}, gomock.Any()).
+					DoAndReturn(export(nil, dune, trashed))
+			},
+			wantCode:        http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			want: "id,isbn,title,author,authors,language,price,currency,formatted_price,created_at,version," +
+				"deleted_at,created_by,updated_by,updated_at,changes\n" +
+				"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42,9780306406157,Dune,Frank Herbert," +
+				"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c:author,en,1299,EUR,€ 12.99,2024-05-01T12:00:00Z,2,,,,,\n" +
+				"book:0b7c4b2e-6d1f-4f0a-8d3a-7e9c2a1b5c33,,Gone,Someone,,en,500,EUR,€ 5.00,2024-05-01T12:00:00Z,3," +
+				"2024-05-01T13:00:00Z,alice,bob,2024-05-01T13:00:00Z,2",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodGet, "/v1/books:export"+tt.query, nil)
+			r.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+
+			bc.ExportBooks(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if tt.wantContentType != "" && w.Header().Get("Content-Type") != tt.wantContentType {
+				t.Errorf("want content type %s, got %s", tt.wantContentType, w.Header().Get("Content-Type"))
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("aborts the response if the interactor fails after the first book", func(t *testing.T) {
+		mockBookInteractor.EXPECT().ExportBooks(gomock.Any(), domain.ExportOptions{}, gomock.Any()).
+			DoAndReturn(export(errors.New("oops"), dune))
+		defer func() {
+			if got, _ := recover().(error); !errors.Is(got, http.ErrAbortHandler) {
+				t.Errorf("want panic %v, got %v", http.ErrAbortHandler, got)
+			}
+		}()
+		bc.ExportBooks(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/books:export", nil))
+	})
+}
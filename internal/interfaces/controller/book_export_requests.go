@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"net/url"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// Formats a catalog export can be written in.
+const (
+	exportCSV    = "csv"
+	exportNDJSON = "ndjson"
+	exportJSON   = "json"
+)
+
+// ExportBooksRequest defines the expected query parameters for a catalog export.
+type ExportBooksRequest struct {
+	Format         string `query:"format" doc:"one of csv, ndjson, json; json when missing"`
+	IncludeDeleted bool   `query:"include_deleted"`
+	IncludeAudit   bool   `query:"include_audit"`
+}
+
+// ParseExportBooksRequest reads an ExportBooksRequest from URL query parameters.
+// Missing boolean parameters default to false.
+func ParseExportBooksRequest(values url.Values) (*ExportBooksRequest, error) {
+	r := &ExportBooksRequest{Format: values.Get("format")}
+	if r.Format == "" {
+		r.Format = exportJSON
+	}
+	err := parseBoolParams(values,
+		boolParam{name: "include_deleted", dst: &r.IncludeDeleted},
+		boolParam{name: "include_audit", dst: &r.IncludeAudit},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Validate an ExportBooksRequest.
+func (r *ExportBooksRequest) Validate() error {
+	switch r.Format {
+	case exportCSV, exportNDJSON, exportJSON:
+		return nil
+	default:
+		return domain.NewFieldError("format", "format must be one of csv, ndjson, json")
+	}
+}
@@ -4,12 +4,13 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
 
-	"github.com/google/uuid"
+	"golang.org/x/text/language"
 
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 )
@@ -18,29 +19,63 @@ import (
 // to be used by the BookController to execute business logic.
 type BookInteractor interface {
 	// CreateBook sends the book to be created to the underlying repository.
-	CreateBook(book *domain.Book) error
+	CreateBook(ctx context.Context, book *domain.Book) error
 	// GetBook retrieves a domain.Book by its ID.
-	GetBook(id string) (*domain.Book, error)
-	// ListBooks retrieves a list of books.
-	ListBooks() ([]*domain.Book, error)
-	// UpdateBook updates a single book by its ID.
-	UpdateBook(book *domain.Book) error
-	// DeleteBook removes a book from the repository.
-	DeleteBook(id string) error
+	GetBook(ctx context.Context, id string) (*domain.Book, error)
+	// GetBookByISBN retrieves a domain.Book by its ISBN.
+	GetBookByISBN(ctx context.Context, isbn string) (*domain.Book, error)
+	// ListBooks retrieves a page of books matching query.
+	ListBooks(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error)
+	// UpdateBook updates the price of a single book by its ID.
+	UpdateBook(ctx context.Context, book *domain.Book) error
+	// PatchBook applies patch to the book matching id and returns the updated book.
+	PatchBook(ctx context.Context, id string, patch *domain.BookPatch, version int) (*domain.Book, error)
+	// DeleteBook moves a book to the trash.
+	DeleteBook(ctx context.Context, id string) error
+	// ListTrash retrieves a page of trashed books matching query.
+	ListTrash(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error)
+	// RestoreBook moves a trashed book back to the catalog and returns it.
+	RestoreBook(ctx context.Context, id string) (*domain.Book, error)
+	// GetBookHistory retrieves a page of the audit log of a book.
+	GetBookHistory(ctx context.Context, id string, query domain.AuditQuery) (*domain.AuditPage, error)
+	// ImportBooks creates the books of rows and reports the outcome of every row.
+	ImportBooks(ctx context.Context, rows []*domain.ImportRow, opts domain.ImportOptions) (*domain.ImportReport, error)
+	// ExportBooks calls fn with every book of the catalog, as it is read.
+	ExportBooks(ctx context.Context, opts domain.ExportOptions, fn func(*domain.ExportedBook) error) error
 }
 
 // BookPresenter is the interface a presenter must implement
 // to be used by the BookController to return successful responses.
 type BookPresenter interface {
-	// Present prepares the domain.Book message to be returned.
-	Present(book *domain.Book) map[string]any
+	// Present prepares the domain.Book message to be returned,
+	// matching its language against the preferred ones, if any.
+	Present(book *domain.Book, preferred ...language.Tag) map[string]any
+	// PresentPage prepares the domain.BookPage message to be returned,
+	// matching the language of its books against the preferred ones, if any.
+	PresentPage(page *domain.BookPage, preferred ...language.Tag) map[string]any
+	// PresentHistory prepares the domain.AuditPage message to be returned.
+	PresentHistory(page *domain.AuditPage) map[string]any
+	// PresentImport prepares the domain.ImportReport message to be returned.
+	PresentImport(report *domain.ImportReport) map[string]any
+	// PresentExport prepares a domain.ExportedBook to be returned.
+	PresentExport(exported *domain.ExportedBook) map[string]any
 }
 
 // ErrorPresenter is the interface a presenter must implement
 // to be used by the BookController to return error responses.
 type ErrorPresenter interface {
 	// Present prepares the error message to be returned through w.
-	Present(w http.ResponseWriter, err error, code int)
+	Present(w http.ResponseWriter, r *http.Request, err error, code int)
+}
+
+// ResponsePresenter is the interface a presenter must implement
+// to be used by the controllers to return view models, encoded as the request accepts.
+type ResponsePresenter interface {
+	// Present writes view through w with status.
+	Present(w http.ResponseWriter, r *http.Request, status int, view any)
+	// PresentList writes view, a list of objects held by its field named items,
+	// or view itself if items is empty, through w with status.
+	PresentList(w http.ResponseWriter, r *http.Request, status int, view any, items string)
 }
 
 // BookController handles http requests, validates them and transform them into domain objects.
@@ -49,6 +84,7 @@ type BookController struct {
 	interactor    BookInteractor
 	bookPresenter BookPresenter
 	errPresenter  ErrorPresenter
+	resPresenter  ResponsePresenter
 	logger        *slog.Logger
 }
 
@@ -58,12 +94,14 @@ func NewBookController(
 	interactor BookInteractor,
 	bookPresenter BookPresenter,
 	errPresenter ErrorPresenter,
+	resPresenter ResponsePresenter,
 ) *BookController {
 	return &BookController{
 		interactor:    interactor,
 		logger:        logger,
 		bookPresenter: bookPresenter,
 		errPresenter:  errPresenter,
+		resPresenter:  resPresenter,
 	}
 }
 
@@ -72,23 +110,19 @@ func (bc *BookController) CreateBook(w http.ResponseWriter, r *http.Request) {
 	var b CreateBookRequest
 	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
 		bc.logger.With("error", err).Error("unable to decode request body")
-		bc.errPresenter.Present(w, err, http.StatusBadRequest)
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	if err := b.Validate(); err != nil {
 		bc.logger.With("error", err).Error("invalid request body")
-		bc.errPresenter.Present(w, err, http.StatusBadRequest)
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
 		return
 	}
 
-	if err := bc.interactor.CreateBook(&domain.Book{
-		Title:  b.Title,
-		Author: b.Author,
-		Price:  b.Price,
-	}); err != nil {
+	if err := bc.interactor.CreateBook(r.Context(), b.Book()); err != nil {
 		bc.logger.With("error", err).Error("unable to create book")
-		bc.errPresenter.Present(w, err, http.StatusInternalServerError)
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusCreated)
@@ -100,88 +134,264 @@ func (bc *BookController) GetBook(w http.ResponseWriter, r *http.Request) {
 	l := bc.logger.With("book_id", id)
 
 	if id == "" {
-		bc.errPresenter.Present(w, errors.New("book id is required"), http.StatusBadRequest)
+		bc.errPresenter.Present(w, r, errors.New("book id is required"), http.StatusBadRequest)
 		return
 	}
 
-	book, err := bc.interactor.GetBook(id)
+	book, err := bc.interactor.GetBook(r.Context(), id)
 	if err != nil {
 		l.With("error", err).Error("error getting book")
-		bc.errPresenter.Present(w, err, http.StatusInternalServerError)
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	bc.presentBook(w, r, l, book)
+}
+
+// GetBookByISBN handles read book by ISBN requests over http.
+// Both ISBN-10 and ISBN-13 are accepted, with or without hyphens.
+func (bc *BookController) GetBookByISBN(w http.ResponseWriter, r *http.Request) {
+	isbn := r.PathValue("isbn")
+	l := bc.logger.With("isbn", isbn)
+
+	if isbn == "" {
+		bc.errPresenter.Present(w, r, errors.New("isbn is required"), http.StatusBadRequest)
 		return
 	}
-	err = json.NewEncoder(w).Encode(bc.bookPresenter.Present(book))
+
+	book, err := bc.interactor.GetBookByISBN(r.Context(), isbn)
 	if err != nil {
-		l.With("error", err).Error("error presenting book")
-		bc.errPresenter.Present(w, err, http.StatusInternalServerError)
+		l.With("error", err).Error("error getting book by isbn")
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
 		return
 	}
+	bc.presentBook(w, r, l, book)
+}
+
+// presentBook writes a single book with its version and language headers.
+// A book is only available in its own language: Accept-Language does not pick the book,
+// it only tells the client how well the book language matches its preferences.
+func (bc *BookController) presentBook(w http.ResponseWriter, r *http.Request, l *slog.Logger, book *domain.Book) {
+	w.Header().Set("ETag", formatETag(book.Version))
+	w.Header().Add("Vary", "Accept-Language")
+	setContentLanguage(w, book.LanguageTag)
+	preferred := preferredLanguages(r.Header.Get("Accept-Language"))
+	bc.resPresenter.Present(w, r, http.StatusOK, bc.bookPresenter.Present(book, preferred...))
 }
 
-// ListBooks handles read books requests over http.
-func (bc *BookController) ListBooks(w http.ResponseWriter, _ *http.Request) {
-	books, err := bc.interactor.ListBooks()
+// ListBooks handles ListBooksRequest over http.
+// Books are filtered by the language query parameter only. The Accept-Language header does not filter them,
+// each book tells instead how well its language matches the languages the client prefers.
+// Content-Language lists the languages of the books returned.
+func (bc *BookController) ListBooks(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseListBooksRequest(r.URL.Query())
+	if err != nil {
+		bc.logger.With("error", err).Error("unable to parse query parameters")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = q.Validate(); err != nil {
+		bc.logger.With("error", err).Error("invalid query parameters")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+	page, err := bc.interactor.ListBooks(r.Context(), q.BookQuery())
 	if err != nil {
 		bc.logger.With("error", err).Error("error listing books")
-		bc.errPresenter.Present(w, err, http.StatusInternalServerError)
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
-	res := make([]map[string]any, len(books))
-	for i, book := range books {
-		res[i] = bc.bookPresenter.Present(book)
+	setContentLanguage(w, bookLanguages(page.Books, q.Language))
+	preferred := preferredLanguages(r.Header.Get("Accept-Language"))
+	bc.resPresenter.PresentList(w, r, http.StatusOK, bc.bookPresenter.PresentPage(page, preferred...), "books")
+}
+
+// ListTrash handles ListBooksRequest over http, listing the books in the trash.
+// Trashed books are filtered by the language query parameter only, Accept-Language is ignored.
+func (bc *BookController) ListTrash(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseListBooksRequest(r.URL.Query())
+	if err != nil {
+		bc.logger.With("error", err).Error("unable to parse query parameters")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
 	}
 
-	err = json.NewEncoder(w).Encode(res)
+	if err = q.Validate(); err != nil {
+		bc.logger.With("error", err).Error("invalid query parameters")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	page, err := bc.interactor.ListTrash(r.Context(), q.BookQuery())
 	if err != nil {
-		bc.logger.With("error", err).Error("error presenting books")
-		bc.errPresenter.Present(w, err, http.StatusInternalServerError)
+		bc.logger.With("error", err).Error("error listing trashed books")
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
 		return
 	}
+
+	bc.resPresenter.PresentList(w, r, http.StatusOK, bc.bookPresenter.PresentPage(page), "books")
 }
 
 // UpdateBook handles UpdateBookRequest over http.
+// An If-Match header makes the update conditional on the book's current ETag.
 func (bc *BookController) UpdateBook(w http.ResponseWriter, r *http.Request) {
+	version, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		bc.logger.With("error", err).Error("invalid precondition")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
 	var b UpdateBookRequest
 	if err := json.NewDecoder(r.Body).Decode(&b); err != nil {
 		bc.logger.With("error", err).Error("unable to decode request body")
-		bc.errPresenter.Present(w, err, http.StatusBadRequest)
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
 		return
 	}
 
 	if err := b.Validate(); err != nil {
 		bc.logger.With("error", err).Error("invalid request body")
-		bc.errPresenter.Present(w, err, http.StatusBadRequest)
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
 		return
 	}
 
-	err := bc.interactor.UpdateBook(&domain.Book{
-		ID:    uuid.MustParse(b.ID),
-		Price: b.Price,
-	})
+	book := b.Book(version)
+	err = bc.interactor.UpdateBook(r.Context(), book)
 	if err != nil {
 		bc.logger.With("book_id", b.ID).With("error", err).Error("error updating book")
-		bc.errPresenter.Present(w, err, http.StatusInternalServerError)
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
+	if book.Version > 0 {
+		w.Header().Set("ETag", formatETag(book.Version))
+	}
 	w.WriteHeader(http.StatusAccepted)
 }
 
+// PatchBook handles PatchBookRequest, an RFC 7396 JSON merge patch, over http.
+// An If-Match header makes the patch conditional on the book's current ETag.
+// Responds with the updated book and its new ETag.
+func (bc *BookController) PatchBook(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	l := bc.logger.With("book_id", id)
+
+	if id == "" {
+		bc.errPresenter.Present(w, r, errors.New("book id is required"), http.StatusBadRequest)
+		return
+	}
+
+	if !isMergePatch(r.Header.Get("Content-Type")) {
+		bc.errPresenter.Present(w, r,
+			errors.New("content type must be "+mergePatchContentType),
+			http.StatusUnsupportedMediaType,
+		)
+		return
+	}
+
+	version, err := parseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		l.With("error", err).Error("invalid precondition")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	p, err := ParsePatchBookRequest(r.Body)
+	if err != nil {
+		l.With("error", err).Error("unable to decode request body")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = p.Validate(); err != nil {
+		l.With("error", err).Error("invalid request body")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	book, err := bc.interactor.PatchBook(r.Context(), id, p.BookPatch(), version)
+	if err != nil {
+		l.With("error", err).Error("error patching book")
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("ETag", formatETag(book.Version))
+	setContentLanguage(w, book.LanguageTag)
+	bc.resPresenter.Present(w, r, http.StatusOK, bc.bookPresenter.Present(book))
+}
+
 // DeleteBook handles delete book by ID requests over http.
+// The book is moved to the trash, from where it can be restored until it is purged.
 func (bc *BookController) DeleteBook(w http.ResponseWriter, r *http.Request) {
 	id := r.PathValue("id")
 	l := bc.logger.With("book_id", id)
 
 	if id == "" {
-		bc.errPresenter.Present(w, errors.New("book id is required"), http.StatusBadRequest)
+		bc.errPresenter.Present(w, r, errors.New("book id is required"), http.StatusBadRequest)
 		return
 	}
 
-	err := bc.interactor.DeleteBook(id)
+	err := bc.interactor.DeleteBook(r.Context(), id)
 	if err != nil {
 		l.With("error", err).Error("error deleting book")
-		bc.errPresenter.Present(w, err, http.StatusInternalServerError)
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+}
+
+// RestoreBook handles restore trashed book by ID requests over http.
+// Responds with the restored book and its new ETag.
+func (bc *BookController) RestoreBook(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	l := bc.logger.With("book_id", id)
+
+	if id == "" {
+		bc.errPresenter.Present(w, r, errors.New("book id is required"), http.StatusBadRequest)
+		return
+	}
+
+	book, err := bc.interactor.RestoreBook(r.Context(), id)
+	if err != nil {
+		l.With("error", err).Error("error restoring book")
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	bc.presentBook(w, r, l, book)
+}
+
+// GetBookHistory handles BookHistoryRequest over http, listing the changes of a book oldest first.
+func (bc *BookController) GetBookHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	l := bc.logger.With("book_id", id)
+
+	if id == "" {
+		bc.errPresenter.Present(w, r, errors.New("book id is required"), http.StatusBadRequest)
+		return
+	}
+
+	q, err := ParseBookHistoryRequest(r.URL.Query())
+	if err != nil {
+		l.With("error", err).Error("unable to parse query parameters")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = q.Validate(); err != nil {
+		l.With("error", err).Error("invalid query parameters")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
 		return
 	}
+
+	page, err := bc.interactor.GetBookHistory(r.Context(), id, domain.AuditQuery{Cursor: q.Cursor, Limit: q.Limit})
+	if err != nil {
+		l.With("error", err).Error("error getting book history")
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	bc.resPresenter.PresentList(w, r, http.StatusOK, bc.bookPresenter.PresentHistory(page), "entries")
 }
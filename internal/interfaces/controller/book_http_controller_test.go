@@ -1,12 +1,16 @@
 package controller_test
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/mock/gomock"
@@ -19,10 +23,17 @@ import (
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
 )
 
+// The generated mocks must keep up with the interfaces they mock, see internal/test/gen.go.
+var (
+	_ controller.ErrorPresenter    = (*mocks.MockErrorPresenter)(nil)
+	_ controller.ResponsePresenter = (*mocks.MockResponsePresenter)(nil)
+)
+
 type controllerFields struct {
 	interactor    controller.BookInteractor
 	bookPresenter controller.BookPresenter
 	errPresenter  controller.ErrorPresenter
+	resPresenter  controller.ResponsePresenter
 	logger        *slog.Logger
 }
 
@@ -34,6 +45,7 @@ func TestBookController_CreateBook(t *testing.T) {
 		interactor:    mockBookInteractor,
 		bookPresenter: presenter.NewBookPresenter(logger),
 		errPresenter:  presenter.NewErrorPresenter(logger),
+		resPresenter:  presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
 		logger:        logger,
 	}
 
@@ -70,7 +82,7 @@ func TestBookController_CreateBook(t *testing.T) {
 					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
 				}
 				got := strings.TrimSpace(res.Body.String())
-				want := `{"message":"price must be greater than zero","status":"Bad Request"}`
+				want := `{"message":"price must be greater than zero; currency is required","status":"Bad Request"}`
 				if got != want {
 					t.Errorf("want %s, got %s", want, got)
 				}
@@ -82,14 +94,15 @@ func TestBookController_CreateBook(t *testing.T) {
 				{
 					"title": "a book",
 					"author": "someone",
-					"price": 42
+					"price": 42,
+					"currency": "eur"
 				}`,
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					CreateBook(&domain.Book{
+					CreateBook(gomock.Any(), &domain.Book{
 						Title:  "a book",
 						Author: "someone",
-						Price:  42,
+						Price:  domain.Money{Amount: 42, Currency: "EUR"},
 					}).
 					Return(errors.New("oops"))
 			},
@@ -110,14 +123,40 @@ func TestBookController_CreateBook(t *testing.T) {
 				{
 					"title": "a book",
 					"author": "someone",
-					"price": 42
+					"price": 42,
+					"currency": "eur"
 				}`,
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					CreateBook(&domain.Book{
+					CreateBook(gomock.Any(), &domain.Book{
 						Title:  "a book",
 						Author: "someone",
-						Price:  42,
+						Price:  domain.Money{Amount: 42, Currency: "EUR"},
+					})
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusCreated {
+					t.Errorf("want status: %d, got status %d", http.StatusCreated, res.Code)
+				}
+			},
+		},
+		{
+			name: "succeeds with language",
+			body: `
+				{
+					"title": "il nome della rosa",
+					"author": "Umberto Eco",
+					"language": "IT-it",
+					"price": 42,
+					"currency": "eur"
+				}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					CreateBook(gomock.Any(), &domain.Book{
+						Title:       "il nome della rosa",
+						Author:      "Umberto Eco",
+						LanguageTag: "it-IT",
+						Price:       domain.Money{Amount: 42, Currency: "EUR"},
 					})
 			},
 			expect: func(res *httptest.ResponseRecorder) {
@@ -138,8 +177,10 @@ func TestBookController_CreateBook(t *testing.T) {
 				commonFields.interactor,
 				commonFields.bookPresenter,
 				commonFields.errPresenter,
+				commonFields.resPresenter,
 			)
 			r := httptest.NewRequest(http.MethodPut, "/v1/books", strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
 			w := httptest.NewRecorder()
 
 			bc.CreateBook(w, r)
@@ -159,6 +200,7 @@ func TestBookController_GetBook(t *testing.T) {
 		interactor:    mockBookInteractor,
 		bookPresenter: presenter.NewBookPresenter(logger),
 		errPresenter:  presenter.NewErrorPresenter(logger),
+		resPresenter:  presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
 		logger:        logger,
 	}
 
@@ -187,7 +229,7 @@ func TestBookController_GetBook(t *testing.T) {
 			id:   bookID.String(),
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					GetBook(bookID.String()).
+					GetBook(gomock.Any(), bookID.String()).
 					Return(nil, domain.ErrBookNotFound)
 			},
 			expect: func(res *httptest.ResponseRecorder) {
@@ -206,21 +248,30 @@ func TestBookController_GetBook(t *testing.T) {
 			id:   bookID.String(),
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					GetBook(bookID.String()).
+					GetBook(gomock.Any(), bookID.String()).
 					Return(&domain.Book{
 						ID:          bookID,
 						Title:       "a book",
 						Author:      "someone",
-						Price:       42,
+						Price:       domain.Money{Amount: 42, Currency: "EUR"},
 						LanguageTag: language.Italian.String(),
+						Version:     3,
 					}, nil)
 			},
 			expect: func(res *httptest.ResponseRecorder) {
 				if res.Code != http.StatusOK {
 					t.Errorf("want status: %d, got status %d", http.StatusCreated, res.Code)
 				}
+				if etag := res.Header().Get("ETag"); etag != `"3"` {
+					t.Errorf("want ETag %q, got %q", `"3"`, etag)
+				}
+				if lang := res.Header().Get("Content-Language"); lang != "it" {
+					t.Errorf("want Content-Language %q, got %q", "it", lang)
+				}
 				got := strings.TrimSpace(res.Body.String())
-				want := `{"author":"someone","id":"book:` + bookID.String() + `","price":42,"title":"A Book"}`
+				want := `{"author":"someone","authors":[],` +
+					`"currency":"EUR","formatted_price":"€ 0,42","id":"book:` + bookID.String() +
+					`","isbn":null,"language":"it","price":42,"title":"A Book"}`
 				if got != want {
 					t.Errorf("want %s, got %s", want, got)
 				}
@@ -238,8 +289,10 @@ func TestBookController_GetBook(t *testing.T) {
 				commonFields.interactor,
 				commonFields.bookPresenter,
 				commonFields.errPresenter,
+				commonFields.resPresenter,
 			)
 			r := httptest.NewRequest(http.MethodGet, "/v1/books/"+tt.id, http.NoBody)
+			r.Header.Set("Accept", "application/json")
 			r.SetPathValue("id", tt.id)
 			w := httptest.NewRecorder()
 
@@ -260,21 +313,49 @@ func TestBookController_ListBooks(t *testing.T) {
 		interactor:    mockBookInteractor,
 		bookPresenter: presenter.NewBookPresenter(logger),
 		errPresenter:  presenter.NewErrorPresenter(logger),
+		resPresenter:  presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
 		logger:        logger,
 	}
 
 	tests := []struct {
 		name             string
-		id               string
+		query            string
 		mockExpectations func()
 		expect           func(*httptest.ResponseRecorder)
 	}{
+		{
+			name:  "fails to parse query parameters",
+			query: "?limit=ten",
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"limit must be an integer","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:  "fails to validate query parameters",
+			query: "?sort=isbn",
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"sort must be one of title, author, price, created","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
 		{
 			name: "fails to list book",
-			id:   bookID.String(),
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					ListBooks().
+					ListBooks(gomock.Any(), domain.BookQuery{}).
 					Return(nil, errors.New("oops"))
 			},
 			expect: func(res *httptest.ResponseRecorder) {
@@ -288,19 +369,54 @@ func TestBookController_ListBooks(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "fails with invalid cursor",
+			query: "?cursor=garbage",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ListBooks(gomock.Any(), domain.BookQuery{Cursor: "garbage"}).
+					Return(nil, domain.ErrInvalidCursor)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid cursor","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
 		{
 			name: "succeeds",
-			id:   bookID.String(),
+			query: "?limit=1&cursor=abc&sort=price&order=desc" +
+				"&author=someone&language=en&min_price=10&max_price=50",
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					ListBooks().
-					Return([]*domain.Book{
-						{
-							ID:     bookID,
-							Title:  "a book",
-							Author: "someone",
-							Price:  42,
+					ListBooks(gomock.Any(), domain.BookQuery{
+						Filter: domain.BookFilter{
+							Author:      "someone",
+							LanguageTag: "en",
+							MinPrice:    10,
+							MaxPrice:    50,
+						},
+						SortBy:     domain.SortByPrice,
+						Descending: true,
+						Cursor:     "abc",
+						Limit:      1,
+					}).
+					Return(&domain.BookPage{
+						Books: []*domain.Book{
+							{
+								ID:     bookID,
+								Title:  "a book",
+								Author: "someone",
+								Price:  domain.Money{Amount: 42, Currency: "EUR"},
+							},
 						},
+						NextCursor: "next",
+						Total:      2,
 					}, nil)
 			},
 			expect: func(res *httptest.ResponseRecorder) {
@@ -308,7 +424,27 @@ func TestBookController_ListBooks(t *testing.T) {
 					t.Errorf("want status: %d, got status %d", http.StatusCreated, res.Code)
 				}
 				got := strings.TrimSpace(res.Body.String())
-				want := `[{"author":"someone","id":"book:` + bookID.String() + `","price":42,"title":"a book"}]`
+				want := `{"books":[{"author":"someone","authors":[],"currency":"EUR","formatted_price":"€ 0.42",` +
+					`"id":"book:` + bookID.String() +
+					`","isbn":null,"language":"","price":42,"title":"a book"}],"next_cursor":"next","total":2}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "succeeds on last page",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ListBooks(gomock.Any(), domain.BookQuery{}).
+					Return(&domain.BookPage{Books: []*domain.Book{}}, nil)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusOK {
+					t.Errorf("want status: %d, got status %d", http.StatusCreated, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"books":[],"next_cursor":null,"total":0}`
 				if got != want {
 					t.Errorf("want %s, got %s", want, got)
 				}
@@ -326,8 +462,10 @@ func TestBookController_ListBooks(t *testing.T) {
 				commonFields.interactor,
 				commonFields.bookPresenter,
 				commonFields.errPresenter,
+				commonFields.resPresenter,
 			)
-			r := httptest.NewRequest(http.MethodGet, "/v1/books", http.NoBody)
+			r := httptest.NewRequest(http.MethodGet, "/v1/books"+tt.query, http.NoBody)
+			r.Header.Set("Accept", "application/json")
 			w := httptest.NewRecorder()
 
 			bc.ListBooks(w, r)
@@ -338,6 +476,150 @@ func TestBookController_ListBooks(t *testing.T) {
 	}
 }
 
+func TestBookController_AcceptLanguage(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	bc := controller.NewBookController(
+		logger,
+		mockBookInteractor,
+		presenter.NewBookPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+	american := &domain.Book{ID: uuid.New(), Title: "a", LanguageTag: "en-US", Price: domain.Money{Currency: "USD"}}
+	italian := &domain.Book{ID: uuid.New(), Title: "b", LanguageTag: "it", Price: domain.Money{Currency: "EUR"}}
+
+	tests := []struct {
+		name                string
+		query               string
+		acceptLanguage      string
+		wantFilter          string
+		wantContentLanguage string
+		books               []*domain.Book
+		wantMatches         []string
+	}{
+		{
+			name:                "no preference",
+			wantMatches:         []string{"", ""},
+			wantContentLanguage: "en-US, it",
+		},
+		{
+			name:                "annotates books without filtering them",
+			acceptLanguage:      "it-CH, en;q=0.5",
+			wantContentLanguage: "en-US, it",
+			wantMatches:         []string{"close", "close"},
+		},
+		{
+			name:                "matches regions by their base language",
+			acceptLanguage:      "en-US, en;q=0.8",
+			wantContentLanguage: "en-US, it",
+			wantMatches:         []string{"exact", "none"},
+		},
+		{
+			name:                "matches regions of the preferred language",
+			acceptLanguage:      "en",
+			wantContentLanguage: "en-US, it",
+			wantMatches:         []string{"close", "none"},
+		},
+		{
+			name:                "query parameter filters",
+			query:               "?language=it",
+			acceptLanguage:      "it",
+			wantFilter:          "it",
+			books:               []*domain.Book{italian},
+			wantContentLanguage: "it",
+			wantMatches:         []string{"exact"},
+		},
+		{
+			name:                "query parameter sets the language of empty pages",
+			query:               "?language=it",
+			wantFilter:          "it",
+			books:               []*domain.Book{},
+			wantContentLanguage: "it",
+			wantMatches:         []string{},
+		},
+		{
+			name:                "ignores wildcard",
+			acceptLanguage:      "*",
+			wantContentLanguage: "en-US, it",
+			wantMatches:         []string{"", ""},
+		},
+		{
+			name:                "ignores malformed header",
+			acceptLanguage:      "it;q=high",
+			wantContentLanguage: "en-US, it",
+			wantMatches:         []string{"", ""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			books := tt.books
+			if books == nil {
+				books = []*domain.Book{american, italian}
+			}
+			mockBookInteractor.EXPECT().
+				ListBooks(gomock.Any(), domain.BookQuery{
+					Filter: domain.BookFilter{LanguageTag: tt.wantFilter},
+				}).
+				Return(&domain.BookPage{Books: books, Total: len(books)}, nil)
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/books"+tt.query, http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			if tt.acceptLanguage != "" {
+				r.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+
+			bc.ListBooks(w, r)
+			if w.Code != http.StatusOK {
+				t.Errorf("want status: %d, got status %d", http.StatusOK, w.Code)
+			}
+			if got := w.Header().Get("Content-Language"); got != tt.wantContentLanguage {
+				t.Errorf("want Content-Language %q, got %q", tt.wantContentLanguage, got)
+			}
+			if got := w.Header().Values("Vary"); !slices.Contains(got, "Accept-Language") {
+				t.Errorf("want Vary Accept-Language, got %q", got)
+			}
+			var page struct {
+				Books []struct {
+					LanguageMatch string `json:"language_match"`
+				} `json:"books"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&page); err != nil {
+				t.Fatalf("unable to decode response: %v", err)
+			}
+			got := make([]string, len(page.Books))
+			for i, b := range page.Books {
+				got[i] = b.LanguageMatch
+			}
+			if !slices.Equal(got, tt.wantMatches) {
+				t.Errorf("want language matches %q, got %q", tt.wantMatches, got)
+			}
+		})
+	}
+
+	t.Run("annotates a single book", func(t *testing.T) {
+		mockBookInteractor.EXPECT().GetBook(gomock.Any(), american.ID.String()).Return(american, nil)
+
+		r := httptest.NewRequest(http.MethodGet, "/v1/books/"+american.ID.String(), http.NoBody)
+		r.SetPathValue("id", american.ID.String())
+		r.Header.Set("Accept-Language", "fr, en;q=0.5")
+		w := httptest.NewRecorder()
+
+		bc.GetBook(w, r)
+		if got := w.Header().Get("Content-Language"); got != "en-US" {
+			t.Errorf("want Content-Language en-US, got %q", got)
+		}
+		if got := w.Header().Values("Vary"); !slices.Contains(got, "Accept-Language") {
+			t.Errorf("want Vary Accept-Language, got %q", got)
+		}
+		if got := w.Body.String(); !strings.Contains(got, `"language_match":"close"`) {
+			t.Errorf("want a close language match, got %s", got)
+		}
+	})
+}
+
 func TestBookController_UpdateBook(t *testing.T) {
 	logger := testlog.NewTestLogger()
 	mockCtl := gomock.NewController(t)
@@ -346,6 +628,7 @@ func TestBookController_UpdateBook(t *testing.T) {
 		interactor:    mockBookInteractor,
 		bookPresenter: presenter.NewBookPresenter(logger),
 		errPresenter:  presenter.NewErrorPresenter(logger),
+		resPresenter:  presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
 		logger:        logger,
 	}
 	bookID := uuid.New()
@@ -353,6 +636,7 @@ func TestBookController_UpdateBook(t *testing.T) {
 	tests := []struct {
 		name             string
 		body             string
+		ifMatch          string
 		mockExpectations func()
 		expect           func(*httptest.ResponseRecorder)
 	}{
@@ -387,6 +671,45 @@ func TestBookController_UpdateBook(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "fails with invalid If-Match header",
+			ifMatch: "3",
+			body:    `{"id": "` + bookID.String() + `", "price": 10}`,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid If-Match header","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "fails with version conflict",
+			ifMatch: `"3"`,
+			body:    `{"id": "` + bookID.String() + `", "price": 10}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					UpdateBook(gomock.Any(), &domain.Book{
+						ID:      bookID,
+						Price:   domain.Money{Amount: 10},
+						Version: 3,
+					}).
+					Return(domain.ErrVersionConflict)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusPreconditionFailed {
+					t.Errorf("want status: %d, got status %d", http.StatusPreconditionFailed, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"book version conflict","status":"Precondition Failed"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
 		{
 			name: "fails to update book",
 			body: `{
@@ -395,9 +718,9 @@ func TestBookController_UpdateBook(t *testing.T) {
 				}`,
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					UpdateBook(&domain.Book{
+					UpdateBook(gomock.Any(), &domain.Book{
 						ID:    bookID,
-						Price: 10,
+						Price: domain.Money{Amount: 10},
 					}).
 					Return(errors.New("oops"))
 			},
@@ -421,15 +744,56 @@ func TestBookController_UpdateBook(t *testing.T) {
 				}`,
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					UpdateBook(&domain.Book{
+					UpdateBook(gomock.Any(), &domain.Book{
+						ID:    bookID,
+						Price: domain.Money{Amount: 10},
+					})
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusAccepted {
+					t.Errorf("want status: %d, got status %d", http.StatusAccepted, res.Code)
+				}
+			},
+		},
+		{
+			name: "succeeds with currency",
+			body: `{"id": "` + bookID.String() + `", "price": 10, "currency": "usd"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					UpdateBook(gomock.Any(), &domain.Book{
 						ID:    bookID,
-						Price: 10,
+						Price: domain.Money{Amount: 10, Currency: "USD"},
+					})
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusAccepted {
+					t.Errorf("want status: %d, got status %d", http.StatusAccepted, res.Code)
+				}
+			},
+		},
+		{
+			name:    "succeeds with matching version",
+			ifMatch: `"3"`,
+			body:    `{"id": "` + bookID.String() + `", "price": 10}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					UpdateBook(gomock.Any(), &domain.Book{
+						ID:      bookID,
+						Price:   domain.Money{Amount: 10},
+						Version: 3,
+					}).
+					DoAndReturn(func(_ context.Context, b *domain.Book) error {
+						b.Version = 4
+						return nil
 					})
 			},
 			expect: func(res *httptest.ResponseRecorder) {
 				if res.Code != http.StatusAccepted {
 					t.Errorf("want status: %d, got status %d", http.StatusAccepted, res.Code)
 				}
+				if etag := res.Header().Get("ETag"); etag != `"4"` {
+					t.Errorf("want ETag %q, got %q", `"4"`, etag)
+				}
 			},
 		},
 	}
@@ -444,8 +808,13 @@ func TestBookController_UpdateBook(t *testing.T) {
 				commonFields.interactor,
 				commonFields.bookPresenter,
 				commonFields.errPresenter,
+				commonFields.resPresenter,
 			)
 			r := httptest.NewRequest(http.MethodPatch, "/v1/books", strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
 			w := httptest.NewRecorder()
 
 			bc.UpdateBook(w, r)
@@ -456,6 +825,193 @@ func TestBookController_UpdateBook(t *testing.T) {
 	}
 }
 
+func TestBookController_PatchBook(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	commonFields := controllerFields{
+		interactor:    mockBookInteractor,
+		bookPresenter: presenter.NewBookPresenter(logger),
+		errPresenter:  presenter.NewErrorPresenter(logger),
+		resPresenter:  presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+		logger:        logger,
+	}
+	bookID := uuid.New()
+	title := "the name of the rose"
+
+	tests := []struct {
+		name             string
+		id               string
+		contentType      string
+		ifMatch          string
+		body             string
+		mockExpectations func()
+		expect           func(*httptest.ResponseRecorder)
+	}{
+		{
+			name: "fails with missing id",
+			body: `{"title": "` + title + `"}`,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"book id is required","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:        "fails with unsupported content type",
+			id:          bookID.String(),
+			contentType: "text/plain",
+			body:        `{"title": "` + title + `"}`,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusUnsupportedMediaType {
+					t.Errorf("want status: %d, got status %d", http.StatusUnsupportedMediaType, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"content type must be application/merge-patch+json",` +
+					`"status":"Unsupported Media Type"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "fails with invalid If-Match header",
+			id:      bookID.String(),
+			ifMatch: "3",
+			body:    `{"title": "` + title + `"}`,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid If-Match header","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "fails to parse patch",
+			id:   bookID.String(),
+			body: `{"id": "` + uuid.NewString() + `"}`,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"id is immutable","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "fails to validate patch",
+			id:   bookID.String(),
+			body: `{"price": -1}`,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"price must be greater than zero","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "fails with version conflict",
+			id:      bookID.String(),
+			ifMatch: `"3"`,
+			body:    `{"title": "` + title + `"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					PatchBook(gomock.Any(), bookID.String(), &domain.BookPatch{Title: &title}, 3).
+					Return(nil, domain.ErrVersionConflict)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusPreconditionFailed {
+					t.Errorf("want status: %d, got status %d", http.StatusPreconditionFailed, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"book version conflict","status":"Precondition Failed"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:        "succeeds",
+			id:          bookID.String(),
+			contentType: "application/merge-patch+json",
+			body:        `{"title": "` + title + `"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					PatchBook(gomock.Any(), bookID.String(), &domain.BookPatch{Title: &title}, 0).
+					Return(&domain.Book{
+						ID:          bookID,
+						Title:       title,
+						Author:      "Umberto Eco",
+						LanguageTag: language.English.String(),
+						Price:       domain.Money{Amount: 1000, Currency: "EUR"},
+						Version:     2,
+					}, nil)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusOK {
+					t.Errorf("want status: %d, got status %d", http.StatusOK, res.Code)
+				}
+				if etag := res.Header().Get("ETag"); etag != `"2"` {
+					t.Errorf("want ETag %q, got %q", `"2"`, etag)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"author":"Umberto Eco","authors":[],` +
+					`"currency":"EUR","formatted_price":"€ 10.00","id":"book:` + bookID.String() +
+					`","isbn":null,"language":"en","price":1000,"title":"The Name Of The Rose"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(_ *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+
+			bc := controller.NewBookController(
+				commonFields.logger,
+				commonFields.interactor,
+				commonFields.bookPresenter,
+				commonFields.errPresenter,
+				commonFields.resPresenter,
+			)
+			r := httptest.NewRequest(http.MethodPatch, "/v1/books/"+tt.id, strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.ifMatch != "" {
+				r.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+
+			bc.PatchBook(w, r)
+			if tt.expect != nil {
+				tt.expect(w)
+			}
+		})
+	}
+}
+
 func TestBookController_DeleteBook(t *testing.T) {
 	logger := testlog.NewTestLogger()
 	mockCtl := gomock.NewController(t)
@@ -465,6 +1021,7 @@ func TestBookController_DeleteBook(t *testing.T) {
 		interactor:    mockBookInteractor,
 		bookPresenter: presenter.NewBookPresenter(logger),
 		errPresenter:  presenter.NewErrorPresenter(logger),
+		resPresenter:  presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
 		logger:        logger,
 	}
 
@@ -493,7 +1050,7 @@ func TestBookController_DeleteBook(t *testing.T) {
 			id:   bookID.String(),
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					DeleteBook(bookID.String()).
+					DeleteBook(gomock.Any(), bookID.String()).
 					Return(domain.ErrInvalidBookID)
 			},
 			expect: func(res *httptest.ResponseRecorder) {
@@ -512,7 +1069,7 @@ func TestBookController_DeleteBook(t *testing.T) {
 			id:   bookID.String(),
 			mockExpectations: func() {
 				mockBookInteractor.EXPECT().
-					DeleteBook(bookID.String()).
+					DeleteBook(gomock.Any(), bookID.String()).
 					Return(nil)
 			},
 			expect: func(res *httptest.ResponseRecorder) {
@@ -533,8 +1090,10 @@ func TestBookController_DeleteBook(t *testing.T) {
 				commonFields.interactor,
 				commonFields.bookPresenter,
 				commonFields.errPresenter,
+				commonFields.resPresenter,
 			)
 			r := httptest.NewRequest(http.MethodDelete, "/v1/books/"+tt.id, http.NoBody)
+			r.Header.Set("Accept", "application/json")
 			r.SetPathValue("id", tt.id)
 			w := httptest.NewRecorder()
 
@@ -545,3 +1104,292 @@ func TestBookController_DeleteBook(t *testing.T) {
 		})
 	}
 }
+
+func TestBookController_GetBookByISBN(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	bookID := uuid.New()
+	bc := controller.NewBookController(
+		logger, mockBookInteractor, presenter.NewBookPresenter(logger), presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		isbn             string
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:     "fails if missing isbn",
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"isbn is required","status":"Bad Request"}`,
+		},
+		{
+			name: "fails with invalid isbn",
+			isbn: "0306406153",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					GetBookByISBN(gomock.Any(), "0306406153").
+					Return(nil, domain.ErrInvalidISBN)
+			},
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"invalid isbn","status":"Bad Request"}`,
+		},
+		{
+			name: "succeeds",
+			isbn: "0-306-40615-2",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					GetBookByISBN(gomock.Any(), "0-306-40615-2").
+					Return(&domain.Book{
+						ID: bookID, Title: "a book", Author: "someone", ISBN: "9780306406157", LanguageTag: "en",
+						Price: domain.Money{Amount: 42, Currency: "EUR"}, Version: 2,
+					}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"author":"someone","authors":[],"currency":"EUR","formatted_price":"€ 0.42","id":"book:` +
+				bookID.String() + `","isbn":"9780306406157","language":"en","price":42,"title":"A Book"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodGet, "/v1/books/isbn/"+tt.isbn, http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("isbn", tt.isbn)
+			w := httptest.NewRecorder()
+
+			bc.GetBookByISBN(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+			if tt.wantCode == http.StatusOK && w.Header().Get("ETag") != `"2"` {
+				t.Errorf("want ETag %q, got %q", `"2"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestBookController_ListTrash(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	bookID := uuid.New()
+	deletedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	bc := controller.NewBookController(
+		logger, mockBookInteractor, presenter.NewBookPresenter(logger), presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		query            string
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:     "fails to validate query",
+			query:    "?sort=isbn",
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"sort must be one of title, author, price, created","status":"Bad Request"}`,
+		},
+		{
+			name:  "succeeds",
+			query: "?sort=title&limit=1",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ListTrash(gomock.Any(), domain.BookQuery{SortBy: domain.SortByTitle, Limit: 1}).
+					Return(&domain.BookPage{Books: []*domain.Book{{
+						ID: bookID, Title: "a book", Author: "someone", LanguageTag: "en",
+						Price: domain.Money{Amount: 42, Currency: "EUR"}, DeletedAt: deletedAt,
+					}}, Total: 1}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"books":[{"author":"someone","authors":[],"currency":"EUR",` +
+				`"deleted_at":"2024-05-01T12:00:00Z","formatted_price":"€ 0.42","id":"book:` + bookID.String() +
+				`","isbn":null,"language":"en","price":42,"title":"A Book"}],"next_cursor":null,"total":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodGet, "/v1/trash/books"+tt.query, http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			r.Header.Set("Accept-Language", "it")
+			w := httptest.NewRecorder()
+
+			bc.ListTrash(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBookController_RestoreBook(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	bookID := uuid.New()
+	bc := controller.NewBookController(
+		logger, mockBookInteractor, presenter.NewBookPresenter(logger), presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		id               string
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:     "fails if missing ID",
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"book id is required","status":"Bad Request"}`,
+		},
+		{
+			name: "fails if not in the trash",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					RestoreBook(gomock.Any(), bookID.String()).
+					Return(nil, domain.ErrBookNotFound)
+			},
+			wantCode: http.StatusNotFound,
+			want:     `{"message":"book not found","status":"Not Found"}`,
+		},
+		{
+			name: "succeeds",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					RestoreBook(gomock.Any(), bookID.String()).
+					Return(&domain.Book{
+						ID: bookID, Title: "a book", Author: "someone", LanguageTag: "en",
+						Price: domain.Money{Amount: 42, Currency: "EUR"}, Version: 3,
+					}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"author":"someone","authors":[],"currency":"EUR","formatted_price":"€ 0.42","id":"book:` +
+				bookID.String() + `","isbn":null,"language":"en","price":42,"title":"A Book"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/books/"+tt.id+"/restore", http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			bc.RestoreBook(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+			if tt.wantCode == http.StatusOK && w.Header().Get("ETag") != `"3"` {
+				t.Errorf("want ETag %q, got %q", `"3"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestBookController_GetBookHistory(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	bookID, entryID := uuid.New(), uuid.New()
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	bc := controller.NewBookController(
+		logger, mockBookInteractor, presenter.NewBookPresenter(logger), presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		id               string
+		query            string
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:     "fails if missing ID",
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"book id is required","status":"Bad Request"}`,
+		},
+		{
+			name:     "fails to validate query",
+			id:       bookID.String(),
+			query:    "?limit=1000",
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"limit must be between 1 and 100","status":"Bad Request"}`,
+		},
+		{
+			name: "fails with invalid id",
+			id:   "invalid",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					GetBookHistory(gomock.Any(), "invalid", domain.AuditQuery{}).
+					Return(nil, domain.ErrInvalidBookID)
+			},
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"invalid book id","status":"Bad Request"}`,
+		},
+		{
+			name:  "succeeds",
+			id:    bookID.String(),
+			query: "?limit=1&cursor=abc",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					GetBookHistory(gomock.Any(), bookID.String(), domain.AuditQuery{Cursor: "abc", Limit: 1}).
+					Return(&domain.AuditPage{Entries: []*domain.AuditEntry{{
+						ID: entryID, BookID: bookID, At: at, Actor: "alice", Operation: domain.AuditUpdate, Version: 2,
+						Changes: []domain.FieldChange{{Field: "price", Before: "10", After: "42"}},
+					}}, NextCursor: "def", Total: 2}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"entries":[{"actor":"alice","at":"2024-05-01T12:00:00Z","book_id":"book:` + bookID.String() +
+				`","changes":[{"after":"42","before":"10","field":"price"}],"id":"audit:` + entryID.String() +
+				`","operation":"update","version":2}],"next_cursor":"def","total":2}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodGet, "/v1/books/"+tt.id+"/history"+tt.query, http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			bc.GetBookHistory(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
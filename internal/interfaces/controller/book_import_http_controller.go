@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// importTimeout is how long a bulk import has to stream its body and receive its report,
+// past the timeouts of the server, which are sized for single book requests.
+const importTimeout = 5 * time.Minute
+
+// ImportBooks handles bulk import requests over http.
+// The body is streamed as CSV or NDJSON, as told by its Content-Type, see ReadImportRows.
+// Responds with the outcome of every row; atomic imports rejected because of failed rows respond 422.
+func (bc *BookController) ImportBooks(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseImportBooksRequest(r.URL.Query())
+	if err != nil {
+		bc.logger.With("error", err).Error("unable to parse query parameters")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	// Servers and recorders that can't extend their deadlines keep their own, which is fine.
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Now().Add(importTimeout))
+	_ = rc.SetWriteDeadline(time.Now().Add(importTimeout))
+
+	rows, err := ReadImportRows(r.Header.Get("Content-Type"), r.Body)
+	if errors.Is(err, errUnsupportedImportType) {
+		bc.errPresenter.Present(w, r, err, http.StatusUnsupportedMediaType)
+		return
+	}
+	if err != nil {
+		bc.logger.With("error", err).Error("unable to read import")
+		bc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if len(rows) == 0 {
+		bc.errPresenter.Present(w, r, errors.New("no rows to import"), http.StatusBadRequest)
+		return
+	}
+
+	report, err := bc.interactor.ImportBooks(r.Context(), rows, domain.ImportOptions{DryRun: q.DryRun, Atomic: q.Atomic})
+	if err != nil {
+		bc.logger.With("error", err).Error("unable to import books")
+		bc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	status := http.StatusOK
+	if report.Rejected() {
+		status = http.StatusUnprocessableEntity
+	}
+	bc.resPresenter.PresentList(w, r, status, bc.bookPresenter.PresentImport(report), "rows")
+}
@@ -0,0 +1,159 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestBookController_ImportBooks(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockBookInteractor := mocks.NewMockBookInteractor(mockCtl)
+	bookID := uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42")
+	bc := controller.NewBookController(
+		logger,
+		mockBookInteractor,
+		presenter.NewBookPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+	// report fills in the report of the interactor as if every valid row had been created.
+	report := func(
+		_ any, rows []*domain.ImportRow, opts domain.ImportOptions,
+	) (*domain.ImportReport, error) {
+		r := &domain.ImportReport{Rows: rows, Options: opts}
+		for _, row := range rows {
+			if row.Err != nil {
+				r.Failed++
+			}
+		}
+		for _, row := range rows {
+			if row.Err == nil && !r.Rejected() && !opts.DryRun {
+				row.Book.ID = bookID
+				r.Created++
+			}
+		}
+		return r, nil
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		contentType      string
+		body             string
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:        "fails with an invalid query parameter",
+			query:       "?atomic=maybe",
+			contentType: "text/csv",
+			body:        "title,author,price,currency\nDune,Frank Herbert,42,EUR\n",
+			wantCode:    http.StatusBadRequest,
+			want:        `{"message":"atomic must be a boolean","status":"Bad Request"}`,
+		},
+		{
+			name:        "fails with an unsupported content type",
+			contentType: "application/json",
+			body:        `[]`,
+			wantCode:    http.StatusUnsupportedMediaType,
+			want: `{"message":"content type must be text/csv or application/x-ndjson",` +
+				`"status":"Unsupported Media Type"}`,
+		},
+		{
+			name:        "fails without rows",
+			contentType: "text/csv",
+			body:        "title,author,price,currency\n",
+			wantCode:    http.StatusBadRequest,
+			want:        `{"message":"no rows to import","status":"Bad Request"}`,
+		},
+		{
+			name:        "fails if the interactor fails",
+			contentType: "application/x-ndjson",
+			body:        `{"title":"Dune","author":"Frank Herbert","price":42,"currency":"EUR"}`,
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ImportBooks(gomock.Any(), gomock.Len(1), domain.ImportOptions{}).
+					Return(nil, errors.New("oops"))
+			},
+			wantCode: http.StatusInternalServerError,
+			want:     `{"message":"internal server error","status":"Internal Server Error"}`,
+		},
+		{
+			name:        "imports csv",
+			contentType: "text/csv; charset=utf-8",
+			body:        "Title,Author,Price,Currency\nDune,Frank Herbert,42,eur\n,nobody,1,EUR\n",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ImportBooks(gomock.Any(), gomock.Len(2), domain.ImportOptions{}).
+					DoAndReturn(report)
+			},
+			wantCode: http.StatusOK,
+			want: `{"atomic":false,"created":1,"dry_run":false,"failed":1,"rows":[` +
+				`{"error":null,"id":"book:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42","line":2,"status":"created"},` +
+				`{"error":"title is required","id":null,"line":3,"status":"failed"}]}`,
+		},
+		{
+			name:        "validates ndjson on dry runs",
+			query:       "?dry_run=true",
+			contentType: "application/x-ndjson",
+			body:        `{"title":"Dune","author":"Frank Herbert","price":42,"currency":"EUR"}` + "\n\n{",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ImportBooks(gomock.Any(), gomock.Len(2), domain.ImportOptions{DryRun: true}).
+					DoAndReturn(report)
+			},
+			wantCode: http.StatusOK,
+			want: `{"atomic":false,"created":0,"dry_run":true,"failed":1,"rows":[` +
+				`{"error":null,"id":null,"line":1,"status":"valid"},` +
+				`{"error":"invalid JSON: unexpected end of JSON input","id":null,"line":3,"status":"failed"}]}`,
+		},
+		{
+			name:        "rejects atomic imports with failed rows",
+			query:       "?atomic=1",
+			contentType: "text/csv",
+			body:        "title,author,price,currency\nDune,Frank Herbert,42,EUR\nDune,Frank Herbert,lots,EUR\n",
+			mockExpectations: func() {
+				mockBookInteractor.EXPECT().
+					ImportBooks(gomock.Any(), gomock.Len(2), domain.ImportOptions{Atomic: true}).
+					DoAndReturn(report)
+			},
+			wantCode: http.StatusUnprocessableEntity,
+			want: `{"atomic":true,"created":0,"dry_run":false,"failed":1,"rows":[` +
+				`{"error":null,"id":null,"line":2,"status":"skipped"},` +
+				`{"error":"price must be an integer","id":null,"line":3,"status":"failed"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/books:import"+tt.query, strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+
+			bc.ImportBooks(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// Media types of the documents accepted by a bulk import.
+const (
+	csvContentType    = "text/csv"
+	ndjsonContentType = "application/x-ndjson"
+	jsonlContentType  = "application/jsonl"
+)
+
+// maxImportLineSize caps the size of a single NDJSON line of a bulk import.
+const maxImportLineSize = 1 << 20
+
+// errUnsupportedImportType is returned when a bulk import is neither CSV nor NDJSON.
+var errUnsupportedImportType = errors.New("content type must be text/csv or application/x-ndjson")
+
+// ImportBooksRequest defines the expected query parameters for a bulk import.
+type ImportBooksRequest struct {
+	DryRun bool `query:"dry_run"`
+	Atomic bool `query:"atomic"`
+}
+
+// ParseImportBooksRequest reads an ImportBooksRequest from URL query parameters.
+// Missing parameters default to false.
+func ParseImportBooksRequest(values url.Values) (*ImportBooksRequest, error) {
+	r := &ImportBooksRequest{}
+	err := parseBoolParams(values, boolParam{name: "dry_run", dst: &r.DryRun}, boolParam{name: "atomic", dst: &r.Atomic})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// boolParam is a boolean query parameter and where to store it.
+type boolParam struct {
+	dst  *bool
+	name string
+}
+
+// parseBoolParams reads every parameter of params from values, in order.
+// Missing parameters are left untouched.
+func parseBoolParams(values url.Values, params ...boolParam) error {
+	for _, p := range params {
+		v := values.Get(p.name)
+		if v == "" {
+			continue
+		}
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return domain.NewFieldError(p.name, p.name+" must be a boolean")
+		}
+		*p.dst = b
+	}
+	return nil
+}
+
+// ReadImportRows reads the rows of a bulk import from body, in the format told by contentType:
+// either CSV with a header row naming the columns, or NDJSON with a CreateBookRequest per line.
+// Every row is validated like a CreateBookRequest; rows that can't be read or are invalid are returned
+// with their error, so they can be reported, while a document that can't be read at all fails.
+// CSV columns are the fields of CreateBookRequest, in any order; credits are listed in the authors column
+// as author_id:role pairs separated by semicolons, the role being optional and the author_id bare or prefixed.
+func ReadImportRows(contentType string, body io.Reader) ([]*domain.ImportRow, error) {
+	mt, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, errUnsupportedImportType
+	}
+	switch mt {
+	case csvContentType:
+		return readCSVRows(body)
+	case ndjsonContentType, jsonlContentType:
+		return readNDJSONRows(body)
+	default:
+		return nil, errUnsupportedImportType
+	}
+}
+
+// readCSVRows reads the rows of a CSV bulk import.
+func readCSVRows(body io.Reader) ([]*domain.ImportRow, error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("header row is required")
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, column := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+		if !csvColumns[header[i]] {
+			return nil, fmt.Errorf("unknown column %q", column)
+		}
+	}
+
+	var rows []*domain.ImportRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr):
+			rows = append(rows, &domain.ImportRow{Line: parseErr.StartLine, Err: parseErr.Err})
+		case err != nil:
+			return nil, err
+		default:
+			line, _ := reader.FieldPos(0)
+			req, err := csvRequest(header, record)
+			rows = append(rows, importRow(line, req, err))
+		}
+		if len(rows) > domain.MaxImportRows {
+			return nil, domain.ErrTooManyImportRows
+		}
+	}
+}
+
+// csvColumns are the columns a CSV bulk import can have.
+var csvColumns = map[string]bool{
+	"title": true, "author": true, "language": true, "currency": true, "isbn": true, "price": true, "authors": true,
+}
+
+// csvRequest converts a CSV record, whose columns are named by header, to a CreateBookRequest.
+func csvRequest(header, record []string) (*CreateBookRequest, error) {
+	req := &CreateBookRequest{}
+	for i, value := range record {
+		value = strings.TrimSpace(value)
+		switch header[i] {
+		case "title":
+			req.Title = value
+		case "author":
+			req.Author = value
+		case "language":
+			req.Language = value
+		case "currency":
+			req.Currency = value
+		case "isbn":
+			req.ISBN = value
+		case "price":
+			if value == "" {
+				continue
+			}
+			price, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, errors.New("price must be an integer")
+			}
+			req.Price = price
+		case "authors":
+			for credit := range strings.SplitSeq(value, ";") {
+				if credit = strings.TrimSpace(credit); credit == "" {
+					continue
+				}
+				req.Authors = append(req.Authors, creditRequest(credit))
+			}
+		}
+	}
+	return req, nil
+}
+
+// creditRequest returns the credit of an authors column entry, an author ID optionally followed by :role.
+// The ID may itself be prefixed by its resource type, e.g. author:<uuid>:translator.
+func creditRequest(credit string) BookAuthorRequest {
+	if _, err := domain.AuthorResource.Parse(credit); err == nil {
+		return BookAuthorRequest{AuthorID: credit}
+	}
+	i := strings.LastIndex(credit, ":")
+	if i < 0 {
+		return BookAuthorRequest{AuthorID: credit}
+	}
+	return BookAuthorRequest{AuthorID: credit[:i], Role: credit[i+1:]}
+}
+
+// readNDJSONRows reads the rows of an NDJSON bulk import; blank lines are skipped.
+func readNDJSONRows(body io.Reader) ([]*domain.ImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineSize)
+	var rows []*domain.ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		req := &CreateBookRequest{}
+		if err := json.Unmarshal(scanner.Bytes(), req); err != nil {
+			rows = append(rows, &domain.ImportRow{Line: line, Err: fmt.Errorf("invalid JSON: %w", err)})
+		} else {
+			rows = append(rows, importRow(line, req, nil))
+		}
+		if len(rows) > domain.MaxImportRows {
+			return nil, domain.ErrTooManyImportRows
+		}
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		return nil, fmt.Errorf("lines must not be longer than %d bytes", maxImportLineSize)
+	}
+	return rows, scanner.Err()
+}
+
+// importRow returns the row read at line: the book of req, or err if req could not be read or is invalid.
+func importRow(line int, req *CreateBookRequest, err error) *domain.ImportRow {
+	if err == nil {
+		err = req.Validate()
+	}
+	if err != nil {
+		return &domain.ImportRow{Line: line, Err: err}
+	}
+	return &domain.ImportRow{Line: line, Book: req.Book()}
+}
@@ -0,0 +1,115 @@
+package controller_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+)
+
+func TestReadImportRows(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantErr     string
+		want        []*domain.ImportRow
+	}{
+		{
+			name:    "fails without content type",
+			body:    "title\n",
+			wantErr: "content type must be text/csv or application/x-ndjson",
+		},
+		{
+			name:        "fails without csv header",
+			contentType: "text/csv",
+			wantErr:     "header row is required",
+		},
+		{
+			name:        "fails with an unknown csv column",
+			contentType: "text/csv",
+			body:        "title,Pages\n",
+			wantErr:     `unknown column "Pages"`,
+		},
+		{
+			name:        "fails with too long ndjson lines",
+			contentType: "application/x-ndjson",
+			body:        strings.Repeat(" ", 1<<20+1),
+			wantErr:     "lines must not be longer than 1048576 bytes",
+		},
+		{
+			name:        "reads csv rows",
+			contentType: "text/csv",
+			body: "isbn,title,author,price,currency,language,authors\n" +
+				"978-0-306-40615-7,Dune,Frank Herbert,42,eur,en,\n" +
+				"bro\"ken,Dune,x,1,EUR,,\n" +
+				",Good Omens,,10,GBP,,5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42; 3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c:illustrator\n" +
+				",Bad,someone,-1,EUR,,\n" +
+				",Dune,,42,EUR,,author:5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42;" +
+				"author:3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c:translator\n",
+			want: []*domain.ImportRow{
+				{Line: 2, Book: &domain.Book{
+					Title: "Dune", Author: "Frank Herbert", ISBN: "978-0-306-40615-7", LanguageTag: "en",
+					Price: domain.Money{Amount: 42, Currency: "EUR"},
+				}},
+				{Line: 3, Err: errors.New(`bare " in non-quoted-field`)},
+				{Line: 4, Book: &domain.Book{
+					Title: "Good Omens", Price: domain.Money{Amount: 10, Currency: "GBP"},
+					Authors: []domain.BookAuthor{
+						{AuthorID: uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42"), Role: domain.RoleAuthor},
+						{AuthorID: uuid.MustParse("3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c"), Role: domain.RoleIllustrator},
+					},
+				}},
+				{Line: 5, Err: errors.New("price must be greater than zero")},
+				{Line: 6, Book: &domain.Book{
+					Title: "Dune", Price: domain.Money{Amount: 42, Currency: "EUR"},
+					Authors: []domain.BookAuthor{
+						{AuthorID: uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42"), Role: domain.RoleAuthor},
+						{AuthorID: uuid.MustParse("3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c"), Role: domain.RoleTranslator},
+					},
+				}},
+			},
+		},
+		{
+			name:        "reads ndjson rows",
+			contentType: "application/jsonl",
+			body: `{"title":"Dune","author":"Frank Herbert","price":42,"currency":"EUR"}` + "\n" +
+				"\n" +
+				`{"title":"Dune","author":"Frank Herbert","price":"42"}`,
+			want: []*domain.ImportRow{
+				{Line: 1, Book: &domain.Book{
+					Title: "Dune", Author: "Frank Herbert", Price: domain.Money{Amount: 42, Currency: "EUR"},
+				}},
+				{Line: 3, Err: errors.New("invalid JSON: json: cannot unmarshal string into Go struct field " +
+					"CreateBookRequest.price of type int64")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := controller.ReadImportRows(tt.contentType, strings.NewReader(tt.body))
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("ReadImportRows() error = %v, wantErr %s", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ReadImportRows() got %d rows, want %d", len(got), len(tt.want))
+			}
+			for i, row := range got {
+				want := tt.want[i]
+				if row.Line != want.Line || (row.Err == nil) != (want.Err == nil) ||
+					(row.Err != nil && row.Err.Error() != want.Err.Error()) {
+					t.Errorf("ReadImportRows() row %d = line %d, error %v, want line %d, error %v",
+						i, row.Line, row.Err, want.Line, want.Err)
+				}
+				if want.Book != nil && (row.Book == nil || !reflect.DeepEqual(row.Book, want.Book)) {
+					t.Errorf("ReadImportRows() row %d book = %+v, want %+v", i, row.Book, want.Book)
+				}
+			}
+		})
+	}
+}
@@ -1,48 +1,477 @@
 package controller
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 )
 
 // CreateBookRequest defines the expected request for create.
+// Language is an optional BCP 47 tag, English is assumed when missing.
+// Price is expressed in minor units of the ISO 4217 Currency, e.g. 1299 EUR is €12.99.
+// Author is the byline; it can be omitted when Authors credits at least one author.
+// ISBN is optional, either ISBN-10 or ISBN-13, with or without hyphens.
 type CreateBookRequest struct {
-	Title  string `json:"title"`
-	Author string `json:"author"`
-	Price  int    `json:"price"`
+	Title    string              `json:"title" required:"true"`
+	Author   string              `json:"author"`
+	Language string              `json:"language" doc:"BCP 47 tag, en when missing"`
+	Currency string              `json:"currency" required:"true" doc:"ISO 4217 code"`
+	ISBN     string              `json:"isbn" doc:"ISBN-10 or ISBN-13, with or without hyphens"`
+	Authors  []BookAuthorRequest `json:"authors"`
+	Price    int64               `json:"price" required:"true" doc:"minor units of currency"`
+}
+
+// BookAuthorRequest credits an author on a book.
+// Role defaults to author when missing.
+type BookAuthorRequest struct {
+	AuthorID string `json:"author_id" required:"true"`
+	Role     string `json:"role" doc:"one of author, translator, illustrator; author when missing"`
 }
 
 // Validate a CreateBookRequest.
 func (r *CreateBookRequest) Validate() error {
+	v := &domain.ValidationError{}
+	if r.Title == "" {
+		v.Add("title", "title is required")
+	}
+	if r.Author == "" && len(r.Authors) == 0 {
+		v.Add("author", "author is required")
+	}
+	if r.Price <= 0 {
+		v.Add("price", "price must be greater than zero")
+	}
 	switch {
-	case r.Title == "":
-		return errors.New("title is required")
-	case r.Author == "":
-		return errors.New("author is required")
-	case r.Price <= 0:
-		return errors.New("price must be greater than zero")
+	case r.Currency == "":
+		v.Add("currency", "currency is required")
+	case !validCurrency(r.Currency):
+		v.Add("currency", "invalid currency")
 	}
-	return nil
+	if r.Language != "" {
+		if _, err := language.Parse(r.Language); err != nil {
+			v.Add("language", "invalid language tag")
+		}
+	}
+	if r.ISBN != "" {
+		if _, err := domain.ParseISBN(r.ISBN); err != nil {
+			v.Add("isbn", err.Error())
+		}
+	}
+	validateCredits(v, r.Authors)
+	return v.Err()
+}
+
+// LanguageTag returns the canonical form of r.Language, or an empty string if it is missing or invalid.
+func (r *CreateBookRequest) LanguageTag() string {
+	tag, err := language.Parse(r.Language)
+	if err != nil {
+		return ""
+	}
+	return tag.String()
+}
+
+// Book converts a CreateBookRequest, validated with Validate, to a new domain.Book.
+func (r *CreateBookRequest) Book() *domain.Book {
+	return &domain.Book{
+		Title:       r.Title,
+		Author:      r.Author,
+		LanguageTag: r.LanguageTag(),
+		ISBN:        r.ISBN,
+		Authors:     bookAuthors(r.Authors),
+		Price:       domain.Money{Amount: r.Price, Currency: currencyCode(r.Currency)},
+	}
+}
+
+// invalidID describes why the id of field failed to parse with err:
+// its resource type if it refers to another type of resource, its format otherwise.
+func invalidID(field string, err error) string {
+	if errors.Is(err, domain.ErrWrongResourceType) {
+		return field + ": " + err.Error()
+	}
+	return "invalid " + field
+}
+
+// validateCredits checks every credit references a valid author id with a supported role,
+// adding the failures to v.
+func validateCredits(v *domain.ValidationError, credits []BookAuthorRequest) {
+	for i, c := range credits {
+		if _, err := domain.AuthorResource.Parse(c.AuthorID); err != nil {
+			v.Add(fmt.Sprintf("authors[%d].author_id", i), fmt.Sprintf("authors[%d]: %s", i, invalidID("author_id", err)))
+		}
+		if c.Role != "" && !domain.AuthorRole(c.Role).IsValid() {
+			v.Add(fmt.Sprintf("authors[%d].role", i),
+				fmt.Sprintf("authors[%d]: role must be one of author, translator, illustrator", i))
+		}
+	}
+}
+
+// bookAuthors converts credits, validated with validateCredits, to domain credits.
+func bookAuthors(credits []BookAuthorRequest) []domain.BookAuthor {
+	if len(credits) == 0 {
+		return nil
+	}
+	authors := make([]domain.BookAuthor, len(credits))
+	for i, c := range credits {
+		authors[i] = domain.BookAuthor{AuthorID: domain.AuthorResource.MustParse(c.AuthorID), Role: domain.AuthorRole(c.Role)}
+		if c.Role == "" {
+			authors[i].Role = domain.RoleAuthor
+		}
+	}
+	return authors
 }
 
 // UpdateBookRequest defines the expected request for update.
+// Price is expressed in minor units of Currency, or of the current book currency if Currency is missing.
 type UpdateBookRequest struct {
-	ID    string `json:"id"`
-	Price int    `json:"price"`
+	ID       string `json:"id" required:"true"`
+	Currency string `json:"currency" doc:"ISO 4217 code, the current one when missing"`
+	Price    int64  `json:"price" required:"true" doc:"minor units of currency"`
 }
 
 // Validate an UpdateBookRequest.
 func (r *UpdateBookRequest) Validate() error {
+	v := &domain.ValidationError{}
+	if r.ID == "" {
+		v.Add("id", "id is required")
+	} else if _, err := domain.BookResource.Parse(r.ID); err != nil {
+		v.Add("id", invalidID("id", err))
+	}
+	if r.Price <= 0 {
+		v.Add("price", "price must be greater than zero")
+	}
+	if r.Currency != "" && !validCurrency(r.Currency) {
+		v.Add("currency", "invalid currency")
+	}
+	return v.Err()
+}
+
+// Book converts an UpdateBookRequest, validated with Validate, to the domain.Book to update.
+// A version other than zero makes the update conditional on the current version of the book.
+func (r *UpdateBookRequest) Book(version int) *domain.Book {
+	return &domain.Book{
+		ID:      domain.BookResource.MustParse(r.ID),
+		Price:   domain.Money{Amount: r.Price, Currency: currencyCode(r.Currency)},
+		Version: version,
+	}
+}
+
+// Supported ListBooksRequest.Order values.
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// ListBooksRequest defines the expected query parameters for list.
+// The query tag of each field names its parameter.
+type ListBooksRequest struct {
+	Cursor   string `query:"cursor"`
+	Sort     string `query:"sort" doc:"one of title, author, price, created"`
+	Order    string `query:"order" doc:"asc or desc"`
+	Author   string `query:"author"`
+	AuthorID string `query:"author_id"`
+	Language string `query:"language"`
+	Limit    int    `query:"limit"`
+	MinPrice int    `query:"min_price"`
+	MaxPrice int    `query:"max_price"`
+}
+
+// ParseListBooksRequest reads a ListBooksRequest from URL query parameters.
+// Missing parameters are left to their zero value.
+func ParseListBooksRequest(values url.Values) (*ListBooksRequest, error) {
+	r := &ListBooksRequest{
+		Cursor:   values.Get("cursor"),
+		Sort:     values.Get("sort"),
+		Order:    values.Get("order"),
+		Author:   values.Get("author"),
+		AuthorID: values.Get("author_id"),
+		Language: values.Get("language"),
+	}
+	for _, p := range []struct {
+		dst  *int
+		name string
+	}{
+		{name: "limit", dst: &r.Limit},
+		{name: "min_price", dst: &r.MinPrice},
+		{name: "max_price", dst: &r.MaxPrice},
+	} {
+		v := values.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, domain.NewFieldError(p.name, p.name+" must be an integer")
+		}
+		*p.dst = n
+	}
+	return r, nil
+}
+
+// Validate a ListBooksRequest.
+func (r *ListBooksRequest) Validate() error {
+	v := &domain.ValidationError{}
+	if r.Limit < 0 || r.Limit > domain.MaxBookPageSize {
+		v.Add("limit", fmt.Sprintf("limit must be between 1 and %d", domain.MaxBookPageSize))
+	}
+	if r.Sort != "" && !domain.BookSortField(r.Sort).IsValid() {
+		v.Add("sort", "sort must be one of title, author, price, created")
+	}
+	if r.Order != "" && r.Order != SortOrderAsc && r.Order != SortOrderDesc {
+		v.Add("order", "order must be asc or desc")
+	}
 	switch {
-	case r.ID == "":
-		return errors.New("id is required")
-	case r.Price <= 0:
-		return errors.New("price must be greater than zero")
+	case r.MinPrice < 0:
+		v.Add("min_price", "price range must not be negative")
+	case r.MaxPrice < 0:
+		v.Add("max_price", "price range must not be negative")
+	case r.MaxPrice > 0 && r.MinPrice > r.MaxPrice:
+		v.Add("min_price", "min_price must not be greater than max_price")
+	}
+	if r.AuthorID != "" {
+		if _, err := domain.AuthorResource.Parse(r.AuthorID); err != nil {
+			v.Add("author_id", invalidID("author_id", err))
+		}
+	}
+	return v.Err()
+}
+
+// BookQuery converts a ListBooksRequest, validated with Validate, to a domain.BookQuery.
+func (r *ListBooksRequest) BookQuery() domain.BookQuery {
+	var authorID uuid.UUID
+	if r.AuthorID != "" {
+		authorID = domain.AuthorResource.MustParse(r.AuthorID)
 	}
+	return domain.BookQuery{
+		Filter: domain.BookFilter{
+			Author:      r.Author,
+			AuthorID:    authorID,
+			LanguageTag: r.Language,
+			MinPrice:    r.MinPrice,
+			MaxPrice:    r.MaxPrice,
+		},
+		SortBy:     domain.BookSortField(r.Sort),
+		Descending: r.Order == SortOrderDesc,
+		Cursor:     r.Cursor,
+		Limit:      r.Limit,
+	}
+}
+
+// SearchBooksRequest defines the expected query parameters for search.
+type SearchBooksRequest struct {
+	Query string `query:"q" required:"true"`
+	Limit int    `query:"limit"`
+}
+
+// ParseSearchBooksRequest reads a SearchBooksRequest from URL query parameters.
+func ParseSearchBooksRequest(values url.Values) (*SearchBooksRequest, error) {
+	r := &SearchBooksRequest{Query: strings.TrimSpace(values.Get("q"))}
+	if v := values.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, domain.NewFieldError("limit", "limit must be an integer")
+		}
+		r.Limit = n
+	}
+	return r, nil
+}
+
+// Validate a SearchBooksRequest.
+func (r *SearchBooksRequest) Validate() error {
+	v := &domain.ValidationError{}
+	if r.Query == "" {
+		v.Add("q", "q is required")
+	}
+	if r.Limit < 0 || r.Limit > domain.MaxBookPageSize {
+		v.Add("limit", fmt.Sprintf("limit must be between 1 and %d", domain.MaxBookPageSize))
+	}
+	return v.Err()
+}
+
+// BookHistoryRequest defines the expected query parameters for the history of a book.
+type BookHistoryRequest struct {
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+// ParseBookHistoryRequest reads a BookHistoryRequest from URL query parameters.
+// Missing parameters are left to their zero value.
+func ParseBookHistoryRequest(values url.Values) (*BookHistoryRequest, error) {
+	r := &BookHistoryRequest{Cursor: values.Get("cursor")}
+	if v := values.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, domain.NewFieldError("limit", "limit must be an integer")
+		}
+		r.Limit = n
+	}
+	return r, nil
+}
 
-	if _, err := uuid.Parse(r.ID); err != nil {
-		return errors.New("invalid id")
+// Validate a BookHistoryRequest.
+func (r *BookHistoryRequest) Validate() error {
+	if r.Limit < 0 || r.Limit > domain.MaxAuditPageSize {
+		return domain.NewFieldError("limit", fmt.Sprintf("limit must be between 1 and %d", domain.MaxAuditPageSize))
 	}
 	return nil
 }
+
+// mergePatchContentType is the media type of RFC 7396 JSON merge patch documents.
+const mergePatchContentType = "application/merge-patch+json"
+
+// isMergePatch reports whether contentType can carry a JSON merge patch.
+// Plain JSON and a missing content type are accepted for convenience.
+func isMergePatch(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mt, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mt == mergePatchContentType || mt == "application/json")
+}
+
+// PatchBookRequest defines the expected RFC 7396 JSON merge patch for a book.
+// Nil fields were not present in the patch.
+// Authors replaces every credit of the book; an empty list removes them.
+type PatchBookRequest struct {
+	Title    *string              `json:"title,omitempty"`
+	Author   *string              `json:"author,omitempty"`
+	Language *string              `json:"language,omitempty"`
+	Currency *string              `json:"currency,omitempty"`
+	Price    *int64               `json:"price,omitempty"`
+	Authors  *[]BookAuthorRequest `json:"authors,omitempty"`
+}
+
+// ParsePatchBookRequest decodes a JSON merge patch document from body.
+// It rejects documents that are not objects, that change unknown or immutable fields,
+// or that remove a required field with null.
+func ParsePatchBookRequest(body io.Reader) (*PatchBookRequest, error) {
+	var doc map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&doc); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, errors.New("patch must be a JSON object")
+		}
+		return nil, err
+	}
+	if doc == nil {
+		return nil, errors.New("patch must be a JSON object")
+	}
+
+	r := &PatchBookRequest{}
+	for _, field := range slices.Sorted(maps.Keys(doc)) {
+		raw := doc[field]
+		var dst any
+		switch field {
+		case "id", "isbn":
+			return nil, domain.NewFieldError(field, field+" is immutable")
+		case "title":
+			r.Title = new(string)
+			dst = r.Title
+		case "author":
+			r.Author = new(string)
+			dst = r.Author
+		case "language":
+			r.Language = new(string)
+			dst = r.Language
+		case "currency":
+			r.Currency = new(string)
+			dst = r.Currency
+		case "price":
+			r.Price = new(int64)
+			dst = r.Price
+		case "authors":
+			r.Authors = new([]BookAuthorRequest)
+			dst = r.Authors
+		default:
+			return nil, domain.NewFieldError(field, fmt.Sprintf("unknown field %q", field))
+		}
+		if string(raw) == "null" {
+			return nil, domain.NewFieldError(field, field+" cannot be removed")
+		}
+		if err := json.Unmarshal(raw, dst); err != nil {
+			return nil, domain.NewFieldError(field, "invalid "+field)
+		}
+	}
+	return r, nil
+}
+
+// Validate a PatchBookRequest.
+func (r *PatchBookRequest) Validate() error {
+	if r.Title == nil && r.Author == nil && r.Language == nil && r.Currency == nil && r.Price == nil &&
+		r.Authors == nil {
+		return errors.New("patch must change at least one field")
+	}
+	v := &domain.ValidationError{}
+	if r.Title != nil && strings.TrimSpace(*r.Title) == "" {
+		v.Add("title", "title must not be empty")
+	}
+	if r.Author != nil && strings.TrimSpace(*r.Author) == "" {
+		v.Add("author", "author must not be empty")
+	}
+	if r.Price != nil && *r.Price <= 0 {
+		v.Add("price", "price must be greater than zero")
+	}
+	if r.Currency != nil && !validCurrency(*r.Currency) {
+		v.Add("currency", "invalid currency")
+	}
+	if r.Language != nil {
+		if _, err := language.Parse(*r.Language); err != nil {
+			v.Add("language", "invalid language tag")
+		}
+	}
+	if r.Authors != nil {
+		validateCredits(v, *r.Authors)
+	}
+	return v.Err()
+}
+
+// BookPatch converts a PatchBookRequest, validated with Validate, to a domain.BookPatch.
+func (r *PatchBookRequest) BookPatch() *domain.BookPatch {
+	patch := &domain.BookPatch{
+		Title:  r.Title,
+		Author: r.Author,
+		Price:  r.Price,
+	}
+	if r.Language != nil {
+		if tag, err := language.Parse(*r.Language); err == nil {
+			canonical := tag.String()
+			patch.LanguageTag = &canonical
+		}
+	}
+	if r.Currency != nil {
+		code := currencyCode(*r.Currency)
+		patch.Currency = &code
+	}
+	if r.Authors != nil {
+		authors := bookAuthors(*r.Authors)
+		patch.Authors = &authors
+	}
+	return patch
+}
+
+// validCurrency reports whether code is a known ISO 4217 currency code.
+func validCurrency(code string) bool {
+	_, err := currency.ParseISO(code)
+	return err == nil
+}
+
+// currencyCode returns the canonical, upper case form of a currency code.
+// Codes that are not valid are returned as is.
+func currencyCode(code string) string {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return code
+	}
+	return unit.String()
+}
@@ -1,18 +1,27 @@
 package controller_test
 
 import (
+	"errors"
+	"net/url"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/google/uuid"
 
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
 )
 
 func TestCreateBookRequest_Validate(t *testing.T) {
 	type fields struct {
-		Title  string
-		Author string
-		Price  int
+		Title    string
+		Author   string
+		Language string
+		Currency string
+		ISBN     string
+		Authors  []controller.BookAuthorRequest
+		Price    int64
 	}
 	tests := []struct {
 		name       string
@@ -23,8 +32,9 @@ func TestCreateBookRequest_Validate(t *testing.T) {
 		{
 			name: "fails if missing title",
 			fields: fields{
-				Author: "John Doe",
-				Price:  42,
+				Author:   "John Doe",
+				Price:    42,
+				Currency: "EUR",
 			},
 			wantErr: true,
 			compareErr: func(err error) bool {
@@ -34,8 +44,9 @@ func TestCreateBookRequest_Validate(t *testing.T) {
 		{
 			name: "fails if missing Author",
 			fields: fields{
-				Title: "Test Book",
-				Price: 42,
+				Title:    "Test Book",
+				Price:    42,
+				Currency: "EUR",
 			},
 			wantErr: true,
 			compareErr: func(err error) bool {
@@ -45,9 +56,10 @@ func TestCreateBookRequest_Validate(t *testing.T) {
 		{
 			name: "fails if invalid price",
 			fields: fields{
-				Title:  "Test Book",
-				Author: "John Doe",
-				Price:  -100,
+				Title:    "Test Book",
+				Author:   "John Doe",
+				Price:    -100,
+				Currency: "EUR",
 			},
 			wantErr: true,
 			compareErr: func(err error) bool {
@@ -55,20 +67,153 @@ func TestCreateBookRequest_Validate(t *testing.T) {
 			},
 		},
 		{
-			name: "succeeds",
+			name:    "lists every invalid field",
+			fields:  fields{Price: -100},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				var v *domain.ValidationError
+				if !errors.As(err, &v) || !errors.Is(err, domain.ErrValidation) {
+					return false
+				}
+				return reflect.DeepEqual(v.Fields, []domain.FieldError{
+					{Field: "title", Detail: "title is required"},
+					{Field: "author", Detail: "author is required"},
+					{Field: "price", Detail: "price must be greater than zero"},
+					{Field: "currency", Detail: "currency is required"},
+				})
+			},
+		},
+		{
+			name: "fails if invalid language",
+			fields: fields{
+				Title:    "Test Book",
+				Author:   "John Doe",
+				Language: "english please",
+				Currency: "EUR",
+				Price:    42,
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "invalid language tag"
+			},
+		},
+		{
+			name: "fails if missing currency",
 			fields: fields{
 				Title:  "Test Book",
 				Author: "John Doe",
 				Price:  42,
 			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "currency is required"
+			},
+		},
+		{
+			name: "fails if invalid currency",
+			fields: fields{
+				Title:    "Test Book",
+				Author:   "John Doe",
+				Currency: "EURO",
+				Price:    42,
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "invalid currency"
+			},
+		},
+		{
+			name: "succeeds",
+			fields: fields{
+				Title:    "Test Book",
+				Author:   "John Doe",
+				Currency: "EUR",
+				Price:    42,
+			},
+		},
+		{
+			name: "succeeds with language",
+			fields: fields{
+				Title:    "Test Book",
+				Author:   "John Doe",
+				Language: "de-CH",
+				Currency: "CHF",
+				Price:    42,
+			},
+		},
+		{
+			name: "fails with invalid isbn",
+			fields: fields{
+				Title:    "Test Book",
+				Author:   "John Doe",
+				ISBN:     "978-0-306-40615-8",
+				Currency: "EUR",
+				Price:    42,
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "invalid isbn"
+			},
+		},
+		{
+			name: "succeeds with isbn",
+			fields: fields{
+				Title:    "Test Book",
+				Author:   "John Doe",
+				ISBN:     "0-306-40615-2",
+				Currency: "EUR",
+				Price:    42,
+			},
+		},
+		{
+			name: "fails with invalid author id",
+			fields: fields{
+				Title:    "Test Book",
+				Authors:  []controller.BookAuthorRequest{{AuthorID: "invalid"}},
+				Currency: "EUR",
+				Price:    42,
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "authors[0]: invalid author_id"
+			},
+		},
+		{
+			name: "fails with unknown role",
+			fields: fields{
+				Title:    "Test Book",
+				Authors:  []controller.BookAuthorRequest{{AuthorID: uuid.NewString(), Role: "editor"}},
+				Currency: "EUR",
+				Price:    42,
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "authors[0]: role must be one of author, translator, illustrator"
+			},
+		},
+		{
+			name: "succeeds with credits instead of author",
+			fields: fields{
+				Title: "Test Book",
+				Authors: []controller.BookAuthorRequest{
+					{AuthorID: uuid.NewString()},
+					{AuthorID: uuid.NewString(), Role: "translator"},
+				},
+				Currency: "EUR",
+				Price:    42,
+			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &controller.CreateBookRequest{
-				Title:  tt.fields.Title,
-				Author: tt.fields.Author,
-				Price:  tt.fields.Price,
+				Title:    tt.fields.Title,
+				Author:   tt.fields.Author,
+				Language: tt.fields.Language,
+				Currency: tt.fields.Currency,
+				ISBN:     tt.fields.ISBN,
+				Authors:  tt.fields.Authors,
+				Price:    tt.fields.Price,
 			}
 			err := r.Validate()
 			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
@@ -81,8 +226,9 @@ func TestCreateBookRequest_Validate(t *testing.T) {
 
 func TestUpdateBookRequest_Validate(t *testing.T) {
 	type fields struct {
-		ID    string
-		Price int
+		ID       string
+		Currency string
+		Price    int64
 	}
 	tests := []struct {
 		name       string
@@ -122,6 +268,29 @@ func TestUpdateBookRequest_Validate(t *testing.T) {
 				return err.Error() == "invalid id"
 			},
 		},
+		{
+			name: "fails if id of another resource type",
+			fields: fields{
+				ID:    "author:" + uuid.NewString(),
+				Price: 42,
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "id: wrong resource type: expected book id, got author id"
+			},
+		},
+		{
+			name: "fails if invalid currency",
+			fields: fields{
+				ID:       uuid.NewString(),
+				Currency: "XYZ",
+				Price:    42,
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "invalid currency"
+			},
+		},
 		{
 			name: "succeeds",
 			fields: fields{
@@ -129,12 +298,28 @@ func TestUpdateBookRequest_Validate(t *testing.T) {
 				Price: 42,
 			},
 		},
+		{
+			name: "succeeds with prefixed id",
+			fields: fields{
+				ID:    "book:" + uuid.NewString(),
+				Price: 42,
+			},
+		},
+		{
+			name: "succeeds with currency",
+			fields: fields{
+				ID:       uuid.NewString(),
+				Currency: "usd",
+				Price:    42,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &controller.UpdateBookRequest{
-				ID:    tt.fields.ID,
-				Price: tt.fields.Price,
+				ID:       tt.fields.ID,
+				Currency: tt.fields.Currency,
+				Price:    tt.fields.Price,
 			}
 			err := r.Validate()
 			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
@@ -144,3 +329,427 @@ func TestUpdateBookRequest_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestParseListBooksRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		want       *controller.ListBooksRequest
+		wantErr    bool
+		compareErr func(error) bool
+	}{
+		{
+			name:    "fails if limit is not an integer",
+			query:   "limit=ten",
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "limit must be an integer"
+			},
+		},
+		{
+			name:    "fails if min_price is not an integer",
+			query:   "min_price=cheap",
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "min_price must be an integer"
+			},
+		},
+		{
+			name:  "succeeds with no parameters",
+			query: "",
+			want:  &controller.ListBooksRequest{},
+		},
+		{
+			name:  "succeeds",
+			query: "limit=5&cursor=abc&sort=title&order=asc&author=someone&language=it&min_price=1&max_price=9",
+			want: &controller.ListBooksRequest{
+				Cursor:   "abc",
+				Sort:     "title",
+				Order:    "asc",
+				Author:   "someone",
+				Language: "it",
+				Limit:    5,
+				MinPrice: 1,
+				MaxPrice: 9,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal("failed to parse query:", err)
+			}
+			got, err := controller.ParseListBooksRequest(values)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("ParseListBooksRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseListBooksRequest() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListBooksRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    controller.ListBooksRequest
+		wantErr    bool
+		compareErr func(error) bool
+	}{
+		{
+			name:    "fails if limit is negative",
+			request: controller.ListBooksRequest{Limit: -1},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "limit must be between 1 and 100"
+			},
+		},
+		{
+			name:    "fails if limit is too big",
+			request: controller.ListBooksRequest{Limit: 101},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "limit must be between 1 and 100"
+			},
+		},
+		{
+			name:    "fails if sort is unknown",
+			request: controller.ListBooksRequest{Sort: "isbn"},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "sort must be one of title, author, price, created"
+			},
+		},
+		{
+			name:    "fails if order is unknown",
+			request: controller.ListBooksRequest{Order: "up"},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "order must be asc or desc"
+			},
+		},
+		{
+			name:    "fails if price range is negative",
+			request: controller.ListBooksRequest{MinPrice: -1},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "price range must not be negative"
+			},
+		},
+		{
+			name:    "fails if price range is inverted",
+			request: controller.ListBooksRequest{MinPrice: 10, MaxPrice: 5},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "min_price must not be greater than max_price"
+			},
+		},
+		{
+			name:    "succeeds with defaults",
+			request: controller.ListBooksRequest{},
+		},
+		{
+			name: "succeeds",
+			request: controller.ListBooksRequest{
+				Sort:     "created",
+				Order:    "desc",
+				Limit:    100,
+				MinPrice: 10,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestSearchBooksRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    controller.SearchBooksRequest
+		wantErr    bool
+		compareErr func(error) bool
+	}{
+		{
+			name:    "fails if missing query",
+			request: controller.SearchBooksRequest{Limit: 10},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "q is required"
+			},
+		},
+		{
+			name:    "fails if limit is out of range",
+			request: controller.SearchBooksRequest{Query: "dune", Limit: 101},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "limit must be between 1 and 100"
+			},
+		},
+		{
+			name:    "succeeds",
+			request: controller.SearchBooksRequest{Query: "dune"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestParsePatchBookRequest(t *testing.T) {
+	title, price, cur := "A title", int64(42), "JPY"
+	tests := []struct {
+		name       string
+		body       string
+		want       *controller.PatchBookRequest
+		wantErr    bool
+		compareErr func(error) bool
+	}{
+		{
+			name:    "fails if not an object",
+			body:    `["title"]`,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "patch must be a JSON object"
+			},
+		},
+		{
+			name:    "fails if null document",
+			body:    `null`,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "patch must be a JSON object"
+			},
+		},
+		{
+			name:    "fails if id is patched",
+			body:    `{"id": "book:1"}`,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "id is immutable"
+			},
+		},
+		{
+			name:    "fails if unknown field",
+			body:    `{"publisher": "Bompiani"}`,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == `unknown field "publisher"`
+			},
+		},
+		{
+			name:    "fails if isbn is changed",
+			body:    `{"isbn": "9780306406157"}`,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "isbn is immutable"
+			},
+		},
+		{
+			name:    "fails if required field is removed",
+			body:    `{"author": null}`,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "author cannot be removed"
+			},
+		},
+		{
+			name:    "fails if field has the wrong type",
+			body:    `{"price": "cheap"}`,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "invalid price"
+			},
+		},
+		{
+			name: "succeeds with empty patch",
+			body: `{}`,
+			want: &controller.PatchBookRequest{},
+		},
+		{
+			name: "succeeds",
+			body: `{"title": "A title", "price": 42, "currency": "JPY"}`,
+			want: &controller.PatchBookRequest{Title: &title, Price: &price, Currency: &cur},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := controller.ParsePatchBookRequest(strings.NewReader(tt.body))
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("ParsePatchBookRequest() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePatchBookRequest() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchBookRequest_Validate(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	zero, price := int64(0), int64(42)
+	tests := []struct {
+		name       string
+		req        controller.PatchBookRequest
+		wantErr    bool
+		compareErr func(error) bool
+	}{
+		{
+			name:    "fails if empty",
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "patch must change at least one field"
+			},
+		},
+		{
+			name:    "fails if blank title",
+			req:     controller.PatchBookRequest{Title: ptr(" ")},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "title must not be empty"
+			},
+		},
+		{
+			name:    "fails if blank author",
+			req:     controller.PatchBookRequest{Author: ptr("")},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "author must not be empty"
+			},
+		},
+		{
+			name:    "fails if invalid price",
+			req:     controller.PatchBookRequest{Price: &zero},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "price must be greater than zero"
+			},
+		},
+		{
+			name:    "fails if invalid currency",
+			req:     controller.PatchBookRequest{Currency: ptr("ZZZ")},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "invalid currency"
+			},
+		},
+		{
+			name:    "fails if invalid language",
+			req:     controller.PatchBookRequest{Language: ptr("not a language")},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return err.Error() == "invalid language tag"
+			},
+		},
+		{
+			name: "succeeds",
+			req:  controller.PatchBookRequest{Title: ptr("A title"), Language: ptr("it"), Price: &price},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPatchBookRequest_BookPatch(t *testing.T) {
+	ptr := func(s string) *string { return &s }
+	tests := []struct {
+		name string
+		req  controller.PatchBookRequest
+		want *domain.BookPatch
+	}{
+		{
+			name: "canonicalizes the language tag",
+			req:  controller.PatchBookRequest{Language: ptr("EN-us")},
+			want: &domain.BookPatch{LanguageTag: ptr("en-US")},
+		},
+		{
+			name: "normalizes the currency code",
+			req:  controller.PatchBookRequest{Title: ptr("Dune"), Currency: ptr("eur")},
+			want: &domain.BookPatch{Title: ptr("Dune"), Currency: ptr("EUR")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.BookPatch(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BookPatch() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestQueryTags checks the query tag of every field of the query requests names the parameter it is parsed from.
+// A failure means the OpenAPI document of the service no longer describes the parameters.
+func TestQueryTags(t *testing.T) {
+	tests := []struct {
+		parse func(url.Values) (any, error)
+		name  string
+	}{
+		{
+			name:  "ListBooksRequest",
+			parse: func(v url.Values) (any, error) { return controller.ParseListBooksRequest(v) },
+		},
+		{
+			name:  "SearchBooksRequest",
+			parse: func(v url.Values) (any, error) { return controller.ParseSearchBooksRequest(v) },
+		},
+		{
+			name:  "BookHistoryRequest",
+			parse: func(v url.Values) (any, error) { return controller.ParseBookHistoryRequest(v) },
+		},
+		{
+			name:  "ImportBooksRequest",
+			parse: func(v url.Values) (any, error) { return controller.ParseImportBooksRequest(v) },
+		},
+		{
+			name:  "ExportBooksRequest",
+			parse: func(v url.Values) (any, error) { return controller.ParseExportBooksRequest(v) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			empty, err := tt.parse(url.Values{})
+			if err != nil {
+				t.Fatalf("error parsing empty query: %v", err)
+			}
+			typ := reflect.TypeOf(empty).Elem()
+			for i := range typ.NumField() {
+				f := typ.Field(i)
+				param := f.Tag.Get("query")
+				if param == "" {
+					t.Errorf("field %s has no query tag", f.Name)
+					continue
+				}
+				value := map[reflect.Kind]string{reflect.String: "x", reflect.Int: "7", reflect.Bool: "true"}[f.Type.Kind()]
+				got, err := tt.parse(url.Values{param: {value}})
+				if err != nil {
+					t.Fatalf("error parsing %s: %v", param, err)
+				}
+				if reflect.ValueOf(got).Elem().Field(i).Equal(reflect.ValueOf(empty).Elem().Field(i)) {
+					t.Errorf("parameter %s is not parsed into field %s", param, f.Name)
+				}
+			}
+		})
+	}
+}
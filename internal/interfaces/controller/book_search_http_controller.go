@@ -0,0 +1,72 @@
+package controller
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// BookSearchInteractor is the interface an interactor must implement
+// to be used by the BookSearchController to execute search business logic.
+type BookSearchInteractor interface {
+	// SearchBooks retrieves at most limit books matching query, most relevant first.
+	SearchBooks(ctx context.Context, query string, limit int) ([]*domain.Book, error)
+}
+
+// BookSearchController handles full-text search requests over http.
+type BookSearchController struct {
+	interactor    BookSearchInteractor
+	bookPresenter BookPresenter
+	errPresenter  ErrorPresenter
+	resPresenter  ResponsePresenter
+	logger        *slog.Logger
+}
+
+// NewBookSearchController creates a new instance of BookSearchController.
+func NewBookSearchController(
+	logger *slog.Logger,
+	interactor BookSearchInteractor,
+	bookPresenter BookPresenter,
+	errPresenter ErrorPresenter,
+	resPresenter ResponsePresenter,
+) *BookSearchController {
+	return &BookSearchController{
+		interactor:    interactor,
+		logger:        logger,
+		bookPresenter: bookPresenter,
+		errPresenter:  errPresenter,
+		resPresenter:  resPresenter,
+	}
+}
+
+// SearchBooks handles SearchBooksRequest over http.
+func (sc *BookSearchController) SearchBooks(w http.ResponseWriter, r *http.Request) {
+	q, err := ParseSearchBooksRequest(r.URL.Query())
+	if err != nil {
+		sc.logger.With("error", err).Error("unable to parse query parameters")
+		sc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err = q.Validate(); err != nil {
+		sc.logger.With("error", err).Error("invalid query parameters")
+		sc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	books, err := sc.interactor.SearchBooks(r.Context(), q.Query, q.Limit)
+	if err != nil {
+		sc.logger.With("error", err).Error("error searching books")
+		sc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]map[string]any, len(books))
+	for i, book := range books {
+		res[i] = sc.bookPresenter.Present(book)
+	}
+
+	sc.resPresenter.PresentList(w, r, http.StatusOK, res, "")
+}
@@ -0,0 +1,131 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestBookSearchController_SearchBooks(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockSearchInteractor := mocks.NewMockBookSearchInteractor(mockCtl)
+	bookID := uuid.New()
+
+	tests := []struct {
+		name             string
+		query            string
+		mockExpectations func()
+		expect           func(*httptest.ResponseRecorder)
+	}{
+		{
+			name:  "fails to parse query parameters",
+			query: "?q=dune&limit=ten",
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"limit must be an integer","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:  "fails if missing query",
+			query: "?q=%20",
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"q is required","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:  "fails to search books",
+			query: "?q=dune",
+			mockExpectations: func() {
+				mockSearchInteractor.EXPECT().
+					SearchBooks(gomock.Any(), "dune", 0).
+					Return(nil, errors.New("oops"))
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusInternalServerError {
+					t.Errorf("want status: %d, got status %d", http.StatusInternalServerError, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"internal server error","status":"Internal Server Error"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:  "succeeds",
+			query: "?q=dune&limit=5",
+			mockExpectations: func() {
+				mockSearchInteractor.EXPECT().
+					SearchBooks(gomock.Any(), "dune", 5).
+					Return([]*domain.Book{
+						{
+							ID:     bookID,
+							Title:  "dune",
+							Author: "Frank Herbert",
+							Price:  domain.Money{Amount: 42, Currency: "EUR"},
+						},
+					}, nil)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusOK {
+					t.Errorf("want status: %d, got status %d", http.StatusOK, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `[{"author":"Frank Herbert","authors":[],` +
+					`"currency":"EUR","formatted_price":"€ 0.42","id":"book:` + bookID.String() +
+					`","isbn":null,"language":"","price":42,"title":"dune"}]`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(_ *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+
+			sc := controller.NewBookSearchController(
+				logger,
+				mockSearchInteractor,
+				presenter.NewBookPresenter(logger),
+				presenter.NewErrorPresenter(logger),
+				presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+			)
+			r := httptest.NewRequest(http.MethodGet, "/v1/books/search"+tt.query, http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+
+			sc.SearchBooks(w, r)
+			if tt.expect != nil {
+				tt.expect(w)
+			}
+		})
+	}
+}
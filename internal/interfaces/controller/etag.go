@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// formatETag returns the strong entity tag of a resource version.
+func formatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// parseIfMatch returns the version required by an If-Match header.
+// An empty header or "*" impose no precondition and return zero.
+func parseIfMatch(header string) (int, error) {
+	h := strings.TrimSpace(header)
+	if h == "" || h == "*" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(strings.Trim(h, `"`))
+	if err != nil || version <= 0 || !strings.HasPrefix(h, `"`) || !strings.HasSuffix(h, `"`) {
+		return 0, errors.New("invalid If-Match header")
+	}
+	return version, nil
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// InventoryInteractor is the interface an interactor must implement
+// to be used by the InventoryController to execute stock management business logic.
+type InventoryInteractor interface {
+	// GetStock retrieves the stock of the book matching id.
+	GetStock(ctx context.Context, id string) (*domain.Stock, error)
+	// ReceiveStock adds quantity delivered copies to the stock of the book matching id.
+	ReceiveStock(ctx context.Context, id string, quantity int) (*domain.Stock, error)
+	// AdjustStock corrects the copies on hand of the book matching id by delta.
+	AdjustStock(ctx context.Context, id string, delta int) (*domain.Stock, error)
+	// ReserveStock sets quantity copies of the book matching id aside.
+	ReserveStock(ctx context.Context, id string, quantity int) (*domain.Stock, error)
+}
+
+// StockPresenter is the interface a presenter must implement
+// to be used by the InventoryController to return successful responses.
+type StockPresenter interface {
+	// Present prepares the domain.Stock message to be returned.
+	Present(stock *domain.Stock) map[string]any
+}
+
+// InventoryController handles stock management requests over http.
+type InventoryController struct {
+	interactor     InventoryInteractor
+	stockPresenter StockPresenter
+	errPresenter   ErrorPresenter
+	resPresenter   ResponsePresenter
+	logger         *slog.Logger
+}
+
+// NewInventoryController creates a new instance of InventoryController.
+func NewInventoryController(
+	logger *slog.Logger,
+	interactor InventoryInteractor,
+	stockPresenter StockPresenter,
+	errPresenter ErrorPresenter,
+	resPresenter ResponsePresenter,
+) *InventoryController {
+	return &InventoryController{
+		interactor:     interactor,
+		logger:         logger,
+		stockPresenter: stockPresenter,
+		errPresenter:   errPresenter,
+		resPresenter:   resPresenter,
+	}
+}
+
+// GetStock handles read stock by book ID requests over http.
+func (ic *InventoryController) GetStock(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		ic.errPresenter.Present(w, r, errors.New("book id is required"), http.StatusBadRequest)
+		return
+	}
+
+	stock, err := ic.interactor.GetStock(r.Context(), id)
+	ic.present(w, r, id, stock, err)
+}
+
+// ReceiveStock handles StockQuantityRequest for delivered copies over http.
+func (ic *InventoryController) ReceiveStock(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req StockQuantityRequest
+	if !ic.decode(w, r, id, &req) {
+		return
+	}
+	stock, err := ic.interactor.ReceiveStock(r.Context(), id, req.Quantity)
+	ic.present(w, r, id, stock, err)
+}
+
+// AdjustStock handles AdjustStockRequest over http.
+func (ic *InventoryController) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req AdjustStockRequest
+	if !ic.decode(w, r, id, &req) {
+		return
+	}
+	stock, err := ic.interactor.AdjustStock(r.Context(), id, req.Delta)
+	ic.present(w, r, id, stock, err)
+}
+
+// ReserveStock handles StockQuantityRequest for reservations over http.
+// Reservations exceeding the available copies are rejected with 409 Conflict.
+func (ic *InventoryController) ReserveStock(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req StockQuantityRequest
+	if !ic.decode(w, r, id, &req) {
+		return
+	}
+	stock, err := ic.interactor.ReserveStock(r.Context(), id, req.Quantity)
+	ic.present(w, r, id, stock, err)
+}
+
+// decode reads and validates the request body into req.
+// It reports false if the request was rejected and the error response already written.
+func (ic *InventoryController) decode(
+	w http.ResponseWriter,
+	r *http.Request,
+	id string,
+	req interface{ Validate() error },
+) bool {
+	if id == "" {
+		ic.errPresenter.Present(w, r, errors.New("book id is required"), http.StatusBadRequest)
+		return false
+	}
+	l := ic.logger.With("book_id", id)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		l.With("error", err).Error("unable to decode request body")
+		ic.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return false
+	}
+	if err := req.Validate(); err != nil {
+		l.With("error", err).Error("invalid request body")
+		ic.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// present writes stock, or err if the operation failed.
+func (ic *InventoryController) present(
+	w http.ResponseWriter, r *http.Request, id string, stock *domain.Stock, err error,
+) {
+	l := ic.logger.With("book_id", id)
+	if err != nil {
+		l.With("error", err).Error("error managing stock")
+		ic.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	ic.resPresenter.Present(w, r, http.StatusOK, ic.stockPresenter.Present(stock))
+}
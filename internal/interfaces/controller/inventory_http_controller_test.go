@@ -0,0 +1,283 @@
+package controller_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestInventoryController_GetStock(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockInventoryInteractor := mocks.NewMockInventoryInteractor(mockCtl)
+	bookID := uuid.New()
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		id               string
+		mockExpectations func()
+		expect           func(*httptest.ResponseRecorder)
+	}{
+		{
+			name: "fails with missing id",
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"book id is required","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "fails to get stock",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockInventoryInteractor.EXPECT().
+					GetStock(gomock.Any(), bookID.String()).
+					Return(nil, domain.ErrBookNotFound)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusNotFound {
+					t.Errorf("want status: %d, got status %d", http.StatusNotFound, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"book not found","status":"Not Found"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "succeeds",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockInventoryInteractor.EXPECT().
+					GetStock(gomock.Any(), bookID.String()).
+					Return(&domain.Stock{BookID: bookID, OnHand: 5, Reserved: 2, UpdatedAt: updatedAt}, nil)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusOK {
+					t.Errorf("want status: %d, got status %d", http.StatusOK, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"available":3,"book_id":"book:` + bookID.String() +
+					`","on_hand":5,"reserved":2,"updated_at":"2024-05-01T12:00:00Z"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(_ *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+
+			ic := controller.NewInventoryController(
+				logger,
+				mockInventoryInteractor,
+				presenter.NewStockPresenter(logger),
+				presenter.NewErrorPresenter(logger),
+				presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+			)
+			r := httptest.NewRequest(http.MethodGet, "/v1/books/"+tt.id+"/stock", http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			ic.GetStock(w, r)
+			if tt.expect != nil {
+				tt.expect(w)
+			}
+		})
+	}
+}
+
+func TestInventoryController_UpdateStock(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockInventoryInteractor := mocks.NewMockInventoryInteractor(mockCtl)
+	bookID := uuid.New()
+	ic := controller.NewInventoryController(
+		logger,
+		mockInventoryInteractor,
+		presenter.NewStockPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		id               string
+		body             string
+		handler          http.HandlerFunc
+		mockExpectations func()
+		expect           func(*httptest.ResponseRecorder)
+	}{
+		{
+			name:    "fails with missing id",
+			body:    `{"quantity": 1}`,
+			handler: ic.ReceiveStock,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"book id is required","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "fails to decode request body",
+			id:      bookID.String(),
+			body:    `}} broken json body`,
+			handler: ic.ReceiveStock,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid character '}' looking for beginning of value","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "fails to validate request",
+			id:      bookID.String(),
+			body:    `{"delta": 0}`,
+			handler: ic.AdjustStock,
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusBadRequest {
+					t.Errorf("want status: %d, got status %d", http.StatusBadRequest, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"delta must not be zero","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "fails with insufficient stock",
+			id:      bookID.String(),
+			body:    `{"quantity": 3}`,
+			handler: ic.ReserveStock,
+			mockExpectations: func() {
+				mockInventoryInteractor.EXPECT().
+					ReserveStock(gomock.Any(), bookID.String(), 3).
+					Return(nil, domain.ErrInsufficientStock)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusConflict {
+					t.Errorf("want status: %d, got status %d", http.StatusConflict, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"insufficient stock","status":"Conflict"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "fails with generic error",
+			id:      bookID.String(),
+			body:    `{"delta": -1}`,
+			handler: ic.AdjustStock,
+			mockExpectations: func() {
+				mockInventoryInteractor.EXPECT().
+					AdjustStock(gomock.Any(), bookID.String(), -1).
+					Return(nil, errors.New("oops"))
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusInternalServerError {
+					t.Errorf("want status: %d, got status %d", http.StatusInternalServerError, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"internal server error","status":"Internal Server Error"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "receive succeeds",
+			id:      bookID.String(),
+			body:    `{"quantity": 4}`,
+			handler: ic.ReceiveStock,
+			mockExpectations: func() {
+				mockInventoryInteractor.EXPECT().
+					ReceiveStock(gomock.Any(), bookID.String(), 4).
+					Return(&domain.Stock{BookID: bookID, OnHand: 4}, nil)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusOK {
+					t.Errorf("want status: %d, got status %d", http.StatusOK, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"available":4,"book_id":"book:` + bookID.String() + `","on_hand":4,"reserved":0}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name:    "reserve succeeds",
+			id:      bookID.String(),
+			body:    `{"quantity": 1}`,
+			handler: ic.ReserveStock,
+			mockExpectations: func() {
+				mockInventoryInteractor.EXPECT().
+					ReserveStock(gomock.Any(), bookID.String(), 1).
+					Return(&domain.Stock{BookID: bookID, OnHand: 4, Reserved: 1}, nil)
+			},
+			expect: func(res *httptest.ResponseRecorder) {
+				if res.Code != http.StatusOK {
+					t.Errorf("want status: %d, got status %d", http.StatusOK, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"available":3,"book_id":"book:` + bookID.String() + `","on_hand":4,"reserved":1}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(_ *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/v1/books/"+tt.id+"/stock", strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			tt.handler(w, r)
+			if tt.expect != nil {
+				tt.expect(w)
+			}
+		})
+	}
+}
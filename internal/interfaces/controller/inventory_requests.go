@@ -0,0 +1,30 @@
+package controller
+
+import "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+
+// StockQuantityRequest defines the expected request to receive or reserve copies of a book.
+type StockQuantityRequest struct {
+	Quantity int `json:"quantity" required:"true"`
+}
+
+// Validate a StockQuantityRequest.
+func (r *StockQuantityRequest) Validate() error {
+	if r.Quantity <= 0 {
+		return domain.NewFieldError("quantity", "quantity must be greater than zero")
+	}
+	return nil
+}
+
+// AdjustStockRequest defines the expected request to correct the copies on hand of a book.
+// Delta is negative to remove copies.
+type AdjustStockRequest struct {
+	Delta int `json:"delta" required:"true"`
+}
+
+// Validate an AdjustStockRequest.
+func (r *AdjustStockRequest) Validate() error {
+	if r.Delta == 0 {
+		return domain.NewFieldError("delta", "delta must not be zero")
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+)
+
+func TestStockQuantityRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		quantity int
+		wantErr  bool
+	}{
+		{name: "fails if zero", quantity: 0, wantErr: true},
+		{name: "fails if negative", quantity: -1, wantErr: true},
+		{name: "succeeds", quantity: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &controller.StockQuantityRequest{Quantity: tt.quantity}
+			err := r.Validate()
+			if tt.wantErr != (err != nil) || (tt.wantErr && err.Error() != "quantity must be greater than zero") {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAdjustStockRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		delta   int
+		wantErr bool
+	}{
+		{name: "fails if zero", delta: 0, wantErr: true},
+		{name: "succeeds removing copies", delta: -2},
+		{name: "succeeds adding copies", delta: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &controller.AdjustStockRequest{Delta: tt.delta}
+			err := r.Validate()
+			if tt.wantErr != (err != nil) || (tt.wantErr && err.Error() != "delta must not be zero") {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
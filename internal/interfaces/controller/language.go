@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// wildcard is the tag language.ParseAcceptLanguage reads the "*" wildcard as.
+var wildcard = language.MustParse("mul")
+
+// preferredLanguages returns the languages of an Accept-Language header, most preferred first.
+// Languages weighted zero and the "*" wildcard are left out; an empty or malformed header has none.
+func preferredLanguages(acceptLanguage string) []language.Tag {
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return nil
+	}
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil {
+		return nil
+	}
+	preferred := make([]language.Tag, 0, len(tags))
+	for _, tag := range tags {
+		if tag != wildcard && tag != language.Und {
+			preferred = append(preferred, tag)
+		}
+	}
+	return preferred
+}
+
+// setContentLanguage advertises the language of the response body, if known.
+func setContentLanguage(w http.ResponseWriter, tag string) {
+	if tag != "" {
+		w.Header().Set("Content-Language", tag)
+	}
+}
+
+// bookLanguages returns the distinct languages of books, in the order they first appear,
+// as a Content-Language list; fallback is returned when there are no books.
+func bookLanguages(books []*domain.Book, fallback string) string {
+	var tags []string
+	for _, b := range books {
+		if b.LanguageTag != "" && !slices.Contains(tags, b.LanguageTag) {
+			tags = append(tags, b.LanguageTag)
+		}
+	}
+	if len(tags) == 0 {
+		return fallback
+	}
+	return strings.Join(tags, ", ")
+}
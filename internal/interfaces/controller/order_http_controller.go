@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// OrderInteractor is the interface an interactor must implement
+// to be used by the OrderController to execute checkout business logic.
+type OrderInteractor interface {
+	// PlaceOrder creates a pending order for the requested copies of each book.
+	PlaceOrder(ctx context.Context, items []domain.OrderItem) (*domain.Order, error)
+	// GetOrder retrieves a domain.Order by its ID.
+	GetOrder(ctx context.Context, id string) (*domain.Order, error)
+	// ListOrders retrieves every order, oldest first.
+	ListOrders(ctx context.Context) ([]*domain.Order, error)
+	// PayOrder marks a pending order as paid.
+	PayOrder(ctx context.Context, id string) (*domain.Order, error)
+	// ShipOrder marks a paid order as shipped.
+	ShipOrder(ctx context.Context, id string) (*domain.Order, error)
+	// CancelOrder cancels an order that was not shipped yet.
+	CancelOrder(ctx context.Context, id string) (*domain.Order, error)
+}
+
+// OrderPresenter is the interface a presenter must implement
+// to be used by the OrderController to return successful responses.
+type OrderPresenter interface {
+	// Present prepares the domain.Order message to be returned.
+	Present(order *domain.Order) map[string]any
+}
+
+// OrderController handles checkout and order lifecycle requests over http.
+type OrderController struct {
+	interactor     OrderInteractor
+	orderPresenter OrderPresenter
+	errPresenter   ErrorPresenter
+	resPresenter   ResponsePresenter
+	logger         *slog.Logger
+}
+
+// NewOrderController creates a new instance of OrderController.
+func NewOrderController(
+	logger *slog.Logger,
+	interactor OrderInteractor,
+	orderPresenter OrderPresenter,
+	errPresenter ErrorPresenter,
+	resPresenter ResponsePresenter,
+) *OrderController {
+	return &OrderController{
+		interactor:     interactor,
+		logger:         logger,
+		orderPresenter: orderPresenter,
+		errPresenter:   errPresenter,
+		resPresenter:   resPresenter,
+	}
+}
+
+// PlaceOrder handles PlaceOrderRequest over http.
+// Responds with the pending order.
+func (oc *OrderController) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	var req PlaceOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		oc.logger.With("error", err).Error("unable to decode request body")
+		oc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		oc.logger.With("error", err).Error("invalid request body")
+		oc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	items := make([]domain.OrderItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = domain.OrderItem{BookID: domain.BookResource.MustParse(item.BookID), Quantity: item.Quantity}
+	}
+	order, err := oc.interactor.PlaceOrder(r.Context(), items)
+	if err != nil {
+		oc.logger.With("error", err).Error("unable to place order")
+		oc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	oc.present(w, r, http.StatusCreated, order)
+}
+
+// GetOrder handles read order by ID requests over http.
+func (oc *OrderController) GetOrder(w http.ResponseWriter, r *http.Request) {
+	oc.handle(w, r, oc.interactor.GetOrder)
+}
+
+// ListOrders handles read orders requests over http.
+func (oc *OrderController) ListOrders(w http.ResponseWriter, r *http.Request) {
+	orders, err := oc.interactor.ListOrders(r.Context())
+	if err != nil {
+		oc.logger.With("error", err).Error("error listing orders")
+		oc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]map[string]any, len(orders))
+	for i, order := range orders {
+		res[i] = oc.orderPresenter.Present(order)
+	}
+	oc.resPresenter.PresentList(w, r, http.StatusOK, map[string]any{"orders": res}, "orders")
+}
+
+// PayOrder handles payment of an order by ID over http.
+func (oc *OrderController) PayOrder(w http.ResponseWriter, r *http.Request) {
+	oc.handle(w, r, oc.interactor.PayOrder)
+}
+
+// ShipOrder handles shipment of an order by ID over http.
+func (oc *OrderController) ShipOrder(w http.ResponseWriter, r *http.Request) {
+	oc.handle(w, r, oc.interactor.ShipOrder)
+}
+
+// CancelOrder handles cancellation of an order by ID over http.
+func (oc *OrderController) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	oc.handle(w, r, oc.interactor.CancelOrder)
+}
+
+// handle runs an interactor operation on the order matching the id path value and presents the result.
+func (oc *OrderController) handle(
+	w http.ResponseWriter,
+	r *http.Request,
+	operation func(ctx context.Context, id string) (*domain.Order, error),
+) {
+	id := r.PathValue("id")
+	if id == "" {
+		oc.errPresenter.Present(w, r, errors.New("order id is required"), http.StatusBadRequest)
+		return
+	}
+
+	order, err := operation(r.Context(), id)
+	if err != nil {
+		oc.logger.With("order_id", id, "error", err).Error("error handling order")
+		oc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	oc.present(w, r, http.StatusOK, order)
+}
+
+func (oc *OrderController) present(w http.ResponseWriter, r *http.Request, status int, order *domain.Order) {
+	oc.resPresenter.Present(w, r, status, oc.orderPresenter.Present(order))
+}
@@ -0,0 +1,193 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestOrderController_PlaceOrder(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockOrderInteractor := mocks.NewMockOrderInteractor(mockCtl)
+	bookID := uuid.New()
+	orderID := uuid.New()
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	oc := controller.NewOrderController(
+		logger,
+		mockOrderInteractor,
+		presenter.NewOrderPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		body             string
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:     "fails to decode body",
+			body:     `{`,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"unexpected EOF","status":"Bad Request"}`,
+		},
+		{
+			name:     "fails to validate body",
+			body:     `{"items":[]}`,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"items are required","status":"Bad Request"}`,
+		},
+		{
+			name: "fails with insufficient stock",
+			body: `{"items":[{"book_id":"` + bookID.String() + `","quantity":2}]}`,
+			mockExpectations: func() {
+				mockOrderInteractor.EXPECT().
+					PlaceOrder(gomock.Any(), []domain.OrderItem{{BookID: bookID, Quantity: 2}}).
+					Return(nil, domain.ErrInsufficientStock)
+			},
+			wantCode: http.StatusConflict,
+			want:     `{"message":"insufficient stock","status":"Conflict"}`,
+		},
+		{
+			name: "succeeds",
+			body: `{"items":[{"book_id":"` + bookID.String() + `","quantity":2}]}`,
+			mockExpectations: func() {
+				mockOrderInteractor.EXPECT().
+					PlaceOrder(gomock.Any(), []domain.OrderItem{{BookID: bookID, Quantity: 2}}).
+					Return(&domain.Order{
+						ID:        orderID,
+						Status:    domain.OrderPending,
+						CreatedAt: createdAt,
+						UpdatedAt: createdAt,
+						Items: []domain.OrderItem{{
+							BookID:    bookID,
+							Title:     "Dune",
+							Quantity:  2,
+							UnitPrice: domain.Money{Amount: 1000, Currency: "EUR"},
+						}},
+						Total: domain.Money{Amount: 2000, Currency: "EUR"},
+					}, nil)
+			},
+			wantCode: http.StatusCreated,
+			want: `{"created_at":"2024-05-01T12:00:00Z","currency":"EUR","id":"order:` + orderID.String() +
+				`","items":[{"book_id":"book:` + bookID.String() +
+				`","quantity":2,"subtotal":2000,"title":"Dune","unit_price":1000}],` +
+				`"status":"pending","total":2000,"updated_at":"2024-05-01T12:00:00Z"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/orders", strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+
+			oc.PlaceOrder(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOrderController_Transitions(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockOrderInteractor := mocks.NewMockOrderInteractor(mockCtl)
+	orderID := uuid.New()
+	oc := controller.NewOrderController(
+		logger,
+		mockOrderInteractor,
+		presenter.NewOrderPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		id               string
+		handler          http.HandlerFunc
+		mockExpectations func()
+		wantCode         int
+		wantBody         string
+	}{
+		{
+			name:     "fails with missing id",
+			handler:  oc.PayOrder,
+			wantCode: http.StatusBadRequest,
+			wantBody: `{"message":"order id is required","status":"Bad Request"}`,
+		},
+		{
+			name:    "fails with order not found",
+			id:      orderID.String(),
+			handler: oc.GetOrder,
+			mockExpectations: func() {
+				mockOrderInteractor.EXPECT().GetOrder(gomock.Any(), orderID.String()).Return(nil, domain.ErrOrderNotFound)
+			},
+			wantCode: http.StatusNotFound,
+			wantBody: `{"message":"order not found","status":"Not Found"}`,
+		},
+		{
+			name:    "fails with illegal transition",
+			id:      orderID.String(),
+			handler: oc.ShipOrder,
+			mockExpectations: func() {
+				mockOrderInteractor.EXPECT().
+					ShipOrder(gomock.Any(), orderID.String()).
+					Return(nil, domain.ErrIllegalOrderTransition)
+			},
+			wantCode: http.StatusConflict,
+			wantBody: `{"message":"illegal order status transition","status":"Conflict"}`,
+		},
+		{
+			name:    "succeeds",
+			id:      orderID.String(),
+			handler: oc.CancelOrder,
+			mockExpectations: func() {
+				mockOrderInteractor.EXPECT().
+					CancelOrder(gomock.Any(), orderID.String()).
+					Return(&domain.Order{ID: orderID, Status: domain.OrderCancelled}, nil)
+			},
+			wantCode: http.StatusOK,
+			wantBody: `"status":"cancelled"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/orders/"+tt.id, http.NoBody)
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			tt.handler(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if !strings.Contains(w.Body.String(), tt.wantBody) {
+				t.Errorf("want %s in %s", tt.wantBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// PlaceOrderRequest defines the expected request for checkout.
+type PlaceOrderRequest struct {
+	Items []OrderItemRequest `json:"items" required:"true"`
+}
+
+// OrderItemRequest defines the expected copies of a book in a PlaceOrderRequest.
+type OrderItemRequest struct {
+	BookID   string `json:"book_id" required:"true"`
+	Quantity int    `json:"quantity" required:"true"`
+}
+
+// Validate a PlaceOrderRequest.
+func (r *PlaceOrderRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return domain.NewFieldError("items", "items are required")
+	}
+	v := &domain.ValidationError{}
+	for i, item := range r.Items {
+		if item.BookID == "" {
+			v.Add(fmt.Sprintf("items[%d].book_id", i), fmt.Sprintf("items[%d]: book_id is required", i))
+		} else if _, err := domain.BookResource.Parse(item.BookID); err != nil {
+			v.Add(fmt.Sprintf("items[%d].book_id", i), fmt.Sprintf("items[%d]: %s", i, invalidID("book_id", err)))
+		}
+		if item.Quantity <= 0 {
+			v.Add(fmt.Sprintf("items[%d].quantity", i), fmt.Sprintf("items[%d]: quantity must be greater than zero", i))
+		}
+	}
+	return v.Err()
+}
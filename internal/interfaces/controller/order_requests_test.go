@@ -0,0 +1,52 @@
+package controller_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+)
+
+func TestPlaceOrderRequest_Validate(t *testing.T) {
+	bookID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		items   []controller.OrderItemRequest
+		wantErr string
+	}{
+		{name: "fails without items", wantErr: "items are required"},
+		{
+			name:    "fails without book id",
+			items:   []controller.OrderItemRequest{{Quantity: 1}},
+			wantErr: "items[0]: book_id is required",
+		},
+		{
+			name:    "fails with invalid book id",
+			items:   []controller.OrderItemRequest{{BookID: bookID, Quantity: 1}, {BookID: "invalid", Quantity: 1}},
+			wantErr: "items[1]: invalid book_id",
+		},
+		{
+			name:    "fails with the id of another resource type",
+			items:   []controller.OrderItemRequest{{BookID: "order:" + bookID, Quantity: 1}},
+			wantErr: "items[0]: book_id: wrong resource type: expected book id, got order id",
+		},
+		{
+			name:    "fails with zero quantity",
+			items:   []controller.OrderItemRequest{{BookID: bookID}},
+			wantErr: "items[0]: quantity must be greater than zero",
+		},
+		{name: "succeeds", items: []controller.OrderItemRequest{{BookID: bookID, Quantity: 2}}},
+		{name: "succeeds with prefixed id", items: []controller.OrderItemRequest{{BookID: "book:" + bookID, Quantity: 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &controller.PlaceOrderRequest{Items: tt.items}
+			err := r.Validate()
+			if (tt.wantErr != "") != (err != nil) || (err != nil && err.Error() != tt.wantErr) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
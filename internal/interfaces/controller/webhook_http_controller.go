@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// WebhookInteractor is the interface an interactor must implement
+// to be used by the WebhookController to execute webhook business logic.
+type WebhookInteractor interface {
+	// CreateWebhook sends the webhook to be created to the underlying repository.
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) error
+	// GetWebhook retrieves a domain.Webhook by its ID.
+	GetWebhook(ctx context.Context, id string) (*domain.Webhook, error)
+	// ListWebhooks retrieves every webhook, oldest first.
+	ListWebhooks(ctx context.Context) ([]*domain.Webhook, error)
+	// DeleteWebhook removes a webhook, together with its deliveries.
+	DeleteWebhook(ctx context.Context, id string) error
+	// ListDeliveries retrieves every delivery to the webhook matching id, newest first.
+	ListDeliveries(ctx context.Context, id string) ([]*domain.Delivery, error)
+}
+
+// WebhookPresenter is the interface a presenter must implement
+// to be used by the WebhookController to return successful responses.
+type WebhookPresenter interface {
+	// Present prepares the domain.Webhook message to be returned.
+	Present(webhook *domain.Webhook) map[string]any
+	// PresentDelivery prepares the domain.Delivery message to be returned.
+	PresentDelivery(delivery *domain.Delivery) map[string]any
+}
+
+// WebhookController handles webhook subscription requests over http.
+type WebhookController struct {
+	interactor       WebhookInteractor
+	webhookPresenter WebhookPresenter
+	errPresenter     ErrorPresenter
+	resPresenter     ResponsePresenter
+	logger           *slog.Logger
+}
+
+// NewWebhookController creates a new instance of WebhookController.
+func NewWebhookController(
+	logger *slog.Logger,
+	interactor WebhookInteractor,
+	webhookPresenter WebhookPresenter,
+	errPresenter ErrorPresenter,
+	resPresenter ResponsePresenter,
+) *WebhookController {
+	return &WebhookController{
+		interactor:       interactor,
+		logger:           logger,
+		webhookPresenter: webhookPresenter,
+		errPresenter:     errPresenter,
+		resPresenter:     resPresenter,
+	}
+}
+
+// CreateWebhook handles WebhookRequest over http.
+// Responds with the created webhook, without its secret.
+func (wc *WebhookController) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	var req WebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		wc.logger.With("error", err).Error("unable to decode request body")
+		wc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		wc.logger.With("error", err).Error("invalid request body")
+		wc.errPresenter.Present(w, r, err, http.StatusBadRequest)
+		return
+	}
+
+	webhook := &domain.Webhook{URL: req.URL, Secret: req.Secret, Events: make([]domain.EventType, len(req.Events))}
+	for i, e := range req.Events {
+		webhook.Events[i] = domain.EventType(e)
+	}
+	if err := wc.interactor.CreateWebhook(r.Context(), webhook); err != nil {
+		wc.logger.With("error", err).Error("unable to create webhook")
+		wc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	wc.present(w, r, http.StatusCreated, webhook)
+}
+
+// GetWebhook handles read webhook by ID requests over http.
+func (wc *WebhookController) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := wc.id(w, r)
+	if !ok {
+		return
+	}
+
+	webhook, err := wc.interactor.GetWebhook(r.Context(), id)
+	if err != nil {
+		wc.logger.With("webhook_id", id, "error", err).Error("error getting webhook")
+		wc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	wc.present(w, r, http.StatusOK, webhook)
+}
+
+// ListWebhooks handles read webhooks requests over http.
+func (wc *WebhookController) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := wc.interactor.ListWebhooks(r.Context())
+	if err != nil {
+		wc.logger.With("error", err).Error("error listing webhooks")
+		wc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]map[string]any, len(webhooks))
+	for i, webhook := range webhooks {
+		res[i] = wc.webhookPresenter.Present(webhook)
+	}
+	wc.resPresenter.PresentList(w, r, http.StatusOK, map[string]any{"webhooks": res}, "webhooks")
+}
+
+// DeleteWebhook handles delete webhook by ID requests over http.
+// Deliveries still pending are dropped with the webhook.
+func (wc *WebhookController) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, ok := wc.id(w, r)
+	if !ok {
+		return
+	}
+
+	if err := wc.interactor.DeleteWebhook(r.Context(), id); err != nil {
+		wc.logger.With("webhook_id", id, "error", err).Error("error deleting webhook")
+		wc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+	}
+}
+
+// ListDeliveries handles read deliveries by webhook ID requests over http.
+// Responds with every delivery to the webhook, newest first, and their attempts.
+func (wc *WebhookController) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, ok := wc.id(w, r)
+	if !ok {
+		return
+	}
+
+	deliveries, err := wc.interactor.ListDeliveries(r.Context(), id)
+	if err != nil {
+		wc.logger.With("webhook_id", id, "error", err).Error("error listing deliveries")
+		wc.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	res := make([]map[string]any, len(deliveries))
+	for i, delivery := range deliveries {
+		res[i] = wc.webhookPresenter.PresentDelivery(delivery)
+	}
+	wc.resPresenter.PresentList(w, r, http.StatusOK, map[string]any{"deliveries": res}, "deliveries")
+}
+
+// id reads the webhook id path value, presenting an error if it is missing.
+func (wc *WebhookController) id(w http.ResponseWriter, r *http.Request) (string, bool) {
+	id := r.PathValue("id")
+	if id == "" {
+		wc.errPresenter.Present(w, r, errors.New("webhook id is required"), http.StatusBadRequest)
+		return "", false
+	}
+	return id, true
+}
+
+func (wc *WebhookController) present(w http.ResponseWriter, r *http.Request, status int, webhook *domain.Webhook) {
+	wc.resPresenter.Present(w, r, status, wc.webhookPresenter.Present(webhook))
+}
@@ -0,0 +1,181 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/controller"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestWebhookController(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	mockCtl := gomock.NewController(t)
+	mockWebhookInteractor := mocks.NewMockWebhookInteractor(mockCtl)
+	webhookID := uuid.MustParse("3e8b0c1a-4f2d-4b6e-9a1c-5d7f8e9a0b1c")
+	deliveryID := uuid.MustParse("7a6b5c4d-3e2f-4a1b-8c9d-0e1f2a3b4c5d")
+	eventID := uuid.MustParse("0b7c4b2e-6d1f-4f0a-8d3a-7e9c2a1b5c33")
+	bookID := uuid.MustParse("5f1c8a64-8f5e-4b8e-9a57-2c1d1b0b6e42")
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	wc := controller.NewWebhookController(
+		logger,
+		mockWebhookInteractor,
+		presenter.NewWebhookPresenter(logger),
+		presenter.NewErrorPresenter(logger),
+		presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger)),
+	)
+
+	tests := []struct {
+		name             string
+		id               string
+		body             string
+		handler          http.HandlerFunc
+		mockExpectations func()
+		wantCode         int
+		want             string
+	}{
+		{
+			name:     "fails to create with a relative url",
+			body:     `{"url":"/hook","secret":"0123456789abcdef"}`,
+			handler:  wc.CreateWebhook,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"url must be an absolute http or https URL","status":"Bad Request"}`,
+		},
+		{
+			name:     "fails to create with a short secret",
+			body:     `{"url":"https://example.com/hook","secret":"short"}`,
+			handler:  wc.CreateWebhook,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"secret must be at least 16 characters long","status":"Bad Request"}`,
+		},
+		{
+			name:     "fails to create with an unknown event",
+			body:     `{"url":"https://example.com/hook","secret":"0123456789abcdef","events":["book.sold"]}`,
+			handler:  wc.CreateWebhook,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"events[0]: unsupported event type \"book.sold\"","status":"Bad Request"}`,
+		},
+		{
+			name:    "creates a webhook without returning its secret",
+			body:    `{"url":"https://example.com/hook","secret":"0123456789abcdef","events":["book.created"]}`,
+			handler: wc.CreateWebhook,
+			mockExpectations: func() {
+				mockWebhookInteractor.EXPECT().
+					CreateWebhook(gomock.Any(), &domain.Webhook{
+						URL:    "https://example.com/hook",
+						Secret: "0123456789abcdef",
+						Events: []domain.EventType{domain.EventBookCreated},
+					}).
+					DoAndReturn(func(_ any, w *domain.Webhook) error {
+						w.ID, w.CreatedAt = webhookID, createdAt
+						return nil
+					})
+			},
+			wantCode: http.StatusCreated,
+			want: `{"created_at":"2024-05-01T12:00:00Z","events":["book.created"],"id":"webhook:` +
+				webhookID.String() + `","url":"https://example.com/hook"}`,
+		},
+		{
+			name:    "lists webhooks",
+			handler: wc.ListWebhooks,
+			mockExpectations: func() {
+				mockWebhookInteractor.EXPECT().
+					ListWebhooks(gomock.Any()).
+					Return([]*domain.Webhook{{ID: webhookID, URL: "https://example.com/hook", CreatedAt: createdAt}}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"webhooks":[{"created_at":"2024-05-01T12:00:00Z","events":[],"id":"webhook:` +
+				webhookID.String() + `","url":"https://example.com/hook"}]}`,
+		},
+		{
+			name:    "fails to get a missing webhook",
+			id:      webhookID.String(),
+			handler: wc.GetWebhook,
+			mockExpectations: func() {
+				mockWebhookInteractor.EXPECT().
+					GetWebhook(gomock.Any(), webhookID.String()).
+					Return(nil, domain.ErrWebhookNotFound)
+			},
+			wantCode: http.StatusNotFound,
+			want:     `{"message":"webhook not found","status":"Not Found"}`,
+		},
+		{
+			name:    "lists the deliveries of a webhook",
+			id:      webhookID.String(),
+			handler: wc.ListDeliveries,
+			mockExpectations: func() {
+				mockWebhookInteractor.EXPECT().
+					ListDeliveries(gomock.Any(), webhookID.String()).
+					Return([]*domain.Delivery{{
+						CreatedAt:     createdAt,
+						NextAttemptAt: createdAt.Add(30 * time.Second),
+						Status:        domain.DeliveryPending,
+						Attempts: []domain.DeliveryAttempt{
+							{At: createdAt, Error: "dial tcp: connection refused"},
+							{At: createdAt.Add(10 * time.Second), Error: "unexpected status 503", StatusCode: 503},
+						},
+						Event: domain.Event{
+							At: createdAt, Type: domain.EventBookDeleted, ID: eventID, BookID: bookID,
+						},
+						ID:        deliveryID,
+						WebhookID: webhookID,
+					}}, nil)
+			},
+			wantCode: http.StatusOK,
+			want: `{"deliveries":[{"attempts":[` +
+				`{"at":"2024-05-01T12:00:00Z","error":"dial tcp: connection refused","status_code":null},` +
+				`{"at":"2024-05-01T12:00:10Z","error":"unexpected status 503","status_code":503}],` +
+				`"created_at":"2024-05-01T12:00:00Z",` +
+				`"event":{"book_id":"book:` + bookID.String() + `","id":"event:` + eventID.String() +
+				`","occurred_at":"2024-05-01T12:00:00Z","type":"book.deleted"},` +
+				`"id":"delivery:` + deliveryID.String() + `","next_attempt_at":"2024-05-01T12:00:30Z",` +
+				`"status":"pending","webhook_id":"webhook:` + webhookID.String() + `"}]}`,
+		},
+		{
+			name:    "fails to delete with an invalid id",
+			id:      "invalid",
+			handler: wc.DeleteWebhook,
+			mockExpectations: func() {
+				mockWebhookInteractor.EXPECT().
+					DeleteWebhook(gomock.Any(), "invalid").
+					Return(domain.ErrInvalidWebhookID)
+			},
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"invalid webhook id","status":"Bad Request"}`,
+		},
+		{
+			name:     "fails to delete without id",
+			handler:  wc.DeleteWebhook,
+			wantCode: http.StatusBadRequest,
+			want:     `{"message":"webhook id is required","status":"Bad Request"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			r := httptest.NewRequest(http.MethodPost, "/v1/webhooks/"+tt.id, strings.NewReader(tt.body))
+			r.Header.Set("Accept", "application/json")
+			r.SetPathValue("id", tt.id)
+			w := httptest.NewRecorder()
+
+			tt.handler(w, r)
+			if w.Code != tt.wantCode {
+				t.Errorf("want status: %d, got status %d", tt.wantCode, w.Code)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// WebhookRequest defines the expected request to subscribe a webhook.
+// Events filters the delivered event types; every event is delivered when it is empty.
+type WebhookRequest struct {
+	URL    string   `json:"url" required:"true"`
+	Secret string   `json:"secret" required:"true"`
+	Events []string `json:"events"`
+}
+
+// Validate a WebhookRequest.
+func (r *WebhookRequest) Validate() error {
+	v := &domain.ValidationError{}
+	if r.URL == "" {
+		v.Add("url", "url is required")
+	} else if u, err := url.Parse(r.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.Add("url", "url must be an absolute http or https URL")
+	}
+	if len(r.Secret) < domain.MinWebhookSecretLength {
+		v.Add("secret", fmt.Sprintf("secret must be at least %d characters long", domain.MinWebhookSecretLength))
+	}
+	for i, e := range r.Events {
+		if !domain.EventType(e).IsValid() {
+			v.Add(fmt.Sprintf("events[%d]", i), fmt.Sprintf("events[%d]: unsupported event type %q", i, e))
+		}
+	}
+	return v.Err()
+}
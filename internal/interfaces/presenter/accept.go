@@ -0,0 +1,65 @@
+package presenter
+
+import (
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// acceptQuality returns the quality the Accept header value accept gives to mediaType:
+// the q parameter of the most specific media range matching it, 0 if none does.
+// Every media type is acceptable when accept is empty.
+func acceptQuality(accept, mediaType string) float64 {
+	if strings.TrimSpace(accept) == "" {
+		return 1
+	}
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, -1
+	for _, r := range strings.Split(accept, ",") {
+		rangeType, params, err := mime.ParseMediaType(r)
+		if err != nil {
+			continue
+		}
+		s := -1
+		switch rangeType {
+		case mediaType:
+			s = 2
+		case mainType + "/*":
+			s = 1
+		case "*/*":
+			s = 0
+		}
+		if s <= specificity {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		quality, specificity = q, s
+	}
+	return quality
+}
+
+// negotiate returns the offer the Accept header value accept gives the highest quality to,
+// the earliest one on ties, or false if it accepts none of them.
+func negotiate(accept string, offers ...string) (string, bool) {
+	best, quality := "", 0.0
+	for _, offer := range offers {
+		if q := acceptQuality(accept, offer); q > quality {
+			best, quality = offer, q
+		}
+	}
+	return best, quality > 0
+}
+
+// varyAccept adds Accept to the Vary header of h, unless it is already there.
+func varyAccept(h http.Header) {
+	if !slices.Contains(h.Values("Vary"), "Accept") {
+		h.Add("Vary", "Accept")
+	}
+}
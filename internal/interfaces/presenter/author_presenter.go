@@ -0,0 +1,28 @@
+package presenter
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// AuthorPresenter prepares a domain.Author to be returned to an http interface.
+type AuthorPresenter struct {
+	logger *slog.Logger
+}
+
+// NewAuthorPresenter creates a new instance of AuthorPresenter.
+func NewAuthorPresenter(logger *slog.Logger) *AuthorPresenter {
+	return &AuthorPresenter{logger: logger}
+}
+
+// Present returns the map representation of a domain.Author.
+// The author ID is prefixed with the resource type (author:).
+func (p *AuthorPresenter) Present(author *domain.Author) map[string]any {
+	return map[string]any{
+		"id":         domain.AuthorResource.Format(author.ID),
+		"name":       author.Name,
+		"created_at": author.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -4,9 +4,12 @@ package presenter
 
 import (
 	"log/slog"
+	"strconv"
 
 	"golang.org/x/text/cases"
+	"golang.org/x/text/currency"
 	"golang.org/x/text/language"
+	"golang.org/x/text/message"
 
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 )
@@ -24,13 +27,194 @@ func NewBookPresenter(logger *slog.Logger) *BookPresenter {
 // Present returns the map representation of a domain.Book.
 // Present prefixes the book ID with the resource type (book:) and
 // transform the title to Title Case based on the book language.
-func (p *BookPresenter) Present(book *domain.Book) map[string]any {
+// The price is kept in minor units for machines, next to its currency
+// and an amount formatted for humans in the book language.
+// Credited authors are embedded as summaries, in display order; the ISBN is null when unknown.
+// Trashed books also carry the time they were deleted.
+// Given the languages a client prefers, e.g. from its Accept-Language header, the book also carries
+// how well its language matches them: exact, close (e.g. en-US for en) or none.
+func (p *BookPresenter) Present(book *domain.Book, preferred ...language.Tag) map[string]any {
+	return p.present(book, newLanguagePreferences(preferred))
+}
+
+func (p *BookPresenter) present(book *domain.Book, preferences *languagePreferences) map[string]any {
+	authors := make([]map[string]any, len(book.Authors))
+	for i, c := range book.Authors {
+		authors[i] = map[string]any{
+			"id":   domain.AuthorResource.Format(c.AuthorID),
+			"name": c.Name,
+			"role": c.Role,
+		}
+	}
+	var isbn any
+	if book.ISBN != "" {
+		isbn = book.ISBN
+	}
+	view := map[string]any{
+		"id":              domain.BookResource.Format(book.ID),
+		"isbn":            isbn,
+		"title":           p.title(book),
+		"author":          book.Author,
+		"authors":         authors,
+		"language":        book.LanguageTag,
+		"price":           book.Price.Amount,
+		"currency":        book.Price.Currency,
+		"formatted_price": p.formatPrice(book),
+	}
+	if book.IsDeleted() {
+		view["deleted_at"] = book.DeletedAt
+	}
+	if preferences != nil {
+		view["language_match"] = preferences.match(book.LanguageTag)
+	}
+	return view
+}
+
+// PresentPage returns the map representation of a domain.BookPage.
+// Each book is presented with Present, alongside the cursor of the next page
+// (null on the last page) and the total number of matching books.
+func (p *BookPresenter) PresentPage(page *domain.BookPage, preferred ...language.Tag) map[string]any {
+	preferences := newLanguagePreferences(preferred)
+	books := make([]map[string]any, len(page.Books))
+	for i, book := range page.Books {
+		books[i] = p.present(book, preferences)
+	}
+
+	var next any
+	if page.NextCursor != "" {
+		next = page.NextCursor
+	}
+	return map[string]any{
+		"books":       books,
+		"next_cursor": next,
+		"total":       page.Total,
+	}
+}
+
+// PresentHistory returns the map representation of a domain.AuditPage.
+// Each entry lists the changed fields with their value before and after the change,
+// alongside the cursor of the next page (null on the last page) and the total number of entries.
+func (p *BookPresenter) PresentHistory(page *domain.AuditPage) map[string]any {
+	entries := make([]map[string]any, len(page.Entries))
+	for i, e := range page.Entries {
+		changes := make([]map[string]any, len(e.Changes))
+		for j, c := range e.Changes {
+			changes[j] = map[string]any{
+				"field":  c.Field,
+				"before": c.Before,
+				"after":  c.After,
+			}
+		}
+		entries[i] = map[string]any{
+			"id":        domain.AuditResource.Format(e.ID),
+			"book_id":   domain.BookResource.Format(e.BookID),
+			"at":        e.At,
+			"actor":     e.Actor,
+			"operation": e.Operation,
+			"version":   e.Version,
+			"changes":   changes,
+		}
+	}
+
+	var next any
+	if page.NextCursor != "" {
+		next = page.NextCursor
+	}
+	return map[string]any{
+		"entries":     entries,
+		"next_cursor": next,
+		"total":       page.Total,
+	}
+}
+
+// Import row statuses, see PresentImport.
+const (
+	importRowCreated = "created"
+	importRowValid   = "valid"
+	importRowSkipped = "skipped"
+	importRowFailed  = "failed"
+)
+
+// PresentImport returns the map representation of a domain.ImportReport.
+// Each row has its line and status: failed rows carry their error, created rows the ID of their book.
+// Valid rows are reported valid by dry runs, and skipped by rejected atomic imports.
+func (p *BookPresenter) PresentImport(report *domain.ImportReport) map[string]any {
+	rows := make([]map[string]any, len(report.Rows))
+	for i, r := range report.Rows {
+		var id, errMsg any
+		status := importRowCreated
+		switch {
+		case r.Err != nil:
+			status, errMsg = importRowFailed, r.Err.Error()
+		case report.Options.DryRun:
+			status = importRowValid
+		case report.Rejected():
+			status = importRowSkipped
+		default:
+			id = domain.BookResource.Format(r.Book.ID)
+		}
+		rows[i] = map[string]any{
+			"line":   r.Line,
+			"status": status,
+			"id":     id,
+			"error":  errMsg,
+		}
+	}
 	return map[string]any{
-		"id":     "book:" + book.ID.String(),
-		"title":  p.title(book),
-		"author": book.Author,
-		"price":  book.Price,
+		"dry_run": report.Options.DryRun,
+		"atomic":  report.Options.Atomic,
+		"created": report.Created,
+		"failed":  report.Failed,
+		"rows":    rows,
+	}
+}
+
+// PresentExport returns the map representation of a domain.ExportedBook.
+// The book is presented with Present, plus the time it was created, its version,
+// and the time it was deleted, null for books in the catalog.
+// Books exported with their audit summary also carry the actors who created and last changed them,
+// when they were last changed (null without history) and their number of changes.
+func (p *BookPresenter) PresentExport(exported *domain.ExportedBook) map[string]any {
+	view := p.Present(exported.Book)
+	view["created_at"] = exported.Book.CreatedAt
+	view["version"] = exported.Book.Version
+	if !exported.Book.IsDeleted() {
+		view["deleted_at"] = nil
+	}
+	if a := exported.Audit; a != nil {
+		var updatedAt any
+		if !a.UpdatedAt.IsZero() {
+			updatedAt = a.UpdatedAt
+		}
+		view["created_by"] = a.CreatedBy
+		view["updated_by"] = a.UpdatedBy
+		view["updated_at"] = updatedAt
+		view["changes"] = a.Changes
+	}
+	return view
+}
+
+// formatPrice returns the book price with its currency symbol, e.g. "€ 12.99",
+// using the number format of the book language.
+func (p *BookPresenter) formatPrice(book *domain.Book) string {
+	unit, err := currency.ParseISO(book.Price.Currency)
+	if err != nil {
+		p.logger.
+			With("book_id", book.ID, "book_currency", book.Price.Currency).
+			Warn("failed to parse currency")
+		return strconv.FormatInt(book.Price.Amount, 10)
+	}
+	lt, err := language.Parse(book.LanguageTag)
+	if err != nil {
+		lt = language.English
+	}
+
+	amount := float64(book.Price.Amount)
+	scale, _ := currency.Standard.Rounding(unit)
+	for range scale {
+		amount /= 10
 	}
+	return message.NewPrinter(lt).Sprint(currency.Symbol(unit.Amount(amount)))
 }
 
 func (p *BookPresenter) title(book *domain.Book) string {
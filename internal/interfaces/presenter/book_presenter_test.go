@@ -0,0 +1,69 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestBookPresenter_PresentAuthors(t *testing.T) {
+	p := presenter.NewBookPresenter(testlog.NewTestLogger())
+	authorID, translatorID := uuid.New(), uuid.New()
+	book := &domain.Book{
+		Title:       "il nome della rosa",
+		Author:      "Umberto Eco",
+		LanguageTag: "it",
+		Price:       domain.Money{Amount: 1000, Currency: "EUR"},
+		Authors: []domain.BookAuthor{
+			{AuthorID: authorID, Name: "Umberto Eco", Role: domain.RoleAuthor},
+			{AuthorID: translatorID, Name: "William Weaver", Role: domain.RoleTranslator},
+		},
+	}
+
+	got, err := json.Marshal(p.Present(book)["authors"])
+	if err != nil {
+		t.Fatalf("error encoding authors: %v", err)
+	}
+	want := `[{"id":"author:` + authorID.String() + `","name":"Umberto Eco","role":"author"},` +
+		`{"id":"author:` + translatorID.String() + `","name":"William Weaver","role":"translator"}]`
+	if string(got) != want {
+		t.Errorf("want %s, got %s", want, got)
+	}
+}
+
+func TestBookPresenter_PresentLanguageMatch(t *testing.T) {
+	p := presenter.NewBookPresenter(testlog.NewTestLogger())
+	tests := []struct {
+		name      string
+		bookTag   string
+		want      any
+		preferred []language.Tag
+	}{
+		{name: "no preference", bookTag: "en"},
+		{name: "exact", bookTag: "it", preferred: []language.Tag{language.English, language.Italian}, want: "exact"},
+		{name: "region of a preferred one", bookTag: "en-US", preferred: []language.Tag{language.English}, want: "close"},
+		{
+			name: "base of a preferred language", bookTag: "en", preferred: []language.Tag{language.AmericanEnglish},
+			want: "close",
+		},
+		{name: "no match", bookTag: "de", preferred: []language.Tag{language.Italian}, want: "none"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			book := &domain.Book{Title: "a book", LanguageTag: tt.bookTag, Price: domain.Money{Currency: "EUR"}}
+			if got := p.Present(book, tt.preferred...)["language_match"]; got != tt.want {
+				t.Errorf("want language_match %v, got %v", tt.want, got)
+			}
+			page := p.PresentPage(&domain.BookPage{Books: []*domain.Book{book}}, tt.preferred...)
+			if got := page["books"].([]map[string]any)[0]["language_match"]; got != tt.want {
+				t.Errorf("want page language_match %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package presenter
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Media types views can be encoded in, besides JSON.
+const (
+	xmlContentType         = "application/xml"
+	textXMLContentType     = "text/xml"
+	csvContentType         = "text/csv"
+	msgpackContentType     = "application/msgpack"
+	xMsgpackContentType    = "application/x-msgpack"
+	problemXMLContentType  = "application/problem+xml"
+	problemXMLNamespace    = "urn:ietf:rfc:7807"
+	xmlSchemaInstanceSpace = "http://www.w3.org/2001/XMLSchema-instance"
+)
+
+// generic returns the value view is encoded as in JSON, made of map[string]any, []any, string, bool,
+// int64, float64 and nil values, so that every encoding shares the field names and formats of JSON.
+func generic(view any) (any, error) {
+	data, err := json.Marshal(view)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err = dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return numbers(v), nil
+}
+
+// numbers replaces the json.Number values of v with int64, or float64 if they are not integers.
+func numbers(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, e := range v {
+			v[k] = numbers(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = numbers(e)
+		}
+	}
+	return v
+}
+
+// text returns the text form of a generic scalar; nested values are written as JSON.
+func text(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
+
+// encodeMsgpack writes the generic value v as MessagePack, with sorted map keys and integers in their smallest form.
+func encodeMsgpack(w io.Writer, v any) error {
+	enc := msgpack.NewEncoder(w)
+	enc.SetSortMapKeys(true)
+	enc.UseCompactInts(true)
+	return enc.Encode(v)
+}
+
+// encodeXML writes the generic value v as the root element, with objects as elements named after their fields,
+// array items as item elements, and null values as elements with xsi:nil set.
+// namespace is the default namespace of the document, if any.
+func encodeXML(w io.Writer, root, namespace, item string, v any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	attrs := []xml.Attr{{Name: xml.Name{Local: "xmlns:xsi"}, Value: xmlSchemaInstanceSpace}}
+	if namespace != "" {
+		attrs = append([]xml.Attr{{Name: xml.Name{Local: "xmlns"}, Value: namespace}}, attrs...)
+	}
+	if err := writeXML(enc, xml.StartElement{Name: xml.Name{Local: root}, Attr: attrs}, item, v); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+func writeXML(enc *xml.Encoder, start xml.StartElement, item string, v any) error {
+	switch v := v.(type) {
+	case nil:
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "xsi:nil"}, Value: "true"})
+		return enc.EncodeElement("", start)
+	case map[string]any:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, k := range slices.Sorted(maps.Keys(v)) {
+			if err := writeXML(enc, xml.StartElement{Name: xml.Name{Local: k}}, item, v[k]); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	case []any:
+		if err := enc.EncodeToken(start); err != nil {
+			return err
+		}
+		for _, e := range v {
+			if err := writeXML(enc, xml.StartElement{Name: xml.Name{Local: item}}, item, e); err != nil {
+				return err
+			}
+		}
+		return enc.EncodeToken(start.End())
+	default:
+		return enc.EncodeElement(text(v), start)
+	}
+}
+
+// list returns the objects of the generic value v held by its field named items, along with its other fields,
+// or v itself if items is empty. It returns false if they are not an array of objects.
+func list(v any, items string) ([]map[string]any, map[string]any, bool) {
+	var envelope map[string]any
+	if items != "" {
+		object, ok := v.(map[string]any)
+		if !ok {
+			return nil, nil, false
+		}
+		envelope = maps.Clone(object)
+		delete(envelope, items)
+		v = object[items]
+	}
+	a, ok := v.([]any)
+	if !ok {
+		return nil, nil, false
+	}
+	objects := make([]map[string]any, len(a))
+	for i, e := range a {
+		if objects[i], ok = e.(map[string]any); !ok {
+			return nil, nil, false
+		}
+	}
+	return objects, envelope, true
+}
+
+// encodeCSV writes the items of a list as CSV, with a header row naming their fields in alphabetical order.
+// The other fields of the list are set as X- headers of h, e.g. next_cursor as X-Next-Cursor.
+func encodeCSV(w io.Writer, h http.Header, items []map[string]any, envelope map[string]any) error {
+	for k, v := range envelope {
+		if v != nil {
+			h.Set("X-"+strings.ReplaceAll(k, "_", "-"), text(v))
+		}
+	}
+
+	columns := map[string]bool{}
+	for _, item := range items {
+		for k := range item {
+			columns[k] = true
+		}
+	}
+	header := slices.Sorted(maps.Keys(columns))
+	cw := csv.NewWriter(w)
+	if len(header) > 0 {
+		if err := cw.Write(header); err != nil {
+			return err
+		}
+	}
+	record := make([]string, len(header))
+	for _, item := range items {
+		for i, c := range header {
+			record[i] = text(item[c])
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -3,14 +3,28 @@
 package presenter
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 )
 
+// errInternal is the message internal errors are reported with, hiding their details.
+const errInternal = "internal server error"
+
+// Media types of error responses.
+const (
+	problemContentType = "application/problem+json"
+	legacyContentType  = "application/json"
+)
+
+// problemTypePrefix prefixes the code of a domain error in the URI of its problem type.
+const problemTypePrefix = "urn:bookshop:problem:"
+
 // ErrorPresenter prepares an error to be returned to an http interface.
 type ErrorPresenter struct {
 	logger *slog.Logger
@@ -21,43 +35,122 @@ func NewErrorPresenter(logger *slog.Logger) *ErrorPresenter {
 	return &ErrorPresenter{logger: logger}
 }
 
-// Present writes the JSON representation of the error directly to w.
-// Additionally, it writes the correct error code to w.
+// Present writes err to w as an RFC 9457 problem details object, along with its status code.
+// The problem is encoded as XML for clients preferring application/problem+xml or application/xml,
+// and clients preferring application/json get the legacy {"status","message"} object instead.
 // Internal errors are caught and replaced with a default message.
 // If the error is one of the known types, the code is overwritten with the correct one.
-func (p *ErrorPresenter) Present(w http.ResponseWriter, err error, code int) {
-	switch {
-	case err == nil:
+func (p *ErrorPresenter) Present(w http.ResponseWriter, r *http.Request, err error, code int) {
+	if err == nil {
 		return
-	case errors.Is(err, domain.ErrBookNotFound):
-		code = http.StatusNotFound
-	case errors.Is(err, domain.ErrInvalidBookID):
-		code = http.StatusBadRequest
-	case code == http.StatusInternalServerError:
-		p.handleInternalError(w, err)
+	}
+	code, msg := p.Status(err, code)
+	varyAccept(w.Header())
+	contentType, ok := negotiate(r.Header.Get("Accept"), problemContentType, problemXMLContentType,
+		legacyContentType, xmlContentType, textXMLContentType)
+	if !ok {
+		contentType = problemContentType
+	}
+	if contentType == legacyContentType {
+		w.Header().Set("Content-Type", legacyContentType)
+		w.WriteHeader(code)
+		p.encode(w, ErrorView{Message: msg, Status: http.StatusText(code)})
 		return
-	case http.StatusText(code) != "":
-	default:
-		p.handleInternalError(w, err)
+	}
+
+	problem := ProblemView{
+		Type:     "about:blank",
+		Title:    http.StatusText(code),
+		Status:   code,
+		Detail:   msg,
+		Instance: r.URL.Path,
+		Code:     p.Code(err, code),
+	}
+	var domainErr *domain.Error
+	if code != http.StatusInternalServerError && errors.As(err, &domainErr) {
+		problem.Type = problemTypePrefix + domainErr.Code
+		problem.Title = domainErr.Error()
+	}
+	var validationErr *domain.ValidationError
+	if errors.As(err, &validationErr) {
+		for _, f := range validationErr.Fields {
+			problem.Errors = append(problem.Errors, ProblemFieldView{Field: f.Field, Detail: f.Detail})
+		}
+	}
+	if contentType != problemContentType {
+		p.encodeXML(w, problem)
 		return
 	}
+	w.Header().Set("Content-Type", problemContentType)
 	w.WriteHeader(code)
-	err = json.NewEncoder(w).Encode(map[string]any{
-		"status":  http.StatusText(code),
-		"message": err.Error(),
-	})
-	if err != nil {
+	p.encode(w, problem)
+}
+
+// Code returns the stable code a non nil err is reported with under status:
+// the code of domain errors, or the upper snake case of the status text otherwise, e.g. NOT_FOUND.
+// Internal errors are always INTERNAL_SERVER_ERROR.
+func (p *ErrorPresenter) Code(err error, status int) string {
+	var domainErr *domain.Error
+	if status != http.StatusInternalServerError && errors.As(err, &domainErr) {
+		return domainErr.Code
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+// Status returns the HTTP status code of a non nil err, and the message it can be reported with.
+// Known error types have their own code, any other error keeps code.
+// Internal errors, and errors with an unknown code, are reported as
+// http.StatusInternalServerError with a default message.
+func (p *ErrorPresenter) Status(err error, code int) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrBookNotFound), errors.Is(err, domain.ErrOrderNotFound),
+		errors.Is(err, domain.ErrAuthorNotFound), errors.Is(err, domain.ErrWebhookNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, domain.ErrValidation), errors.Is(err, domain.ErrInvalidBookID),
+		errors.Is(err, domain.ErrInvalidCursor), errors.Is(err, domain.ErrInvalidQuantity),
+		errors.Is(err, domain.ErrInvalidOrderID),
+		errors.Is(err, domain.ErrEmptyOrder), errors.Is(err, domain.ErrInvalidAuthorID),
+		errors.Is(err, domain.ErrInvalidISBN), errors.Is(err, domain.ErrInvalidWebhookID),
+		errors.Is(err, domain.ErrInvalidID), errors.Is(err, domain.ErrWrongResourceType):
+		code = http.StatusBadRequest
+	case errors.Is(err, domain.ErrVersionConflict):
+		code = http.StatusPreconditionFailed
+	case errors.Is(err, domain.ErrInsufficientStock), errors.Is(err, domain.ErrIllegalOrderTransition),
+		errors.Is(err, domain.ErrAuthorHasBooks), errors.Is(err, domain.ErrDuplicateISBN):
+		code = http.StatusConflict
+	case errors.Is(err, domain.ErrCurrencyMismatch):
+		code = http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrTooManyImportRows):
+		code = http.StatusRequestEntityTooLarge
+	case code == http.StatusInternalServerError, http.StatusText(code) == "":
+		return http.StatusInternalServerError, errInternal
+	}
+	return code, err.Error()
+}
+
+func (p *ErrorPresenter) encode(w http.ResponseWriter, v any) {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
 		p.logger.With("error", err).Error("failed to write error response")
 	}
 }
 
-func (p *ErrorPresenter) handleInternalError(w http.ResponseWriter, err error) {
-	w.WriteHeader(http.StatusInternalServerError)
-	err = json.NewEncoder(w).Encode(map[string]any{
-		"status":  http.StatusText(http.StatusInternalServerError),
-		"message": "internal server error",
-	})
+// encodeXML writes problem as an application/problem+xml document, as described by RFC 9457 appendix B.
+func (p *ErrorPresenter) encodeXML(w http.ResponseWriter, problem ProblemView) {
+	var buf bytes.Buffer
+	v, err := generic(problem)
+	if err == nil {
+		err = encodeXML(&buf, "problem", problemXMLNamespace, "i", v)
+	}
 	if err != nil {
+		p.logger.With("error", err).Error("failed to encode error response")
+		w.Header().Set("Content-Type", problemContentType)
+		w.WriteHeader(problem.Status)
+		p.encode(w, problem)
+		return
+	}
+	w.Header().Set("Content-Type", problemXMLContentType+"; charset=utf-8")
+	w.WriteHeader(problem.Status)
+	if _, err = w.Write(buf.Bytes()); err != nil {
 		p.logger.With("error", err).Error("failed to write error response")
 	}
 }
@@ -1,7 +1,9 @@
 package presenter_test
 
 import (
+	"encoding/xml"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,7 +14,7 @@ import (
 	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
 )
 
-func TestErrorPresenter_Present(t *testing.T) {
+func TestErrorPresenter_Present_Legacy(t *testing.T) {
 	logger := testlog.NewTestLogger()
 	tests := []struct {
 		name   string
@@ -62,6 +64,262 @@ func TestErrorPresenter_Present(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "overwrites status code for domain error ErrInvalidQuantity",
+			err:  domain.ErrInvalidQuantity,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusBadRequest
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid stock quantity","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrInsufficientStock",
+			err:  domain.ErrInsufficientStock,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusConflict
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"insufficient stock","status":"Conflict"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrOrderNotFound",
+			err:  domain.ErrOrderNotFound,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusNotFound
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"order not found","status":"Not Found"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrEmptyOrder",
+			err:  domain.ErrEmptyOrder,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusBadRequest
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"order has no items","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrIllegalOrderTransition",
+			err:  domain.ErrIllegalOrderTransition,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusConflict
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"illegal order status transition","status":"Conflict"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrCurrencyMismatch",
+			err:  domain.ErrCurrencyMismatch,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusUnprocessableEntity
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"order items have different currencies","status":"Unprocessable Entity"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrTooManyImportRows",
+			err:  domain.ErrTooManyImportRows,
+			code: http.StatusBadRequest,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusRequestEntityTooLarge
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"too many rows to import","status":"Request Entity Too Large"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrAuthorNotFound",
+			err:  domain.ErrAuthorNotFound,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusNotFound
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"author not found","status":"Not Found"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrInvalidAuthorID",
+			err:  domain.ErrInvalidAuthorID,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusBadRequest
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid author id","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrAuthorHasBooks",
+			err:  domain.ErrAuthorHasBooks,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusConflict
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"author still has books","status":"Conflict"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrWebhookNotFound",
+			err:  domain.ErrWebhookNotFound,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusNotFound
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"webhook not found","status":"Not Found"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrInvalidWebhookID",
+			err:  domain.ErrInvalidWebhookID,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusBadRequest
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid webhook id","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrInvalidISBN",
+			err:  domain.ErrInvalidISBN,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusBadRequest
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid isbn","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrDuplicateISBN",
+			err:  domain.ErrDuplicateISBN,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusConflict
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"isbn already exists","status":"Conflict"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrInvalidCursor",
+			err:  domain.ErrInvalidCursor,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusBadRequest
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"invalid cursor","status":"Bad Request"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
+		{
+			name: "overwrites status code for domain error ErrVersionConflict",
+			err:  domain.ErrVersionConflict,
+			code: http.StatusInternalServerError,
+			expect: func(res *httptest.ResponseRecorder) {
+				wantCode := http.StatusPreconditionFailed
+				if res.Code != wantCode {
+					t.Errorf("expected response code to be %d, got %d", wantCode, res.Code)
+				}
+				got := strings.TrimSpace(res.Body.String())
+				want := `{"message":"book version conflict","status":"Precondition Failed"}`
+				if got != want {
+					t.Errorf("want %s, got %s", want, got)
+				}
+			},
+		},
 		{
 			name: "redacts internal server errors",
 			err:  errors.New("sensitive implementation data"),
@@ -113,11 +371,228 @@ func TestErrorPresenter_Present(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(_ *testing.T) {
 			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/books", http.NoBody)
+			r.Header.Set("Accept", "application/json")
 			p := presenter.NewErrorPresenter(logger)
-			p.Present(w, tt.err, tt.code)
+			p.Present(w, r, tt.err, tt.code)
 			if tt.expect != nil {
 				tt.expect(w)
 			}
 		})
 	}
 }
+
+func TestErrorPresenter_Status(t *testing.T) {
+	p := presenter.NewErrorPresenter(testlog.NewTestLogger())
+	tests := []struct {
+		name     string
+		err      error
+		wantMsg  string
+		code     int
+		wantCode int
+	}{
+		{
+			name:     "overwrites status code for domain errors",
+			err:      domain.ErrVersionConflict,
+			code:     http.StatusInternalServerError,
+			wantCode: http.StatusPreconditionFailed,
+			wantMsg:  domain.ErrVersionConflict.Error(),
+		},
+		{
+			name:     "keeps status code of other errors",
+			err:      errors.New("title is required"),
+			code:     http.StatusBadRequest,
+			wantCode: http.StatusBadRequest,
+			wantMsg:  "title is required",
+		},
+		{
+			name:     "hides internal errors",
+			err:      errors.New("disk on fire"),
+			code:     http.StatusInternalServerError,
+			wantCode: http.StatusInternalServerError,
+			wantMsg:  "internal server error",
+		},
+		{
+			name:     "hides errors with unknown status code",
+			err:      errors.New("disk on fire"),
+			code:     999,
+			wantCode: http.StatusInternalServerError,
+			wantMsg:  "internal server error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, msg := p.Status(tt.err, tt.code)
+			if code != tt.wantCode || msg != tt.wantMsg {
+				t.Errorf("want %d %q, got %d %q", tt.wantCode, tt.wantMsg, code, msg)
+			}
+		})
+	}
+}
+
+func TestErrorPresenter_Present_Problem(t *testing.T) {
+	p := presenter.NewErrorPresenter(testlog.NewTestLogger())
+	validation := &domain.ValidationError{}
+	validation.Add("title", "title is required")
+	validation.Add("authors[0].role", "authors[0]: role must be one of author, translator, illustrator")
+	tests := []struct {
+		name            string
+		accept          string
+		err             error
+		wantContentType string
+		want            string
+		code            int
+		wantCode        int
+	}{
+		{
+			name:            "presents domain errors with their code",
+			err:             fmt.Errorf("reading book: %w", domain.ErrBookNotFound),
+			code:            http.StatusInternalServerError,
+			wantCode:        http.StatusNotFound,
+			wantContentType: "application/problem+json",
+			want: `{"type":"urn:bookshop:problem:BOOK_NOT_FOUND","title":"book not found",` +
+				`"detail":"reading book: book not found","instance":"/v1/books/42","code":"BOOK_NOT_FOUND","status":404}`,
+		},
+		{
+			name:            "lists the fields that failed validation",
+			err:             validation,
+			code:            http.StatusBadRequest,
+			wantCode:        http.StatusBadRequest,
+			wantContentType: "application/problem+json",
+			want: `{"type":"urn:bookshop:problem:VALIDATION_FAILED","title":"validation failed",` +
+				`"detail":"title is required; authors[0]: role must be one of author, translator, illustrator",` +
+				`"instance":"/v1/books/42","code":"VALIDATION_FAILED","errors":[` +
+				`{"field":"title","detail":"title is required"},` +
+				`{"field":"authors[0].role","detail":"authors[0]: role must be one of author, translator, illustrator"}` +
+				`],"status":400}`,
+		},
+		{
+			name:            "derives the code of other errors from their status",
+			accept:          "*/*",
+			err:             errors.New("book id is required"),
+			code:            http.StatusBadRequest,
+			wantCode:        http.StatusBadRequest,
+			wantContentType: "application/problem+json",
+			want: `{"type":"about:blank","title":"Bad Request","detail":"book id is required",` +
+				`"instance":"/v1/books/42","code":"BAD_REQUEST","status":400}`,
+		},
+		{
+			name:            "hides internal errors",
+			accept:          "application/problem+json, application/json",
+			err:             errors.New("disk on fire"),
+			code:            http.StatusInternalServerError,
+			wantCode:        http.StatusInternalServerError,
+			wantContentType: "application/problem+json",
+			want: `{"type":"about:blank","title":"Internal Server Error","detail":"internal server error",` +
+				`"instance":"/v1/books/42","code":"INTERNAL_SERVER_ERROR","status":500}`,
+		},
+		{
+			name:            "presents the legacy format to clients preferring it",
+			accept:          "application/problem+json;q=0.5, application/json",
+			err:             domain.ErrBookNotFound,
+			code:            http.StatusInternalServerError,
+			wantCode:        http.StatusNotFound,
+			wantContentType: "application/json",
+			want:            `{"message":"book not found","status":"Not Found"}`,
+		},
+		{
+			name:            "presents problems as XML to clients preferring it",
+			accept:          "application/problem+xml",
+			err:             validation,
+			code:            http.StatusBadRequest,
+			wantCode:        http.StatusBadRequest,
+			wantContentType: "application/problem+xml; charset=utf-8",
+			want: xml.Header + `<problem xmlns="urn:ietf:rfc:7807" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+				`<code>VALIDATION_FAILED</code>` +
+				`<detail>title is required; authors[0]: role must be one of author, translator, illustrator</detail>` +
+				`<errors><i><detail>title is required</detail><field>title</field></i>` +
+				`<i><detail>authors[0]: role must be one of author, translator, illustrator</detail>` +
+				`<field>authors[0].role</field></i></errors>` +
+				`<instance>/v1/books/42</instance><status>400</status><title>validation failed</title>` +
+				`<type>urn:bookshop:problem:VALIDATION_FAILED</type></problem>`,
+		},
+		{
+			name:            "presents problems as XML to clients accepting XML",
+			accept:          "text/xml",
+			err:             domain.ErrBookNotFound,
+			code:            http.StatusInternalServerError,
+			wantCode:        http.StatusNotFound,
+			wantContentType: "application/problem+xml; charset=utf-8",
+			want: xml.Header + `<problem xmlns="urn:ietf:rfc:7807" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+				`<code>BOOK_NOT_FOUND</code><detail>book not found</detail><instance>/v1/books/42</instance>` +
+				`<status>404</status><title>book not found</title><type>urn:bookshop:problem:BOOK_NOT_FOUND</type></problem>`,
+		},
+		{
+			name:            "presents problems to clients accepting no error format",
+			accept:          "image/png",
+			err:             domain.ErrBookNotFound,
+			code:            http.StatusInternalServerError,
+			wantCode:        http.StatusNotFound,
+			wantContentType: "application/problem+json",
+			want: `{"type":"urn:bookshop:problem:BOOK_NOT_FOUND","title":"book not found",` +
+				`"detail":"book not found","instance":"/v1/books/42","code":"BOOK_NOT_FOUND","status":404}`,
+		},
+		{
+			name:            "presents problems to clients accepting any application type",
+			accept:          "application/*",
+			err:             domain.ErrBookNotFound,
+			code:            http.StatusInternalServerError,
+			wantCode:        http.StatusNotFound,
+			wantContentType: "application/problem+json",
+			want: `{"type":"urn:bookshop:problem:BOOK_NOT_FOUND","title":"book not found",` +
+				`"detail":"book not found","instance":"/v1/books/42","code":"BOOK_NOT_FOUND","status":404}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/books/42?lang=en", http.NoBody)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			p.Present(w, r, tt.err, tt.code)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("want status %d, got %d", tt.wantCode, w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("want Content-Type %q, got %q", tt.wantContentType, got)
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestErrorPresenter_Code(t *testing.T) {
+	p := presenter.NewErrorPresenter(testlog.NewTestLogger())
+	tests := []struct {
+		name   string
+		err    error
+		want   string
+		status int
+	}{
+		{name: "domain error", err: domain.ErrDuplicateISBN, status: http.StatusConflict, want: "DUPLICATE_ISBN"},
+		{
+			name:   "wrapped validation error",
+			err:    fmt.Errorf("row 3: %w", domain.NewFieldError("price", "price must be greater than zero")),
+			status: http.StatusBadRequest,
+			want:   "VALIDATION_FAILED",
+		},
+		{name: "other error", err: errors.New("bad"), status: http.StatusNotAcceptable, want: "NOT_ACCEPTABLE"},
+		{
+			name:   "internal error",
+			err:    domain.ErrBookNotFound,
+			status: http.StatusInternalServerError,
+			want:   "INTERNAL_SERVER_ERROR",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Code(tt.err, tt.status); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package presenter
+
+import "golang.org/x/text/language"
+
+// Values of the language_match field of a book.
+const (
+	languageMatchExact = "exact"
+	languageMatchClose = "close"
+	languageMatchNone  = "none"
+)
+
+// languagePreferences matches book languages against the languages a client prefers.
+type languagePreferences struct {
+	matcher   language.Matcher
+	preferred []language.Tag
+}
+
+// newLanguagePreferences returns the languagePreferences of the preferred languages, in order of preference,
+// or nil if there are none.
+func newLanguagePreferences(preferred []language.Tag) *languagePreferences {
+	if len(preferred) == 0 {
+		return nil
+	}
+	return &languagePreferences{matcher: language.NewMatcher(preferred), preferred: preferred}
+}
+
+// match reports how well the BCP 47 tag matches the preferred languages: exactly, closely, e.g. by base
+// language (en-US for en) or a mutually intelligible one, or not at all.
+func (l *languagePreferences) match(tag string) string {
+	t, err := language.Parse(tag)
+	if err != nil {
+		return languageMatchNone
+	}
+	_, i, confidence := l.matcher.Match(t)
+	switch {
+	case confidence == language.No:
+		return languageMatchNone
+	case l.preferred[i] == t:
+		return languageMatchExact
+	default:
+		return languageMatchClose
+	}
+}
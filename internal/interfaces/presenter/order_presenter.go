@@ -0,0 +1,44 @@
+package presenter
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// OrderPresenter prepares a domain.Order to be returned to an http interface.
+type OrderPresenter struct {
+	logger *slog.Logger
+}
+
+// NewOrderPresenter creates a new instance of OrderPresenter.
+func NewOrderPresenter(logger *slog.Logger) *OrderPresenter {
+	return &OrderPresenter{logger: logger}
+}
+
+// Present returns the map representation of a domain.Order.
+// IDs are prefixed with their resource type (order:, book:) and
+// amounts are kept in minor units of the order currency.
+func (p *OrderPresenter) Present(order *domain.Order) map[string]any {
+	items := make([]map[string]any, len(order.Items))
+	for i := range order.Items {
+		item := &order.Items[i]
+		items[i] = map[string]any{
+			"book_id":    domain.BookResource.Format(item.BookID),
+			"title":      item.Title,
+			"quantity":   item.Quantity,
+			"unit_price": item.UnitPrice.Amount,
+			"subtotal":   item.Subtotal().Amount,
+		}
+	}
+	return map[string]any{
+		"id":         domain.OrderResource.Format(order.ID),
+		"status":     order.Status,
+		"items":      items,
+		"total":      order.Total.Amount,
+		"currency":   order.Total.Currency,
+		"created_at": order.CreatedAt.Format(time.RFC3339),
+		"updated_at": order.UpdatedAt.Format(time.RFC3339),
+	}
+}
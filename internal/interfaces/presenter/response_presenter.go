@@ -0,0 +1,101 @@
+package presenter
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// errNotAcceptable is reported when a request accepts none of the media types a view can be encoded in.
+var errNotAcceptable = errors.New("not acceptable")
+
+// ResponsePresenter writes view models to an http interface, in the media type the request accepts:
+// JSON, XML, MessagePack, or CSV for lists.
+type ResponsePresenter struct {
+	logger       *slog.Logger
+	errPresenter *ErrorPresenter
+}
+
+// NewResponsePresenter creates a new instance of ResponsePresenter.
+// Requests accepting none of the supported media types are answered through errPresenter.
+func NewResponsePresenter(logger *slog.Logger, errPresenter *ErrorPresenter) *ResponsePresenter {
+	return &ResponsePresenter{logger: logger, errPresenter: errPresenter}
+}
+
+// Present writes view to w with status, encoded in the media type the Accept header of r prefers,
+// JSON if it has no preference:
+//   - application/json: view as it is encoded by encoding/json.
+//   - application/xml or text/xml: a response element holding the fields of view as elements,
+//     and the items of arrays as item elements.
+//   - application/msgpack or application/x-msgpack: view as a MessagePack map.
+//
+// Every encoding uses the field names and formats of JSON.
+// Requests accepting none of these are answered with http.StatusNotAcceptable.
+func (p *ResponsePresenter) Present(w http.ResponseWriter, r *http.Request, status int, view any) {
+	p.present(w, r, status, view, "", false)
+}
+
+// PresentList writes view like Present, where view is a list of objects held by its field named items,
+// or view itself if items is empty. Lists can also be encoded as text/csv: a row per object,
+// with the other fields of view as X- headers.
+func (p *ResponsePresenter) PresentList(w http.ResponseWriter, r *http.Request, status int, view any, items string) {
+	p.present(w, r, status, view, items, true)
+}
+
+func (p *ResponsePresenter) present(
+	w http.ResponseWriter, r *http.Request, status int, view any, items string, isList bool,
+) {
+	varyAccept(w.Header())
+	v, err := generic(view)
+	if err != nil {
+		p.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	offers := []string{legacyContentType, xmlContentType, textXMLContentType}
+	if isList {
+		offers = append(offers, csvContentType)
+	}
+	offers = append(offers, msgpackContentType, xMsgpackContentType)
+	contentType, ok := negotiate(r.Header.Get("Accept"), offers...)
+	if !ok {
+		p.errPresenter.Present(w, r, fmt.Errorf("%w: supported media types are %s",
+			errNotAcceptable, strings.Join(offers, ", ")), http.StatusNotAcceptable)
+		return
+	}
+
+	var buf bytes.Buffer
+	switch contentType {
+	case legacyContentType:
+		err = json.NewEncoder(&buf).Encode(view)
+	case xmlContentType, textXMLContentType:
+		err = encodeXML(&buf, "response", "", "item", v)
+		contentType += "; charset=utf-8"
+	case csvContentType:
+		objects, envelope, ok := list(v, items)
+		if !ok {
+			err = fmt.Errorf("%s is not a list of objects", cmp.Or(items, "response"))
+			break
+		}
+		err = encodeCSV(&buf, w.Header(), objects, envelope)
+		contentType += "; charset=utf-8"
+	default:
+		err = encodeMsgpack(&buf, v)
+	}
+	if err != nil {
+		p.logger.With("error", err, "content_type", contentType).Error("failed to encode response")
+		p.errPresenter.Present(w, r, err, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	if _, err = w.Write(buf.Bytes()); err != nil {
+		p.logger.With("error", err).Error("failed to write response")
+	}
+}
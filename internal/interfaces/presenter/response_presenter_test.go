@@ -0,0 +1,167 @@
+package presenter_test
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+func TestResponsePresenter_Present(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	p := presenter.NewResponsePresenter(logger, presenter.NewErrorPresenter(logger))
+	book := map[string]any{"id": "42", "title": "Dune", "price": 9.5, "stock": 3, "tags": []string{"sf", "classic"}}
+	page := map[string]any{
+		"books": []map[string]any{
+			{"id": "42", "title": "Dune", "price": 9.5},
+			{"id": "43", "title": "Emma, a novel", "price": 12},
+		},
+		"next_cursor": "abc",
+		"prev_cursor": nil,
+	}
+	tests := []struct {
+		view            any
+		headers         map[string]string
+		name            string
+		accept          string
+		wantContentType string
+		want            string
+		items           string
+		status          int
+		wantStatus      int
+		list            bool
+	}{
+		{
+			name:            "encodes JSON by default",
+			view:            book,
+			status:          http.StatusCreated,
+			wantStatus:      http.StatusCreated,
+			wantContentType: "application/json",
+			want:            `{"id":"42","price":9.5,"stock":3,"tags":["sf","classic"],"title":"Dune"}`,
+		},
+		{
+			name:            "encodes XML",
+			accept:          "application/xml",
+			view:            page,
+			list:            true,
+			items:           "books",
+			status:          http.StatusOK,
+			wantStatus:      http.StatusOK,
+			wantContentType: "application/xml; charset=utf-8",
+			want: xml.Header + `<response xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><books>` +
+				`<item><id>42</id><price>9.5</price><title>Dune</title></item>` +
+				`<item><id>43</id><price>12</price><title>Emma, a novel</title></item></books>` +
+				`<next_cursor>abc</next_cursor><prev_cursor xsi:nil="true"></prev_cursor></response>`,
+		},
+		{
+			name:            "encodes lists as CSV, with the other fields as headers",
+			accept:          "text/csv, application/json;q=0.5",
+			view:            page,
+			list:            true,
+			items:           "books",
+			status:          http.StatusOK,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			headers:         map[string]string{"X-Next-Cursor": "abc", "X-Prev-Cursor": ""},
+			want:            "id,price,title\n42,9.5,Dune\n43,12,\"Emma, a novel\"",
+		},
+		{
+			name:            "encodes lists of objects as CSV",
+			accept:          "text/csv",
+			view:            []map[string]any{{"id": "42", "tags": []string{"sf"}}, {"id": "43", "note": nil}},
+			list:            true,
+			status:          http.StatusOK,
+			wantStatus:      http.StatusOK,
+			wantContentType: "text/csv; charset=utf-8",
+			want:            "id,note,tags\n42,,\"[\"\"sf\"\"]\"\n43,,",
+		},
+		{
+			name:            "refuses CSV for single resources",
+			accept:          "text/csv",
+			view:            book,
+			status:          http.StatusOK,
+			wantStatus:      http.StatusNotAcceptable,
+			wantContentType: "application/problem+json",
+			want: `{"type":"about:blank","title":"Not Acceptable","detail":"not acceptable: supported media types ` +
+				`are application/json, application/xml, text/xml, application/msgpack, application/x-msgpack",` +
+				`"instance":"/v1/books","code":"NOT_ACCEPTABLE","status":406}`,
+		},
+		{
+			name:            "refuses unsupported media types",
+			accept:          "image/png",
+			view:            page,
+			list:            true,
+			items:           "books",
+			status:          http.StatusOK,
+			wantStatus:      http.StatusNotAcceptable,
+			wantContentType: "application/problem+json",
+			want: `{"type":"about:blank","title":"Not Acceptable","detail":"not acceptable: supported media types ` +
+				`are application/json, application/xml, text/xml, text/csv, application/msgpack, ` +
+				`application/x-msgpack","instance":"/v1/books","code":"NOT_ACCEPTABLE","status":406}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, "/v1/books", http.NoBody)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			if tt.list {
+				p.PresentList(w, r, tt.status, tt.view, tt.items)
+			} else {
+				p.Present(w, r, tt.status, tt.view)
+			}
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("want status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Errorf("want Content-Type %q, got %q", tt.wantContentType, got)
+			}
+			if got := w.Header().Get("Vary"); got != "Accept" {
+				t.Errorf("want Vary Accept, got %q", got)
+			}
+			for k, want := range tt.headers {
+				if got := w.Header().Get(k); got != want {
+					t.Errorf("want %s %q, got %q", k, want, got)
+				}
+			}
+			if got := strings.TrimSpace(w.Body.String()); got != tt.want {
+				t.Errorf("want %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	t.Run("encodes MessagePack", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/v1/books/42", http.NoBody)
+		r.Header.Set("Accept", "application/msgpack")
+		p.Present(w, r, http.StatusOK, book)
+
+		if got := w.Header().Get("Content-Type"); got != "application/msgpack" {
+			t.Errorf("want Content-Type application/msgpack, got %q", got)
+		}
+		var got struct {
+			ID    string   `msgpack:"id"`
+			Title string   `msgpack:"title"`
+			Tags  []string `msgpack:"tags"`
+			Price float64  `msgpack:"price"`
+			Stock int      `msgpack:"stock"`
+		}
+		if err := msgpack.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Fatalf("unable to decode response: %v", err)
+		}
+		if got.ID != "42" || got.Title != "Dune" || got.Price != 9.5 || got.Stock != 3 ||
+			!reflect.DeepEqual(got.Tags, []string{"sf", "classic"}) {
+			t.Errorf("want %v, got %+v", book, got)
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package presenter
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// StockPresenter prepares a domain.Stock to be returned to an http interface.
+type StockPresenter struct {
+	logger *slog.Logger
+}
+
+// NewStockPresenter creates a new instance of StockPresenter.
+func NewStockPresenter(logger *slog.Logger) *StockPresenter {
+	return &StockPresenter{logger: logger}
+}
+
+// Present returns the map representation of a domain.Stock.
+// The book ID is prefixed with the resource type (book:), like in BookPresenter.
+func (p *StockPresenter) Present(stock *domain.Stock) map[string]any {
+	res := map[string]any{
+		"book_id":   domain.BookResource.Format(stock.BookID),
+		"on_hand":   stock.OnHand,
+		"reserved":  stock.Reserved,
+		"available": stock.Available(),
+	}
+	if !stock.UpdatedAt.IsZero() {
+		res["updated_at"] = stock.UpdatedAt.Format(time.RFC3339)
+	}
+	return res
+}
@@ -0,0 +1,210 @@
+package presenter
+
+import "time"
+
+// The views below are the typed shapes of the maps the presenters return, documented in the OpenAPI document
+// of the service. The format tag marks strings with a well known format, the doc tag describes a field.
+// Nullable fields are pointers; fields that are only present in some responses are omitempty.
+
+// BookView is the shape of BookPresenter.Present.
+type BookView struct {
+	DeletedAt      *time.Time       `json:"deleted_at,omitempty" doc:"only present for trashed books"`
+	ISBN           *string          `json:"isbn" doc:"ISBN-13, null when unknown"`
+	ID             string           `json:"id" doc:"book: prefixed ID"`
+	Title          string           `json:"title"`
+	Author         string           `json:"author"`
+	Language       string           `json:"language" doc:"BCP 47 tag"`
+	LanguageMatch  string           `json:"language_match,omitempty" doc:"exact, close or none, per Accept-Language"`
+	Currency       string           `json:"currency" doc:"ISO 4217 code"`
+	FormattedPrice string           `json:"formatted_price" doc:"price formatted in the book language"`
+	Authors        []BookAuthorView `json:"authors"`
+	Price          int64            `json:"price" doc:"minor units of currency"`
+}
+
+// BookAuthorView is the shape of an author credited in a BookView.
+type BookAuthorView struct {
+	ID   string `json:"id" doc:"author: prefixed ID"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// BookPageView is the shape of BookPresenter.PresentPage.
+type BookPageView struct {
+	NextCursor *string    `json:"next_cursor" doc:"null on the last page"`
+	Books      []BookView `json:"books"`
+	Total      int        `json:"total"`
+}
+
+// AuditPageView is the shape of BookPresenter.PresentHistory.
+type AuditPageView struct {
+	NextCursor *string          `json:"next_cursor" doc:"null on the last page"`
+	Entries    []AuditEntryView `json:"entries"`
+	Total      int              `json:"total"`
+}
+
+// AuditEntryView is the shape of an entry of an AuditPageView.
+type AuditEntryView struct {
+	At        time.Time         `json:"at"`
+	ID        string            `json:"id" doc:"audit: prefixed ID"`
+	BookID    string            `json:"book_id" doc:"book: prefixed ID"`
+	Actor     string            `json:"actor"`
+	Operation string            `json:"operation"`
+	Changes   []AuditChangeView `json:"changes"`
+	Version   int               `json:"version"`
+}
+
+// AuditChangeView is the shape of a field changed by an AuditEntryView.
+type AuditChangeView struct {
+	Field  string `json:"field"`
+	Before string `json:"before" doc:"empty for created or restored books"`
+	After  string `json:"after" doc:"empty for deleted books"`
+}
+
+// ImportReportView is the shape of BookPresenter.PresentImport.
+type ImportReportView struct {
+	Rows    []ImportRowView `json:"rows"`
+	Created int             `json:"created"`
+	Failed  int             `json:"failed"`
+	DryRun  bool            `json:"dry_run"`
+	Atomic  bool            `json:"atomic"`
+}
+
+// ImportRowView is the shape of a row of an ImportReportView.
+type ImportRowView struct {
+	ID     *string `json:"id" doc:"book: prefixed ID of created rows"`
+	Error  *string `json:"error" doc:"error of failed rows"`
+	Status string  `json:"status" doc:"one of created, valid, skipped, failed"`
+	Line   int     `json:"line"`
+}
+
+// ExportedBookView is the shape of BookPresenter.PresentExport.
+type ExportedBookView struct {
+	*ExportAuditView
+
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at" doc:"null for books in the catalog"`
+	BookView
+
+	Version int `json:"version"`
+}
+
+// ExportAuditView is the audit summary of an ExportedBookView, only present when requested.
+type ExportAuditView struct {
+	UpdatedAt *time.Time `json:"updated_at" doc:"null for books that never changed"`
+	CreatedBy string     `json:"created_by"`
+	UpdatedBy string     `json:"updated_by"`
+	Changes   int        `json:"changes"`
+}
+
+// StockView is the shape of StockPresenter.Present.
+type StockView struct {
+	BookID    string `json:"book_id" doc:"book: prefixed ID"`
+	UpdatedAt string `json:"updated_at,omitempty" format:"date-time" doc:"missing until the stock first changes"`
+	OnHand    int    `json:"on_hand"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+}
+
+// OrderView is the shape of OrderPresenter.Present.
+type OrderView struct {
+	ID        string          `json:"id" doc:"order: prefixed ID"`
+	Status    string          `json:"status"`
+	Currency  string          `json:"currency" doc:"ISO 4217 code"`
+	CreatedAt string          `json:"created_at" format:"date-time"`
+	UpdatedAt string          `json:"updated_at" format:"date-time"`
+	Items     []OrderItemView `json:"items"`
+	Total     int64           `json:"total" doc:"minor units of currency"`
+}
+
+// OrderItemView is the shape of an item of an OrderView.
+type OrderItemView struct {
+	BookID    string `json:"book_id" doc:"book: prefixed ID"`
+	Title     string `json:"title"`
+	Quantity  int    `json:"quantity"`
+	UnitPrice int64  `json:"unit_price" doc:"minor units of the order currency"`
+	Subtotal  int64  `json:"subtotal" doc:"minor units of the order currency"`
+}
+
+// OrderListView is the shape of the list of orders.
+type OrderListView struct {
+	Orders []OrderView `json:"orders"`
+}
+
+// AuthorView is the shape of AuthorPresenter.Present.
+type AuthorView struct {
+	ID        string `json:"id" doc:"author: prefixed ID"`
+	Name      string `json:"name"`
+	CreatedAt string `json:"created_at" format:"date-time"`
+}
+
+// AuthorListView is the shape of the list of authors.
+type AuthorListView struct {
+	Authors []AuthorView `json:"authors"`
+}
+
+// WebhookView is the shape of WebhookPresenter.Present.
+type WebhookView struct {
+	ID        string   `json:"id" doc:"webhook: prefixed ID"`
+	URL       string   `json:"url"`
+	CreatedAt string   `json:"created_at" format:"date-time"`
+	Events    []string `json:"events" doc:"empty when every event is delivered"`
+}
+
+// WebhookListView is the shape of the list of webhooks.
+type WebhookListView struct {
+	Webhooks []WebhookView `json:"webhooks"`
+}
+
+// DeliveryView is the shape of WebhookPresenter.PresentDelivery.
+type DeliveryView struct {
+	NextAttemptAt *string           `json:"next_attempt_at" format:"date-time" doc:"null once delivered or dead"`
+	ID            string            `json:"id" doc:"delivery: prefixed ID"`
+	WebhookID     string            `json:"webhook_id" doc:"webhook: prefixed ID"`
+	Status        string            `json:"status"`
+	CreatedAt     string            `json:"created_at" format:"date-time"`
+	Event         DeliveryEventView `json:"event"`
+	Attempts      []AttemptView     `json:"attempts"`
+}
+
+// DeliveryEventView is the shape of the event of a DeliveryView.
+type DeliveryEventView struct {
+	ID         string `json:"id" doc:"event: prefixed ID"`
+	Type       string `json:"type"`
+	BookID     string `json:"book_id" doc:"book: prefixed ID"`
+	OccurredAt string `json:"occurred_at" format:"date-time"`
+}
+
+// AttemptView is the shape of an attempt of a DeliveryView.
+type AttemptView struct {
+	StatusCode *int    `json:"status_code" doc:"null if the receiver could not be reached"`
+	Error      *string `json:"error" doc:"null for successful attempts"`
+	At         string  `json:"at" format:"date-time"`
+}
+
+// DeliveryListView is the shape of the list of deliveries of a webhook.
+type DeliveryListView struct {
+	Deliveries []DeliveryView `json:"deliveries"`
+}
+
+// ProblemView is the RFC 9457 problem details object of ErrorPresenter.Present.
+type ProblemView struct {
+	Type     string             `json:"type" doc:"URI of the problem type, about:blank if not a domain error"`
+	Title    string             `json:"title" doc:"summary of the problem type"`
+	Detail   string             `json:"detail"`
+	Instance string             `json:"instance" doc:"path of the request"`
+	Code     string             `json:"code" doc:"stable code to switch on, e.g. BOOK_NOT_FOUND"`
+	Errors   []ProblemFieldView `json:"errors,omitempty" doc:"fields that failed validation"`
+	Status   int                `json:"status"`
+}
+
+// ProblemFieldView is a field that failed validation in a ProblemView.
+type ProblemFieldView struct {
+	Field  string `json:"field" doc:"name of the field, e.g. authors[0].role"`
+	Detail string `json:"detail"`
+}
+
+// ErrorView is the legacy shape of ErrorPresenter.Present, for clients accepting application/json.
+type ErrorView struct {
+	Message string `json:"message"`
+	Status  string `json:"status" doc:"HTTP status text"`
+}
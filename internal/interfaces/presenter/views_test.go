@@ -0,0 +1,191 @@
+package presenter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/interfaces/presenter"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+)
+
+// TestViews checks every view has the same fields, with the same types, as the map it documents.
+// A failure means the OpenAPI document of the service no longer describes the responses.
+func TestViews(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	books := presenter.NewBookPresenter(logger)
+	webhooks := presenter.NewWebhookPresenter(logger)
+	at := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	book := &domain.Book{
+		ID:          uuid.New(),
+		Title:       "dune",
+		Author:      "Frank Herbert",
+		LanguageTag: "en",
+		ISBN:        "9780306406157",
+		Authors:     []domain.BookAuthor{{AuthorID: uuid.New(), Name: "Frank Herbert", Role: domain.RoleAuthor}},
+		Price:       domain.Money{Amount: 1299, Currency: "EUR"},
+		CreatedAt:   at,
+		Version:     2,
+	}
+	trashed := *book
+	trashed.ISBN, trashed.DeletedAt = "", at
+	delivery := &domain.Delivery{
+		CreatedAt: at,
+		Status:    domain.DeliveryPending,
+		Attempts:  []domain.DeliveryAttempt{{At: at, Error: "timeout"}, {At: at, Error: "boom", StatusCode: 500}},
+		Event:     domain.Event{ID: uuid.New(), BookID: book.ID, Type: domain.EventBookCreated, At: at},
+		ID:        uuid.New(),
+		WebhookID: uuid.New(),
+	}
+	delivered := *delivery
+	delivered.Status, delivered.NextAttemptAt = domain.DeliverySucceeded, time.Time{}
+	delivered.Attempts = []domain.DeliveryAttempt{{At: at, StatusCode: 204}}
+	delivery.NextAttemptAt = at
+	errReq := httptest.NewRequest(http.MethodPut, "/v1/books", http.NoBody)
+	problemRes := httptest.NewRecorder()
+	presenter.NewErrorPresenter(logger).Present(problemRes, errReq, domain.NewFieldError("title", "title is required"), 0)
+	errReq.Header.Set("Accept", "application/json")
+	errRes := httptest.NewRecorder()
+	presenter.NewErrorPresenter(logger).Present(errRes, errReq, errors.New("title is required"), http.StatusBadRequest)
+
+	tests := []struct {
+		view      any
+		presented any
+		name      string
+	}{
+		{name: "book", presented: books.Present(book), view: &presenter.BookView{}},
+		{name: "trashed book", presented: books.Present(&trashed), view: &presenter.BookView{}},
+		{
+			name:      "book page",
+			presented: books.PresentPage(&domain.BookPage{Books: []*domain.Book{book}, NextCursor: "next", Total: 2}),
+			view:      &presenter.BookPageView{},
+		},
+		{
+			name: "history",
+			presented: books.PresentHistory(&domain.AuditPage{Entries: []*domain.AuditEntry{{
+				At:        at,
+				Actor:     "alice",
+				Operation: domain.AuditUpdate,
+				Changes:   []domain.FieldChange{{Field: "price", Before: "1299 EUR", After: "999 EUR"}},
+				ID:        uuid.New(),
+				BookID:    book.ID,
+				Version:   2,
+			}}, Total: 1}),
+			view: &presenter.AuditPageView{},
+		},
+		{
+			name: "import",
+			presented: books.PresentImport(&domain.ImportReport{
+				Rows:    []*domain.ImportRow{{Line: 1, Book: book}, {Line: 2, Err: errors.New("title is required")}},
+				Created: 1,
+				Failed:  1,
+			}),
+			view: &presenter.ImportReportView{},
+		},
+		{
+			name:      "export",
+			presented: books.PresentExport(&domain.ExportedBook{Book: book}),
+			view:      &presenter.ExportedBookView{},
+		},
+		{
+			name: "export with audit",
+			presented: books.PresentExport(&domain.ExportedBook{
+				Book:  &trashed,
+				Audit: &domain.AuditSummary{CreatedBy: "alice", UpdatedBy: "bob", UpdatedAt: at, Changes: 3},
+			}),
+			view: &presenter.ExportedBookView{},
+		},
+		{
+			name: "export with audit of unchanged book",
+			presented: books.PresentExport(&domain.ExportedBook{
+				Book:  book,
+				Audit: &domain.AuditSummary{CreatedBy: "alice", Changes: 1},
+			}),
+			view: &presenter.ExportedBookView{},
+		},
+		{
+			name:      "stock",
+			presented: presenter.NewStockPresenter(logger).Present(&domain.Stock{BookID: book.ID, OnHand: 3}),
+			view:      &presenter.StockView{},
+		},
+		{
+			name: "changed stock",
+			presented: presenter.NewStockPresenter(logger).Present(
+				&domain.Stock{BookID: book.ID, OnHand: 3, Reserved: 1, UpdatedAt: at},
+			),
+			view: &presenter.StockView{},
+		},
+		{
+			name: "order",
+			presented: presenter.NewOrderPresenter(logger).Present(&domain.Order{
+				CreatedAt: at,
+				UpdatedAt: at,
+				Status:    domain.OrderPending,
+				Items: []domain.OrderItem{
+					{Title: "dune", UnitPrice: book.Price, BookID: book.ID, Quantity: 2},
+				},
+				Total: domain.Money{Amount: 2598, Currency: "EUR"},
+				ID:    uuid.New(),
+			}),
+			view: &presenter.OrderView{},
+		},
+		{
+			name: "author",
+			presented: presenter.NewAuthorPresenter(logger).Present(
+				&domain.Author{ID: uuid.New(), Name: "Frank Herbert", CreatedAt: at},
+			),
+			view: &presenter.AuthorView{},
+		},
+		{
+			name: "webhook",
+			presented: webhooks.Present(&domain.Webhook{
+				CreatedAt: at,
+				URL:       "https://example.com/hook",
+				Secret:    "s3cret",
+				Events:    []domain.EventType{domain.EventBookCreated},
+				ID:        uuid.New(),
+			}),
+			view: &presenter.WebhookView{},
+		},
+		{name: "pending delivery", presented: webhooks.PresentDelivery(delivery), view: &presenter.DeliveryView{}},
+		{name: "delivered delivery", presented: webhooks.PresentDelivery(&delivered), view: &presenter.DeliveryView{}},
+		{name: "problem", presented: json.RawMessage(problemRes.Body.Bytes()), view: &presenter.ProblemView{}},
+		{name: "error", presented: json.RawMessage(errRes.Body.Bytes()), view: &presenter.ErrorView{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			presented, err := json.Marshal(tt.presented)
+			if err != nil {
+				t.Fatalf("error encoding presented map: %v", err)
+			}
+			dec := json.NewDecoder(bytes.NewReader(presented))
+			dec.DisallowUnknownFields()
+			if err = dec.Decode(tt.view); err != nil {
+				t.Fatalf("view does not match %s: %v", presented, err)
+			}
+			view, err := json.Marshal(tt.view)
+			if err != nil {
+				t.Fatalf("error encoding view: %v", err)
+			}
+
+			var want, got any
+			if err = json.Unmarshal(presented, &want); err != nil {
+				t.Fatal(err)
+			}
+			if err = json.Unmarshal(view, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Errorf("view does not match presented map\nwant %s\ngot  %s", presented, view)
+			}
+		})
+	}
+}
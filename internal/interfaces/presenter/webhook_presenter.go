@@ -0,0 +1,76 @@
+package presenter
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// WebhookPresenter prepares a domain.Webhook and its deliveries to be returned to an http interface.
+type WebhookPresenter struct {
+	logger *slog.Logger
+}
+
+// NewWebhookPresenter creates a new instance of WebhookPresenter.
+func NewWebhookPresenter(logger *slog.Logger) *WebhookPresenter {
+	return &WebhookPresenter{logger: logger}
+}
+
+// Present returns the map representation of a domain.Webhook.
+// The webhook ID is prefixed with the resource type (webhook:).
+// The secret is never returned; an empty event list means every event is delivered.
+func (p *WebhookPresenter) Present(webhook *domain.Webhook) map[string]any {
+	events := make([]string, len(webhook.Events))
+	for i, e := range webhook.Events {
+		events[i] = string(e)
+	}
+	return map[string]any{
+		"id":         domain.WebhookResource.Format(webhook.ID),
+		"url":        webhook.URL,
+		"events":     events,
+		"created_at": webhook.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// PresentDelivery returns the map representation of a domain.Delivery.
+// IDs are prefixed with their resource type (delivery:, webhook:, event:, book:).
+// Every attempt is listed, oldest first; the next attempt is null once the delivery
+// succeeded or was dead-lettered, as are the error of successful attempts
+// and the status code of attempts that could not reach the receiver.
+func (p *WebhookPresenter) PresentDelivery(delivery *domain.Delivery) map[string]any {
+	attempts := make([]map[string]any, len(delivery.Attempts))
+	for i := range delivery.Attempts {
+		a := &delivery.Attempts[i]
+		var errMsg, code any
+		if a.Failed() {
+			errMsg = a.Error
+		}
+		if a.StatusCode != 0 {
+			code = a.StatusCode
+		}
+		attempts[i] = map[string]any{
+			"at":          a.At.Format(time.RFC3339),
+			"status_code": code,
+			"error":       errMsg,
+		}
+	}
+	var next any
+	if !delivery.NextAttemptAt.IsZero() {
+		next = delivery.NextAttemptAt.Format(time.RFC3339)
+	}
+	return map[string]any{
+		"id":              domain.DeliveryResource.Format(delivery.ID),
+		"webhook_id":      domain.WebhookResource.Format(delivery.WebhookID),
+		"status":          delivery.Status,
+		"attempts":        attempts,
+		"next_attempt_at": next,
+		"event": map[string]any{
+			"id":          domain.EventResource.Format(delivery.Event.ID),
+			"type":        delivery.Event.Type,
+			"book_id":     domain.BookResource.Format(delivery.Event.BookID),
+			"occurred_at": delivery.Event.At.Format(time.RFC3339),
+		},
+		"created_at": delivery.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -3,3 +3,15 @@ package test
 //go:generate mockgen -package mocks -source ../domain/book.go -destination mocks/book_repository.go
 //go:generate mockgen -package mocks -source ../infrastructure/webservice/handler.go -destination mocks/book_controller.go
 //go:generate mockgen -package mocks -source ../interfaces/controller/book_http_controller.go -destination mocks/book_interactor.go BookInteractor
+//go:generate mockgen -package mocks -source ../domain/book_search.go -destination mocks/book_search_index.go
+//go:generate mockgen -package mocks -source ../interfaces/controller/book_search_http_controller.go -destination mocks/book_search_interactor.go BookSearchInteractor
+//go:generate mockgen -package mocks -source ../domain/stock.go -destination mocks/stock_repository.go
+//go:generate mockgen -package mocks -source ../interfaces/controller/inventory_http_controller.go -destination mocks/inventory_interactor.go InventoryInteractor
+//go:generate mockgen -package mocks -source ../domain/order.go -destination mocks/order_repository.go
+//go:generate mockgen -package mocks -source ../interfaces/controller/order_http_controller.go -destination mocks/order_interactor.go OrderInteractor
+//go:generate mockgen -package mocks -source ../domain/author.go -destination mocks/author_repository.go
+//go:generate mockgen -package mocks -source ../domain/audit.go -destination mocks/audit_repository.go
+//go:generate mockgen -package mocks -source ../domain/event.go -destination mocks/event_outbox.go
+//go:generate mockgen -package mocks -source ../interfaces/controller/author_http_controller.go -destination mocks/author_interactor.go AuthorInteractor
+//go:generate mockgen -package mocks -source ../domain/webhook.go -destination mocks/webhook_repository.go
+//go:generate mockgen -package mocks -source ../interfaces/controller/webhook_http_controller.go -destination mocks/webhook_interactor.go WebhookInteractor
@@ -0,0 +1,152 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../interfaces/controller/author_http_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source ../interfaces/controller/author_http_controller.go -destination mocks/author_interactor.go AuthorInteractor
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// MockAuthorInteractor is a mock of AuthorInteractor interface.
+type MockAuthorInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorInteractorMockRecorder
+}
+
+// MockAuthorInteractorMockRecorder is the mock recorder for MockAuthorInteractor.
+type MockAuthorInteractorMockRecorder struct {
+	mock *MockAuthorInteractor
+}
+
+// NewMockAuthorInteractor creates a new mock instance.
+func NewMockAuthorInteractor(ctrl *gomock.Controller) *MockAuthorInteractor {
+	mock := &MockAuthorInteractor{ctrl: ctrl}
+	mock.recorder = &MockAuthorInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorInteractor) EXPECT() *MockAuthorInteractorMockRecorder {
+	return m.recorder
+}
+
+// CreateAuthor mocks base method.
+func (m *MockAuthorInteractor) CreateAuthor(ctx context.Context, author *domain.Author) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthor", ctx, author)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuthor indicates an expected call of CreateAuthor.
+func (mr *MockAuthorInteractorMockRecorder) CreateAuthor(ctx, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthor", reflect.TypeOf((*MockAuthorInteractor)(nil).CreateAuthor), ctx, author)
+}
+
+// DeleteAuthor mocks base method.
+func (m *MockAuthorInteractor) DeleteAuthor(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthor", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuthor indicates an expected call of DeleteAuthor.
+func (mr *MockAuthorInteractorMockRecorder) DeleteAuthor(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthor", reflect.TypeOf((*MockAuthorInteractor)(nil).DeleteAuthor), ctx, id)
+}
+
+// GetAuthor mocks base method.
+func (m *MockAuthorInteractor) GetAuthor(ctx context.Context, id string) (*domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthor", ctx, id)
+	ret0, _ := ret[0].(*domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthor indicates an expected call of GetAuthor.
+func (mr *MockAuthorInteractorMockRecorder) GetAuthor(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthor", reflect.TypeOf((*MockAuthorInteractor)(nil).GetAuthor), ctx, id)
+}
+
+// ListAuthors mocks base method.
+func (m *MockAuthorInteractor) ListAuthors(ctx context.Context) ([]*domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthors", ctx)
+	ret0, _ := ret[0].([]*domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuthors indicates an expected call of ListAuthors.
+func (mr *MockAuthorInteractorMockRecorder) ListAuthors(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthors", reflect.TypeOf((*MockAuthorInteractor)(nil).ListAuthors), ctx)
+}
+
+// RenameAuthor mocks base method.
+func (m *MockAuthorInteractor) RenameAuthor(ctx context.Context, id, name string) (*domain.Author, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameAuthor", ctx, id, name)
+	ret0, _ := ret[0].(*domain.Author)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameAuthor indicates an expected call of RenameAuthor.
+func (mr *MockAuthorInteractorMockRecorder) RenameAuthor(ctx, id, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameAuthor", reflect.TypeOf((*MockAuthorInteractor)(nil).RenameAuthor), ctx, id, name)
+}
+
+// MockAuthorPresenter is a mock of AuthorPresenter interface.
+type MockAuthorPresenter struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorPresenterMockRecorder
+}
+
+// MockAuthorPresenterMockRecorder is the mock recorder for MockAuthorPresenter.
+type MockAuthorPresenterMockRecorder struct {
+	mock *MockAuthorPresenter
+}
+
+// NewMockAuthorPresenter creates a new mock instance.
+func NewMockAuthorPresenter(ctrl *gomock.Controller) *MockAuthorPresenter {
+	mock := &MockAuthorPresenter{ctrl: ctrl}
+	mock.recorder = &MockAuthorPresenterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorPresenter) EXPECT() *MockAuthorPresenterMockRecorder {
+	return m.recorder
+}
+
+// Present mocks base method.
+func (m *MockAuthorPresenter) Present(author *domain.Author) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Present", author)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// Present indicates an expected call of Present.
+func (mr *MockAuthorPresenterMockRecorder) Present(author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockAuthorPresenter)(nil).Present), author)
+}
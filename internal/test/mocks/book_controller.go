@@ -63,6 +63,18 @@ func (mr *MockBookControllerMockRecorder) DeleteBook(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBook", reflect.TypeOf((*MockBookController)(nil).DeleteBook), w, r)
 }
 
+// ExportBooks mocks base method.
+func (m *MockBookController) ExportBooks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ExportBooks", w, r)
+}
+
+// ExportBooks indicates an expected call of ExportBooks.
+func (mr *MockBookControllerMockRecorder) ExportBooks(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBooks", reflect.TypeOf((*MockBookController)(nil).ExportBooks), w, r)
+}
+
 // GetBook mocks base method.
 func (m *MockBookController) GetBook(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -75,16 +87,88 @@ func (mr *MockBookControllerMockRecorder) GetBook(w, r any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBook", reflect.TypeOf((*MockBookController)(nil).GetBook), w, r)
 }
 
+// GetBookByISBN mocks base method.
+func (m *MockBookController) GetBookByISBN(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetBookByISBN", w, r)
+}
+
+// GetBookByISBN indicates an expected call of GetBookByISBN.
+func (mr *MockBookControllerMockRecorder) GetBookByISBN(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByISBN", reflect.TypeOf((*MockBookController)(nil).GetBookByISBN), w, r)
+}
+
+// GetBookHistory mocks base method.
+func (m *MockBookController) GetBookHistory(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetBookHistory", w, r)
+}
+
+// GetBookHistory indicates an expected call of GetBookHistory.
+func (mr *MockBookControllerMockRecorder) GetBookHistory(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookHistory", reflect.TypeOf((*MockBookController)(nil).GetBookHistory), w, r)
+}
+
+// ImportBooks mocks base method.
+func (m *MockBookController) ImportBooks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ImportBooks", w, r)
+}
+
+// ImportBooks indicates an expected call of ImportBooks.
+func (mr *MockBookControllerMockRecorder) ImportBooks(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBooks", reflect.TypeOf((*MockBookController)(nil).ImportBooks), w, r)
+}
+
 // ListBooks mocks base method.
-func (m *MockBookController) ListBooks(w http.ResponseWriter, arg1 *http.Request) {
+func (m *MockBookController) ListBooks(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "ListBooks", w, arg1)
+	m.ctrl.Call(m, "ListBooks", w, r)
 }
 
 // ListBooks indicates an expected call of ListBooks.
-func (mr *MockBookControllerMockRecorder) ListBooks(w, arg1 any) *gomock.Call {
+func (mr *MockBookControllerMockRecorder) ListBooks(w, r any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockBookController)(nil).ListBooks), w, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockBookController)(nil).ListBooks), w, r)
+}
+
+// ListTrash mocks base method.
+func (m *MockBookController) ListTrash(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListTrash", w, r)
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockBookControllerMockRecorder) ListTrash(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockBookController)(nil).ListTrash), w, r)
+}
+
+// PatchBook mocks base method.
+func (m *MockBookController) PatchBook(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PatchBook", w, r)
+}
+
+// PatchBook indicates an expected call of PatchBook.
+func (mr *MockBookControllerMockRecorder) PatchBook(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBook", reflect.TypeOf((*MockBookController)(nil).PatchBook), w, r)
+}
+
+// RestoreBook mocks base method.
+func (m *MockBookController) RestoreBook(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreBook", w, r)
+}
+
+// RestoreBook indicates an expected call of RestoreBook.
+func (mr *MockBookControllerMockRecorder) RestoreBook(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBook", reflect.TypeOf((*MockBookController)(nil).RestoreBook), w, r)
 }
 
 // UpdateBook mocks base method.
@@ -98,3 +182,370 @@ func (mr *MockBookControllerMockRecorder) UpdateBook(w, r any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBook", reflect.TypeOf((*MockBookController)(nil).UpdateBook), w, r)
 }
+
+// MockBookSearchController is a mock of BookSearchController interface.
+type MockBookSearchController struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookSearchControllerMockRecorder
+}
+
+// MockBookSearchControllerMockRecorder is the mock recorder for MockBookSearchController.
+type MockBookSearchControllerMockRecorder struct {
+	mock *MockBookSearchController
+}
+
+// NewMockBookSearchController creates a new mock instance.
+func NewMockBookSearchController(ctrl *gomock.Controller) *MockBookSearchController {
+	mock := &MockBookSearchController{ctrl: ctrl}
+	mock.recorder = &MockBookSearchControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookSearchController) EXPECT() *MockBookSearchControllerMockRecorder {
+	return m.recorder
+}
+
+// SearchBooks mocks base method.
+func (m *MockBookSearchController) SearchBooks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SearchBooks", w, r)
+}
+
+// SearchBooks indicates an expected call of SearchBooks.
+func (mr *MockBookSearchControllerMockRecorder) SearchBooks(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBookSearchController)(nil).SearchBooks), w, r)
+}
+
+// MockInventoryController is a mock of InventoryController interface.
+type MockInventoryController struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryControllerMockRecorder
+}
+
+// MockInventoryControllerMockRecorder is the mock recorder for MockInventoryController.
+type MockInventoryControllerMockRecorder struct {
+	mock *MockInventoryController
+}
+
+// NewMockInventoryController creates a new mock instance.
+func NewMockInventoryController(ctrl *gomock.Controller) *MockInventoryController {
+	mock := &MockInventoryController{ctrl: ctrl}
+	mock.recorder = &MockInventoryControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryController) EXPECT() *MockInventoryControllerMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockInventoryController) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AdjustStock", w, r)
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockInventoryControllerMockRecorder) AdjustStock(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockInventoryController)(nil).AdjustStock), w, r)
+}
+
+// GetStock mocks base method.
+func (m *MockInventoryController) GetStock(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetStock", w, r)
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockInventoryControllerMockRecorder) GetStock(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockInventoryController)(nil).GetStock), w, r)
+}
+
+// ReceiveStock mocks base method.
+func (m *MockInventoryController) ReceiveStock(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReceiveStock", w, r)
+}
+
+// ReceiveStock indicates an expected call of ReceiveStock.
+func (mr *MockInventoryControllerMockRecorder) ReceiveStock(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveStock", reflect.TypeOf((*MockInventoryController)(nil).ReceiveStock), w, r)
+}
+
+// ReserveStock mocks base method.
+func (m *MockInventoryController) ReserveStock(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReserveStock", w, r)
+}
+
+// ReserveStock indicates an expected call of ReserveStock.
+func (mr *MockInventoryControllerMockRecorder) ReserveStock(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockInventoryController)(nil).ReserveStock), w, r)
+}
+
+// MockOrderController is a mock of OrderController interface.
+type MockOrderController struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderControllerMockRecorder
+}
+
+// MockOrderControllerMockRecorder is the mock recorder for MockOrderController.
+type MockOrderControllerMockRecorder struct {
+	mock *MockOrderController
+}
+
+// NewMockOrderController creates a new mock instance.
+func NewMockOrderController(ctrl *gomock.Controller) *MockOrderController {
+	mock := &MockOrderController{ctrl: ctrl}
+	mock.recorder = &MockOrderControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderController) EXPECT() *MockOrderControllerMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderController) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelOrder", w, r)
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderControllerMockRecorder) CancelOrder(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderController)(nil).CancelOrder), w, r)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderController) GetOrder(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetOrder", w, r)
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderControllerMockRecorder) GetOrder(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderController)(nil).GetOrder), w, r)
+}
+
+// ListOrders mocks base method.
+func (m *MockOrderController) ListOrders(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListOrders", w, r)
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderControllerMockRecorder) ListOrders(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderController)(nil).ListOrders), w, r)
+}
+
+// PayOrder mocks base method.
+func (m *MockOrderController) PayOrder(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PayOrder", w, r)
+}
+
+// PayOrder indicates an expected call of PayOrder.
+func (mr *MockOrderControllerMockRecorder) PayOrder(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockOrderController)(nil).PayOrder), w, r)
+}
+
+// PlaceOrder mocks base method.
+func (m *MockOrderController) PlaceOrder(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PlaceOrder", w, r)
+}
+
+// PlaceOrder indicates an expected call of PlaceOrder.
+func (mr *MockOrderControllerMockRecorder) PlaceOrder(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockOrderController)(nil).PlaceOrder), w, r)
+}
+
+// ShipOrder mocks base method.
+func (m *MockOrderController) ShipOrder(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ShipOrder", w, r)
+}
+
+// ShipOrder indicates an expected call of ShipOrder.
+func (mr *MockOrderControllerMockRecorder) ShipOrder(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipOrder", reflect.TypeOf((*MockOrderController)(nil).ShipOrder), w, r)
+}
+
+// MockAuthorController is a mock of AuthorController interface.
+type MockAuthorController struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorControllerMockRecorder
+}
+
+// MockAuthorControllerMockRecorder is the mock recorder for MockAuthorController.
+type MockAuthorControllerMockRecorder struct {
+	mock *MockAuthorController
+}
+
+// NewMockAuthorController creates a new mock instance.
+func NewMockAuthorController(ctrl *gomock.Controller) *MockAuthorController {
+	mock := &MockAuthorController{ctrl: ctrl}
+	mock.recorder = &MockAuthorControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorController) EXPECT() *MockAuthorControllerMockRecorder {
+	return m.recorder
+}
+
+// CreateAuthor mocks base method.
+func (m *MockAuthorController) CreateAuthor(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateAuthor", w, r)
+}
+
+// CreateAuthor indicates an expected call of CreateAuthor.
+func (mr *MockAuthorControllerMockRecorder) CreateAuthor(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthor", reflect.TypeOf((*MockAuthorController)(nil).CreateAuthor), w, r)
+}
+
+// DeleteAuthor mocks base method.
+func (m *MockAuthorController) DeleteAuthor(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteAuthor", w, r)
+}
+
+// DeleteAuthor indicates an expected call of DeleteAuthor.
+func (mr *MockAuthorControllerMockRecorder) DeleteAuthor(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthor", reflect.TypeOf((*MockAuthorController)(nil).DeleteAuthor), w, r)
+}
+
+// GetAuthor mocks base method.
+func (m *MockAuthorController) GetAuthor(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuthor", w, r)
+}
+
+// GetAuthor indicates an expected call of GetAuthor.
+func (mr *MockAuthorControllerMockRecorder) GetAuthor(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthor", reflect.TypeOf((*MockAuthorController)(nil).GetAuthor), w, r)
+}
+
+// ListAuthors mocks base method.
+func (m *MockAuthorController) ListAuthors(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListAuthors", w, r)
+}
+
+// ListAuthors indicates an expected call of ListAuthors.
+func (mr *MockAuthorControllerMockRecorder) ListAuthors(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthors", reflect.TypeOf((*MockAuthorController)(nil).ListAuthors), w, r)
+}
+
+// RenameAuthor mocks base method.
+func (m *MockAuthorController) RenameAuthor(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RenameAuthor", w, r)
+}
+
+// RenameAuthor indicates an expected call of RenameAuthor.
+func (mr *MockAuthorControllerMockRecorder) RenameAuthor(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameAuthor", reflect.TypeOf((*MockAuthorController)(nil).RenameAuthor), w, r)
+}
+
+// MockWebhookController is a mock of WebhookController interface.
+type MockWebhookController struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookControllerMockRecorder
+}
+
+// MockWebhookControllerMockRecorder is the mock recorder for MockWebhookController.
+type MockWebhookControllerMockRecorder struct {
+	mock *MockWebhookController
+}
+
+// NewMockWebhookController creates a new mock instance.
+func NewMockWebhookController(ctrl *gomock.Controller) *MockWebhookController {
+	mock := &MockWebhookController{ctrl: ctrl}
+	mock.recorder = &MockWebhookControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookController) EXPECT() *MockWebhookControllerMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookController) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateWebhook", w, r)
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookControllerMockRecorder) CreateWebhook(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookController)(nil).CreateWebhook), w, r)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookController) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteWebhook", w, r)
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookControllerMockRecorder) DeleteWebhook(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookController)(nil).DeleteWebhook), w, r)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhookController) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetWebhook", w, r)
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookControllerMockRecorder) GetWebhook(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhookController)(nil).GetWebhook), w, r)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookController) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListDeliveries", w, r)
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookControllerMockRecorder) ListDeliveries(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookController)(nil).ListDeliveries), w, r)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookController) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListWebhooks", w, r)
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookControllerMockRecorder) ListWebhooks(w, r any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookController)(nil).ListWebhooks), w, r)
+}
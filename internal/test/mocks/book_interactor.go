@@ -10,10 +10,12 @@
 package mocks
 
 import (
+	context "context"
 	http "net/http"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
+	language "golang.org/x/text/language"
 
 	domain "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
 )
@@ -42,75 +44,179 @@ func (m *MockBookInteractor) EXPECT() *MockBookInteractorMockRecorder {
 }
 
 // CreateBook mocks base method.
-func (m *MockBookInteractor) CreateBook(book *domain.Book) error {
+func (m *MockBookInteractor) CreateBook(ctx context.Context, book *domain.Book) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBook", book)
+	ret := m.ctrl.Call(m, "CreateBook", ctx, book)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateBook indicates an expected call of CreateBook.
-func (mr *MockBookInteractorMockRecorder) CreateBook(book any) *gomock.Call {
+func (mr *MockBookInteractorMockRecorder) CreateBook(ctx, book any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBook", reflect.TypeOf((*MockBookInteractor)(nil).CreateBook), book)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBook", reflect.TypeOf((*MockBookInteractor)(nil).CreateBook), ctx, book)
 }
 
 // DeleteBook mocks base method.
-func (m *MockBookInteractor) DeleteBook(id string) error {
+func (m *MockBookInteractor) DeleteBook(ctx context.Context, id string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteBook", id)
+	ret := m.ctrl.Call(m, "DeleteBook", ctx, id)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteBook indicates an expected call of DeleteBook.
-func (mr *MockBookInteractorMockRecorder) DeleteBook(id any) *gomock.Call {
+func (mr *MockBookInteractorMockRecorder) DeleteBook(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBook", reflect.TypeOf((*MockBookInteractor)(nil).DeleteBook), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBook", reflect.TypeOf((*MockBookInteractor)(nil).DeleteBook), ctx, id)
+}
+
+// ExportBooks mocks base method.
+func (m *MockBookInteractor) ExportBooks(ctx context.Context, opts domain.ExportOptions, fn func(*domain.ExportedBook) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBooks", ctx, opts, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportBooks indicates an expected call of ExportBooks.
+func (mr *MockBookInteractorMockRecorder) ExportBooks(ctx, opts, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBooks", reflect.TypeOf((*MockBookInteractor)(nil).ExportBooks), ctx, opts, fn)
 }
 
 // GetBook mocks base method.
-func (m *MockBookInteractor) GetBook(id string) (*domain.Book, error) {
+func (m *MockBookInteractor) GetBook(ctx context.Context, id string) (*domain.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBook", id)
+	ret := m.ctrl.Call(m, "GetBook", ctx, id)
 	ret0, _ := ret[0].(*domain.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBook indicates an expected call of GetBook.
-func (mr *MockBookInteractorMockRecorder) GetBook(id any) *gomock.Call {
+func (mr *MockBookInteractorMockRecorder) GetBook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBook", reflect.TypeOf((*MockBookInteractor)(nil).GetBook), ctx, id)
+}
+
+// GetBookByISBN mocks base method.
+func (m *MockBookInteractor) GetBookByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookByISBN", ctx, isbn)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookByISBN indicates an expected call of GetBookByISBN.
+func (mr *MockBookInteractorMockRecorder) GetBookByISBN(ctx, isbn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBook", reflect.TypeOf((*MockBookInteractor)(nil).GetBook), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookByISBN", reflect.TypeOf((*MockBookInteractor)(nil).GetBookByISBN), ctx, isbn)
+}
+
+// GetBookHistory mocks base method.
+func (m *MockBookInteractor) GetBookHistory(ctx context.Context, id string, query domain.AuditQuery) (*domain.AuditPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookHistory", ctx, id, query)
+	ret0, _ := ret[0].(*domain.AuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookHistory indicates an expected call of GetBookHistory.
+func (mr *MockBookInteractorMockRecorder) GetBookHistory(ctx, id, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookHistory", reflect.TypeOf((*MockBookInteractor)(nil).GetBookHistory), ctx, id, query)
+}
+
+// ImportBooks mocks base method.
+func (m *MockBookInteractor) ImportBooks(ctx context.Context, rows []*domain.ImportRow, opts domain.ImportOptions) (*domain.ImportReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportBooks", ctx, rows, opts)
+	ret0, _ := ret[0].(*domain.ImportReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportBooks indicates an expected call of ImportBooks.
+func (mr *MockBookInteractorMockRecorder) ImportBooks(ctx, rows, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportBooks", reflect.TypeOf((*MockBookInteractor)(nil).ImportBooks), ctx, rows, opts)
 }
 
 // ListBooks mocks base method.
-func (m *MockBookInteractor) ListBooks() ([]*domain.Book, error) {
+func (m *MockBookInteractor) ListBooks(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListBooks")
-	ret0, _ := ret[0].([]*domain.Book)
+	ret := m.ctrl.Call(m, "ListBooks", ctx, query)
+	ret0, _ := ret[0].(*domain.BookPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListBooks indicates an expected call of ListBooks.
-func (mr *MockBookInteractorMockRecorder) ListBooks() *gomock.Call {
+func (mr *MockBookInteractorMockRecorder) ListBooks(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockBookInteractor)(nil).ListBooks), ctx, query)
+}
+
+// ListTrash mocks base method.
+func (m *MockBookInteractor) ListTrash(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", ctx, query)
+	ret0, _ := ret[0].(*domain.BookPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockBookInteractorMockRecorder) ListTrash(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockBookInteractor)(nil).ListTrash), ctx, query)
+}
+
+// PatchBook mocks base method.
+func (m *MockBookInteractor) PatchBook(ctx context.Context, id string, patch *domain.BookPatch, version int) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchBook", ctx, id, patch, version)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchBook indicates an expected call of PatchBook.
+func (mr *MockBookInteractorMockRecorder) PatchBook(ctx, id, patch, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchBook", reflect.TypeOf((*MockBookInteractor)(nil).PatchBook), ctx, id, patch, version)
+}
+
+// RestoreBook mocks base method.
+func (m *MockBookInteractor) RestoreBook(ctx context.Context, id string) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreBook", ctx, id)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreBook indicates an expected call of RestoreBook.
+func (mr *MockBookInteractorMockRecorder) RestoreBook(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBooks", reflect.TypeOf((*MockBookInteractor)(nil).ListBooks))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreBook", reflect.TypeOf((*MockBookInteractor)(nil).RestoreBook), ctx, id)
 }
 
 // UpdateBook mocks base method.
-func (m *MockBookInteractor) UpdateBook(book *domain.Book) error {
+func (m *MockBookInteractor) UpdateBook(ctx context.Context, book *domain.Book) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBook", book)
+	ret := m.ctrl.Call(m, "UpdateBook", ctx, book)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateBook indicates an expected call of UpdateBook.
-func (mr *MockBookInteractorMockRecorder) UpdateBook(book any) *gomock.Call {
+func (mr *MockBookInteractorMockRecorder) UpdateBook(ctx, book any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBook", reflect.TypeOf((*MockBookInteractor)(nil).UpdateBook), book)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBook", reflect.TypeOf((*MockBookInteractor)(nil).UpdateBook), ctx, book)
 }
 
 // MockBookPresenter is a mock of BookPresenter interface.
@@ -137,17 +243,83 @@ func (m *MockBookPresenter) EXPECT() *MockBookPresenterMockRecorder {
 }
 
 // Present mocks base method.
-func (m *MockBookPresenter) Present(book *domain.Book) map[string]any {
+func (m *MockBookPresenter) Present(book *domain.Book, preferred ...language.Tag) map[string]any {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Present", book)
+	varargs := []any{book}
+	for _, a := range preferred {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Present", varargs...)
 	ret0, _ := ret[0].(map[string]any)
 	return ret0
 }
 
 // Present indicates an expected call of Present.
-func (mr *MockBookPresenterMockRecorder) Present(book any) *gomock.Call {
+func (mr *MockBookPresenterMockRecorder) Present(book any, preferred ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockBookPresenter)(nil).Present), book)
+	varargs := append([]any{book}, preferred...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockBookPresenter)(nil).Present), varargs...)
+}
+
+// PresentExport mocks base method.
+func (m *MockBookPresenter) PresentExport(exported *domain.ExportedBook) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresentExport", exported)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// PresentExport indicates an expected call of PresentExport.
+func (mr *MockBookPresenterMockRecorder) PresentExport(exported any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentExport", reflect.TypeOf((*MockBookPresenter)(nil).PresentExport), exported)
+}
+
+// PresentHistory mocks base method.
+func (m *MockBookPresenter) PresentHistory(page *domain.AuditPage) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresentHistory", page)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// PresentHistory indicates an expected call of PresentHistory.
+func (mr *MockBookPresenterMockRecorder) PresentHistory(page any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentHistory", reflect.TypeOf((*MockBookPresenter)(nil).PresentHistory), page)
+}
+
+// PresentImport mocks base method.
+func (m *MockBookPresenter) PresentImport(report *domain.ImportReport) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresentImport", report)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// PresentImport indicates an expected call of PresentImport.
+func (mr *MockBookPresenterMockRecorder) PresentImport(report any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentImport", reflect.TypeOf((*MockBookPresenter)(nil).PresentImport), report)
+}
+
+// PresentPage mocks base method.
+func (m *MockBookPresenter) PresentPage(page *domain.BookPage, preferred ...language.Tag) map[string]any {
+	m.ctrl.T.Helper()
+	varargs := []any{page}
+	for _, a := range preferred {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PresentPage", varargs...)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// PresentPage indicates an expected call of PresentPage.
+func (mr *MockBookPresenterMockRecorder) PresentPage(page any, preferred ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{page}, preferred...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentPage", reflect.TypeOf((*MockBookPresenter)(nil).PresentPage), varargs...)
 }
 
 // MockErrorPresenter is a mock of ErrorPresenter interface.
@@ -174,13 +346,60 @@ func (m *MockErrorPresenter) EXPECT() *MockErrorPresenterMockRecorder {
 }
 
 // Present mocks base method.
-func (m *MockErrorPresenter) Present(w http.ResponseWriter, err error, code int) {
+func (m *MockErrorPresenter) Present(w http.ResponseWriter, r *http.Request, err error, code int) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "Present", w, err, code)
+	m.ctrl.Call(m, "Present", w, r, err, code)
 }
 
 // Present indicates an expected call of Present.
-func (mr *MockErrorPresenterMockRecorder) Present(w, err, code any) *gomock.Call {
+func (mr *MockErrorPresenterMockRecorder) Present(w, r, err, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockErrorPresenter)(nil).Present), w, r, err, code)
+}
+
+// MockResponsePresenter is a mock of ResponsePresenter interface.
+type MockResponsePresenter struct {
+	ctrl     *gomock.Controller
+	recorder *MockResponsePresenterMockRecorder
+}
+
+// MockResponsePresenterMockRecorder is the mock recorder for MockResponsePresenter.
+type MockResponsePresenterMockRecorder struct {
+	mock *MockResponsePresenter
+}
+
+// NewMockResponsePresenter creates a new mock instance.
+func NewMockResponsePresenter(ctrl *gomock.Controller) *MockResponsePresenter {
+	mock := &MockResponsePresenter{ctrl: ctrl}
+	mock.recorder = &MockResponsePresenterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockResponsePresenter) EXPECT() *MockResponsePresenterMockRecorder {
+	return m.recorder
+}
+
+// Present mocks base method.
+func (m *MockResponsePresenter) Present(w http.ResponseWriter, r *http.Request, status int, view any) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Present", w, r, status, view)
+}
+
+// Present indicates an expected call of Present.
+func (mr *MockResponsePresenterMockRecorder) Present(w, r, status, view any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockResponsePresenter)(nil).Present), w, r, status, view)
+}
+
+// PresentList mocks base method.
+func (m *MockResponsePresenter) PresentList(w http.ResponseWriter, r *http.Request, status int, view any, items string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "PresentList", w, r, status, view, items)
+}
+
+// PresentList indicates an expected call of PresentList.
+func (mr *MockResponsePresenterMockRecorder) PresentList(w, r, status, view, items any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockErrorPresenter)(nil).Present), w, err, code)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentList", reflect.TypeOf((*MockResponsePresenter)(nil).PresentList), w, r, status, view, items)
 }
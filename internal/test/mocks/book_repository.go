@@ -10,7 +10,9 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -42,73 +44,187 @@ func (m *MockBookRepository) EXPECT() *MockBookRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockBookRepository) Create(book *domain.Book) error {
+func (m *MockBookRepository) Create(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", book)
+	varargs := []any{ctx, book}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Create", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockBookRepositoryMockRecorder) Create(book any) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) Create(ctx, book any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookRepository)(nil).Create), book)
+	varargs := append([]any{ctx, book}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockBookRepository)(nil).Create), varargs...)
 }
 
-// Delete mocks base method.
-func (m *MockBookRepository) Delete(id uuid.UUID) error {
+// CreateAll mocks base method.
+func (m *MockBookRepository) CreateAll(ctx context.Context, books []*domain.Book, events ...*domain.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	varargs := []any{ctx, books}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateAll", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// CreateAll indicates an expected call of CreateAll.
+func (mr *MockBookRepositoryMockRecorder) CreateAll(ctx, books any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, books}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAll", reflect.TypeOf((*MockBookRepository)(nil).CreateAll), varargs...)
+}
+
+// Delete mocks base method.
+func (m *MockBookRepository) Delete(ctx context.Context, id uuid.UUID, events ...*domain.Event) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Delete", varargs...)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // Delete indicates an expected call of Delete.
-func (mr *MockBookRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) Delete(ctx, id any, events ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, id}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookRepository)(nil).Delete), varargs...)
+}
+
+// Each mocks base method.
+func (m *MockBookRepository) Each(ctx context.Context, withTrashed bool, fn func(*domain.Book) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Each", ctx, withTrashed, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Each indicates an expected call of Each.
+func (mr *MockBookRepositoryMockRecorder) Each(ctx, withTrashed, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Each", reflect.TypeOf((*MockBookRepository)(nil).Each), ctx, withTrashed, fn)
+}
+
+// Purge mocks base method.
+func (m *MockBookRepository) Purge(ctx context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, deletedBefore)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockBookRepositoryMockRecorder) Purge(ctx, deletedBefore any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBookRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockBookRepository)(nil).Purge), ctx, deletedBefore)
 }
 
 // ReadAll mocks base method.
-func (m *MockBookRepository) ReadAll() ([]*domain.Book, error) {
+func (m *MockBookRepository) ReadAll(ctx context.Context) ([]*domain.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadAll")
+	ret := m.ctrl.Call(m, "ReadAll", ctx)
 	ret0, _ := ret[0].([]*domain.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadAll indicates an expected call of ReadAll.
-func (mr *MockBookRepositoryMockRecorder) ReadAll() *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) ReadAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockBookRepository)(nil).ReadAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadAll", reflect.TypeOf((*MockBookRepository)(nil).ReadAll), ctx)
 }
 
 // ReadByID mocks base method.
-func (m *MockBookRepository) ReadByID(id uuid.UUID) (*domain.Book, error) {
+func (m *MockBookRepository) ReadByID(ctx context.Context, id uuid.UUID) (*domain.Book, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ReadByID", id)
+	ret := m.ctrl.Call(m, "ReadByID", ctx, id)
 	ret0, _ := ret[0].(*domain.Book)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ReadByID indicates an expected call of ReadByID.
-func (mr *MockBookRepositoryMockRecorder) ReadByID(id any) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) ReadByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByID", reflect.TypeOf((*MockBookRepository)(nil).ReadByID), ctx, id)
+}
+
+// ReadByISBN mocks base method.
+func (m *MockBookRepository) ReadByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByISBN", ctx, isbn)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByISBN indicates an expected call of ReadByISBN.
+func (mr *MockBookRepositoryMockRecorder) ReadByISBN(ctx, isbn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByISBN", reflect.TypeOf((*MockBookRepository)(nil).ReadByISBN), ctx, isbn)
+}
+
+// ReadPage mocks base method.
+func (m *MockBookRepository) ReadPage(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadPage", ctx, query)
+	ret0, _ := ret[0].(*domain.BookPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadPage indicates an expected call of ReadPage.
+func (mr *MockBookRepositoryMockRecorder) ReadPage(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadPage", reflect.TypeOf((*MockBookRepository)(nil).ReadPage), ctx, query)
+}
+
+// Restore mocks base method.
+func (m *MockBookRepository) Restore(ctx context.Context, id uuid.UUID, events ...*domain.Event) (*domain.Book, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, id}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Restore", varargs...)
+	ret0, _ := ret[0].(*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockBookRepositoryMockRecorder) Restore(ctx, id any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByID", reflect.TypeOf((*MockBookRepository)(nil).ReadByID), id)
+	varargs := append([]any{ctx, id}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockBookRepository)(nil).Restore), varargs...)
 }
 
 // Update mocks base method.
-func (m *MockBookRepository) Update(book *domain.Book) error {
+func (m *MockBookRepository) Update(ctx context.Context, book *domain.Book, events ...*domain.Event) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", book)
+	varargs := []any{ctx, book}
+	for _, a := range events {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Update", varargs...)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockBookRepositoryMockRecorder) Update(book any) *gomock.Call {
+func (mr *MockBookRepositoryMockRecorder) Update(ctx, book any, events ...any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), book)
+	varargs := append([]any{ctx, book}, events...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockBookRepository)(nil).Update), varargs...)
 }
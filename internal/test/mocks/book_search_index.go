@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../domain/book_search.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source ../domain/book_search.go -destination mocks/book_search_index.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBookSearchIndex is a mock of BookSearchIndex interface.
+type MockBookSearchIndex struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookSearchIndexMockRecorder
+}
+
+// MockBookSearchIndexMockRecorder is the mock recorder for MockBookSearchIndex.
+type MockBookSearchIndexMockRecorder struct {
+	mock *MockBookSearchIndex
+}
+
+// NewMockBookSearchIndex creates a new mock instance.
+func NewMockBookSearchIndex(ctrl *gomock.Controller) *MockBookSearchIndex {
+	mock := &MockBookSearchIndex{ctrl: ctrl}
+	mock.recorder = &MockBookSearchIndexMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookSearchIndex) EXPECT() *MockBookSearchIndexMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockBookSearchIndex) Search(ctx context.Context, query string, limit int) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockBookSearchIndexMockRecorder) Search(ctx, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockBookSearchIndex)(nil).Search), ctx, query, limit)
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../interfaces/controller/book_search_http_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source ../interfaces/controller/book_search_http_controller.go -destination mocks/book_search_interactor.go BookSearchInteractor
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// MockBookSearchInteractor is a mock of BookSearchInteractor interface.
+type MockBookSearchInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockBookSearchInteractorMockRecorder
+}
+
+// MockBookSearchInteractorMockRecorder is the mock recorder for MockBookSearchInteractor.
+type MockBookSearchInteractorMockRecorder struct {
+	mock *MockBookSearchInteractor
+}
+
+// NewMockBookSearchInteractor creates a new mock instance.
+func NewMockBookSearchInteractor(ctrl *gomock.Controller) *MockBookSearchInteractor {
+	mock := &MockBookSearchInteractor{ctrl: ctrl}
+	mock.recorder = &MockBookSearchInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBookSearchInteractor) EXPECT() *MockBookSearchInteractorMockRecorder {
+	return m.recorder
+}
+
+// SearchBooks mocks base method.
+func (m *MockBookSearchInteractor) SearchBooks(ctx context.Context, query string, limit int) ([]*domain.Book, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchBooks", ctx, query, limit)
+	ret0, _ := ret[0].([]*domain.Book)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchBooks indicates an expected call of SearchBooks.
+func (mr *MockBookSearchInteractorMockRecorder) SearchBooks(ctx, query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchBooks", reflect.TypeOf((*MockBookSearchInteractor)(nil).SearchBooks), ctx, query, limit)
+}
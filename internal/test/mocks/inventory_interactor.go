@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../interfaces/controller/inventory_http_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source ../interfaces/controller/inventory_http_controller.go -destination mocks/inventory_interactor.go InventoryInteractor
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// MockInventoryInteractor is a mock of InventoryInteractor interface.
+type MockInventoryInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockInventoryInteractorMockRecorder
+}
+
+// MockInventoryInteractorMockRecorder is the mock recorder for MockInventoryInteractor.
+type MockInventoryInteractorMockRecorder struct {
+	mock *MockInventoryInteractor
+}
+
+// NewMockInventoryInteractor creates a new mock instance.
+func NewMockInventoryInteractor(ctrl *gomock.Controller) *MockInventoryInteractor {
+	mock := &MockInventoryInteractor{ctrl: ctrl}
+	mock.recorder = &MockInventoryInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInventoryInteractor) EXPECT() *MockInventoryInteractorMockRecorder {
+	return m.recorder
+}
+
+// AdjustStock mocks base method.
+func (m *MockInventoryInteractor) AdjustStock(ctx context.Context, id string, delta int) (*domain.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustStock", ctx, id, delta)
+	ret0, _ := ret[0].(*domain.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustStock indicates an expected call of AdjustStock.
+func (mr *MockInventoryInteractorMockRecorder) AdjustStock(ctx, id, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustStock", reflect.TypeOf((*MockInventoryInteractor)(nil).AdjustStock), ctx, id, delta)
+}
+
+// GetStock mocks base method.
+func (m *MockInventoryInteractor) GetStock(ctx context.Context, id string) (*domain.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStock", ctx, id)
+	ret0, _ := ret[0].(*domain.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStock indicates an expected call of GetStock.
+func (mr *MockInventoryInteractorMockRecorder) GetStock(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStock", reflect.TypeOf((*MockInventoryInteractor)(nil).GetStock), ctx, id)
+}
+
+// ReceiveStock mocks base method.
+func (m *MockInventoryInteractor) ReceiveStock(ctx context.Context, id string, quantity int) (*domain.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReceiveStock", ctx, id, quantity)
+	ret0, _ := ret[0].(*domain.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReceiveStock indicates an expected call of ReceiveStock.
+func (mr *MockInventoryInteractorMockRecorder) ReceiveStock(ctx, id, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReceiveStock", reflect.TypeOf((*MockInventoryInteractor)(nil).ReceiveStock), ctx, id, quantity)
+}
+
+// ReserveStock mocks base method.
+func (m *MockInventoryInteractor) ReserveStock(ctx context.Context, id string, quantity int) (*domain.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveStock", ctx, id, quantity)
+	ret0, _ := ret[0].(*domain.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReserveStock indicates an expected call of ReserveStock.
+func (mr *MockInventoryInteractorMockRecorder) ReserveStock(ctx, id, quantity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockInventoryInteractor)(nil).ReserveStock), ctx, id, quantity)
+}
+
+// MockStockPresenter is a mock of StockPresenter interface.
+type MockStockPresenter struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockPresenterMockRecorder
+}
+
+// MockStockPresenterMockRecorder is the mock recorder for MockStockPresenter.
+type MockStockPresenterMockRecorder struct {
+	mock *MockStockPresenter
+}
+
+// NewMockStockPresenter creates a new mock instance.
+func NewMockStockPresenter(ctrl *gomock.Controller) *MockStockPresenter {
+	mock := &MockStockPresenter{ctrl: ctrl}
+	mock.recorder = &MockStockPresenterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockPresenter) EXPECT() *MockStockPresenterMockRecorder {
+	return m.recorder
+}
+
+// Present mocks base method.
+func (m *MockStockPresenter) Present(stock *domain.Stock) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Present", stock)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// Present indicates an expected call of Present.
+func (mr *MockStockPresenterMockRecorder) Present(stock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockStockPresenter)(nil).Present), stock)
+}
@@ -0,0 +1,169 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../interfaces/controller/order_http_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source ../interfaces/controller/order_http_controller.go -destination mocks/order_interactor.go OrderInteractor
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// MockOrderInteractor is a mock of OrderInteractor interface.
+type MockOrderInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderInteractorMockRecorder
+}
+
+// MockOrderInteractorMockRecorder is the mock recorder for MockOrderInteractor.
+type MockOrderInteractorMockRecorder struct {
+	mock *MockOrderInteractor
+}
+
+// NewMockOrderInteractor creates a new mock instance.
+func NewMockOrderInteractor(ctrl *gomock.Controller) *MockOrderInteractor {
+	mock := &MockOrderInteractor{ctrl: ctrl}
+	mock.recorder = &MockOrderInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderInteractor) EXPECT() *MockOrderInteractorMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderInteractor) CancelOrder(ctx context.Context, id string) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderInteractorMockRecorder) CancelOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderInteractor)(nil).CancelOrder), ctx, id)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderInteractor) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderInteractorMockRecorder) GetOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderInteractor)(nil).GetOrder), ctx, id)
+}
+
+// ListOrders mocks base method.
+func (m *MockOrderInteractor) ListOrders(ctx context.Context) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderInteractorMockRecorder) ListOrders(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderInteractor)(nil).ListOrders), ctx)
+}
+
+// PayOrder mocks base method.
+func (m *MockOrderInteractor) PayOrder(ctx context.Context, id string) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PayOrder", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PayOrder indicates an expected call of PayOrder.
+func (mr *MockOrderInteractorMockRecorder) PayOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PayOrder", reflect.TypeOf((*MockOrderInteractor)(nil).PayOrder), ctx, id)
+}
+
+// PlaceOrder mocks base method.
+func (m *MockOrderInteractor) PlaceOrder(ctx context.Context, items []domain.OrderItem) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceOrder", ctx, items)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceOrder indicates an expected call of PlaceOrder.
+func (mr *MockOrderInteractorMockRecorder) PlaceOrder(ctx, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockOrderInteractor)(nil).PlaceOrder), ctx, items)
+}
+
+// ShipOrder mocks base method.
+func (m *MockOrderInteractor) ShipOrder(ctx context.Context, id string) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShipOrder", ctx, id)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShipOrder indicates an expected call of ShipOrder.
+func (mr *MockOrderInteractorMockRecorder) ShipOrder(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipOrder", reflect.TypeOf((*MockOrderInteractor)(nil).ShipOrder), ctx, id)
+}
+
+// MockOrderPresenter is a mock of OrderPresenter interface.
+type MockOrderPresenter struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderPresenterMockRecorder
+}
+
+// MockOrderPresenterMockRecorder is the mock recorder for MockOrderPresenter.
+type MockOrderPresenterMockRecorder struct {
+	mock *MockOrderPresenter
+}
+
+// NewMockOrderPresenter creates a new mock instance.
+func NewMockOrderPresenter(ctrl *gomock.Controller) *MockOrderPresenter {
+	mock := &MockOrderPresenter{ctrl: ctrl}
+	mock.recorder = &MockOrderPresenterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderPresenter) EXPECT() *MockOrderPresenterMockRecorder {
+	return m.recorder
+}
+
+// Present mocks base method.
+func (m *MockOrderPresenter) Present(order *domain.Order) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Present", order)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// Present indicates an expected call of Present.
+func (mr *MockOrderPresenterMockRecorder) Present(order any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockOrderPresenter)(nil).Present), order)
+}
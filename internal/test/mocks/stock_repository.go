@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../domain/stock.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source ../domain/stock.go -destination mocks/stock_repository.go
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// MockStockRepository is a mock of StockRepository interface.
+type MockStockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStockRepositoryMockRecorder
+}
+
+// MockStockRepositoryMockRecorder is the mock recorder for MockStockRepository.
+type MockStockRepositoryMockRecorder struct {
+	mock *MockStockRepository
+}
+
+// NewMockStockRepository creates a new mock instance.
+func NewMockStockRepository(ctrl *gomock.Controller) *MockStockRepository {
+	mock := &MockStockRepository{ctrl: ctrl}
+	mock.recorder = &MockStockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStockRepository) EXPECT() *MockStockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockStockRepository) Create(ctx context.Context, stock *domain.Stock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, stock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockStockRepositoryMockRecorder) Create(ctx, stock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockStockRepository)(nil).Create), ctx, stock)
+}
+
+// Delete mocks base method.
+func (m *MockStockRepository) Delete(ctx context.Context, bookID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, bookID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStockRepositoryMockRecorder) Delete(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStockRepository)(nil).Delete), ctx, bookID)
+}
+
+// ReadByBookID mocks base method.
+func (m *MockStockRepository) ReadByBookID(ctx context.Context, bookID uuid.UUID) (*domain.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadByBookID", ctx, bookID)
+	ret0, _ := ret[0].(*domain.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadByBookID indicates an expected call of ReadByBookID.
+func (mr *MockStockRepositoryMockRecorder) ReadByBookID(ctx, bookID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadByBookID", reflect.TypeOf((*MockStockRepository)(nil).ReadByBookID), ctx, bookID)
+}
+
+// Update mocks base method.
+func (m *MockStockRepository) Update(ctx context.Context, bookID uuid.UUID, change func(*domain.Stock) error) (*domain.Stock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, bookID, change)
+	ret0, _ := ret[0].(*domain.Stock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockStockRepositoryMockRecorder) Update(ctx, bookID, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockStockRepository)(nil).Update), ctx, bookID, change)
+}
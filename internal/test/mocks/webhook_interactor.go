@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../interfaces/controller/webhook_http_controller.go
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -source ../interfaces/controller/webhook_http_controller.go -destination mocks/webhook_interactor.go WebhookInteractor
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+
+	domain "github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// MockWebhookInteractor is a mock of WebhookInteractor interface.
+type MockWebhookInteractor struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookInteractorMockRecorder
+}
+
+// MockWebhookInteractorMockRecorder is the mock recorder for MockWebhookInteractor.
+type MockWebhookInteractorMockRecorder struct {
+	mock *MockWebhookInteractor
+}
+
+// NewMockWebhookInteractor creates a new mock instance.
+func NewMockWebhookInteractor(ctrl *gomock.Controller) *MockWebhookInteractor {
+	mock := &MockWebhookInteractor{ctrl: ctrl}
+	mock.recorder = &MockWebhookInteractorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookInteractor) EXPECT() *MockWebhookInteractorMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhook mocks base method.
+func (m *MockWebhookInteractor) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhook", ctx, webhook)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWebhook indicates an expected call of CreateWebhook.
+func (mr *MockWebhookInteractorMockRecorder) CreateWebhook(ctx, webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhook", reflect.TypeOf((*MockWebhookInteractor)(nil).CreateWebhook), ctx, webhook)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhookInteractor) DeleteWebhook(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookInteractorMockRecorder) DeleteWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhookInteractor)(nil).DeleteWebhook), ctx, id)
+}
+
+// GetWebhook mocks base method.
+func (m *MockWebhookInteractor) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhook", ctx, id)
+	ret0, _ := ret[0].(*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhook indicates an expected call of GetWebhook.
+func (mr *MockWebhookInteractorMockRecorder) GetWebhook(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhook", reflect.TypeOf((*MockWebhookInteractor)(nil).GetWebhook), ctx, id)
+}
+
+// ListDeliveries mocks base method.
+func (m *MockWebhookInteractor) ListDeliveries(ctx context.Context, id string) ([]*domain.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeliveries", ctx, id)
+	ret0, _ := ret[0].([]*domain.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDeliveries indicates an expected call of ListDeliveries.
+func (mr *MockWebhookInteractorMockRecorder) ListDeliveries(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeliveries", reflect.TypeOf((*MockWebhookInteractor)(nil).ListDeliveries), ctx, id)
+}
+
+// ListWebhooks mocks base method.
+func (m *MockWebhookInteractor) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhooks", ctx)
+	ret0, _ := ret[0].([]*domain.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhooks indicates an expected call of ListWebhooks.
+func (mr *MockWebhookInteractorMockRecorder) ListWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhooks", reflect.TypeOf((*MockWebhookInteractor)(nil).ListWebhooks), ctx)
+}
+
+// MockWebhookPresenter is a mock of WebhookPresenter interface.
+type MockWebhookPresenter struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookPresenterMockRecorder
+}
+
+// MockWebhookPresenterMockRecorder is the mock recorder for MockWebhookPresenter.
+type MockWebhookPresenterMockRecorder struct {
+	mock *MockWebhookPresenter
+}
+
+// NewMockWebhookPresenter creates a new mock instance.
+func NewMockWebhookPresenter(ctrl *gomock.Controller) *MockWebhookPresenter {
+	mock := &MockWebhookPresenter{ctrl: ctrl}
+	mock.recorder = &MockWebhookPresenterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookPresenter) EXPECT() *MockWebhookPresenterMockRecorder {
+	return m.recorder
+}
+
+// Present mocks base method.
+func (m *MockWebhookPresenter) Present(webhook *domain.Webhook) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Present", webhook)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// Present indicates an expected call of Present.
+func (mr *MockWebhookPresenterMockRecorder) Present(webhook any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Present", reflect.TypeOf((*MockWebhookPresenter)(nil).Present), webhook)
+}
+
+// PresentDelivery mocks base method.
+func (m *MockWebhookPresenter) PresentDelivery(delivery *domain.Delivery) map[string]any {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PresentDelivery", delivery)
+	ret0, _ := ret[0].(map[string]any)
+	return ret0
+}
+
+// PresentDelivery indicates an expected call of PresentDelivery.
+func (mr *MockWebhookPresenterMockRecorder) PresentDelivery(delivery any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PresentDelivery", reflect.TypeOf((*MockWebhookPresenter)(nil).PresentDelivery), delivery)
+}
@@ -0,0 +1,190 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+)
+
+// AuthorInteractor handles author business logic.
+type AuthorInteractor struct {
+	authors domain.AuthorRepository
+	books   domain.BookRepository
+	audit   domain.AuditRepository
+	credits *CreditLock
+	logger  *slog.Logger
+}
+
+// NewAuthorInteractor creates a new AuthorInteractor.
+// Authors are deleted under credits, shared with the BookInteractor.
+// The bylines changed by a rename are recorded in audit, like the other changes to books.
+func NewAuthorInteractor(
+	logger *slog.Logger,
+	authors domain.AuthorRepository,
+	books domain.BookRepository,
+	audit domain.AuditRepository,
+	credits *CreditLock,
+) *AuthorInteractor {
+	return &AuthorInteractor{authors: authors, books: books, audit: audit, credits: credits, logger: logger}
+}
+
+// CreateAuthor sends the author to be created to the underlying repository.
+func (ai *AuthorInteractor) CreateAuthor(ctx context.Context, author *domain.Author) error {
+	return ai.authors.Create(ctx, author)
+}
+
+// GetAuthor retrieves a domain.Author by its ID.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+func (ai *AuthorInteractor) GetAuthor(ctx context.Context, id string) (*domain.Author, error) {
+	uid, err := domain.AuthorResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	a, err := ai.authors.ReadByID(ctx, uid)
+	if db.IsAuthorNotFoundError(err) {
+		return nil, domain.ErrAuthorNotFound
+	}
+	return a, err
+}
+
+// ListAuthors retrieves every author, sorted by name.
+// Does not fail if nothing is found.
+func (ai *AuthorInteractor) ListAuthors(ctx context.Context) ([]*domain.Author, error) {
+	return ai.authors.ReadAll(ctx)
+}
+
+// RenameAuthor changes the name of the author matching id and returns the updated author.
+// Books whose byline was derived from their credits get a byline with the new name,
+// raising domain.EventBookUpdated and recorded in the audit log. Books modified concurrently keep their byline.
+func (ai *AuthorInteractor) RenameAuthor(ctx context.Context, id, name string) (*domain.Author, error) {
+	author, err := ai.GetAuthor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	books, err := ai.creditedBooks(ctx, author.ID, false, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err = fillCredits(ctx, ai.authors, books...); err != nil {
+		return nil, err
+	}
+
+	author.Name = name
+	err = ai.authors.Update(ctx, author)
+	if db.IsAuthorNotFoundError(err) {
+		return nil, domain.ErrAuthorNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, b := range books {
+		before := *b
+		before.Authors = slices.Clone(b.Authors)
+		byline := domain.Byline(b.Authors)
+		for i := range b.Authors {
+			if b.Authors[i].AuthorID == author.ID {
+				b.Authors[i].Name = name
+			}
+		}
+		if b.Author != byline {
+			continue
+		}
+		b.Author = domain.Byline(b.Authors)
+		err = ai.books.Update(ctx, b, domain.NewBookUpdated())
+		if errors.Is(err, domain.ErrVersionConflict) || db.IsNotFoundError(err) {
+			ai.logger.With("book_id", b.ID, "author_id", author.ID).Warn("book changed while renaming its author")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err = recordBookChange(ctx, ai.audit, ai.logger, domain.AuditUpdate, &before, b); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return author, errors.Join(errs...)
+}
+
+// DeleteAuthor removes an author from the repository.
+// Authors still credited on books, in the catalog or in the trash, can't be deleted,
+// domain.ErrAuthorHasBooks is returned.
+// No book can credit the author between the check and the delete.
+func (ai *AuthorInteractor) DeleteAuthor(ctx context.Context, id string) error {
+	uid, err := domain.AuthorResource.Parse(id)
+	if err != nil {
+		return err
+	}
+	ai.credits.mu.Lock()
+	defer ai.credits.mu.Unlock()
+	// Trashed books count too: restoring them would bring back a credit of a missing author.
+	for _, trashed := range []bool{false, true} {
+		books, err := ai.creditedBooks(ctx, uid, trashed, 1)
+		if err != nil {
+			return err
+		}
+		if len(books) > 0 {
+			return domain.ErrAuthorHasBooks
+		}
+	}
+	err = ai.authors.Delete(ctx, uid)
+	if db.IsAuthorNotFoundError(err) {
+		return domain.ErrAuthorNotFound
+	}
+	return err
+}
+
+// creditedBooks returns at most limit books crediting the author with id, or all of them if limit is zero.
+// The books are read from the trash if trashed is set, from the catalog otherwise.
+func (ai *AuthorInteractor) creditedBooks(
+	ctx context.Context,
+	id uuid.UUID,
+	trashed bool,
+	limit int,
+) ([]*domain.Book, error) {
+	var books []*domain.Book
+	q := domain.BookQuery{Filter: domain.BookFilter{AuthorID: id}, Limit: domain.MaxBookPageSize, Trashed: trashed}
+	for {
+		page, err := ai.books.ReadPage(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, page.Books...)
+		if page.NextCursor == "" || (limit > 0 && len(books) >= limit) {
+			return books, nil
+		}
+		q.Cursor = page.NextCursor
+	}
+}
+
+// fillCredits sets the name of every credit of books from the authors repository.
+// Credits of authors deleted in the meantime are left without a name.
+func fillCredits(ctx context.Context, authors domain.AuthorRepository, books ...*domain.Book) error {
+	names := make(map[uuid.UUID]string)
+	for _, b := range books {
+		for i := range b.Authors {
+			c := &b.Authors[i]
+			name, ok := names[c.AuthorID]
+			if !ok {
+				a, err := authors.ReadByID(ctx, c.AuthorID)
+				switch {
+				case db.IsAuthorNotFoundError(err):
+				case err != nil:
+					return err
+				default:
+					name = a.Name
+				}
+				names[c.AuthorID] = name
+			}
+			c.Name = name
+		}
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+func TestAuthorInteractor_DeleteAuthor(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	authorID := uuid.New()
+	byAuthor := domain.BookQuery{Filter: domain.BookFilter{AuthorID: authorID}, Limit: domain.MaxBookPageSize}
+	trashedByAuthor := byAuthor
+	trashedByAuthor.Trashed = true
+
+	tests := []struct {
+		name             string
+		id               string
+		wantErr          error
+		mockExpectations func(*mocks.MockAuthorRepository, *mocks.MockBookRepository)
+	}{
+		{
+			name:    "fails to parse id",
+			id:      "invalid",
+			wantErr: domain.ErrInvalidAuthorID,
+		},
+		{
+			name: "fails if the author still has books",
+			id:   authorID.String(),
+			mockExpectations: func(_ *mocks.MockAuthorRepository, books *mocks.MockBookRepository) {
+				books.EXPECT().
+					ReadPage(ctx, byAuthor).
+					Return(&domain.BookPage{Books: []*domain.Book{{ID: uuid.New()}}, Total: 1}, nil)
+			},
+			wantErr: domain.ErrAuthorHasBooks,
+		},
+		{
+			name: "fails if the author still has books in the trash",
+			id:   authorID.String(),
+			mockExpectations: func(_ *mocks.MockAuthorRepository, books *mocks.MockBookRepository) {
+				books.EXPECT().ReadPage(ctx, byAuthor).Return(&domain.BookPage{}, nil)
+				books.EXPECT().
+					ReadPage(ctx, trashedByAuthor).
+					Return(&domain.BookPage{Books: []*domain.Book{{ID: uuid.New()}}, Total: 1}, nil)
+			},
+			wantErr: domain.ErrAuthorHasBooks,
+		},
+		{
+			name: "fails with author not found error",
+			id:   authorID.String(),
+			mockExpectations: func(authors *mocks.MockAuthorRepository, books *mocks.MockBookRepository) {
+				books.EXPECT().ReadPage(ctx, byAuthor).Return(&domain.BookPage{}, nil)
+				books.EXPECT().ReadPage(ctx, trashedByAuthor).Return(&domain.BookPage{}, nil)
+				authors.EXPECT().Delete(ctx, authorID).Return(errors.New("author not found"))
+			},
+			wantErr: domain.ErrAuthorNotFound,
+		},
+		{
+			name: "succeeds",
+			id:   authorID.String(),
+			mockExpectations: func(authors *mocks.MockAuthorRepository, books *mocks.MockBookRepository) {
+				books.EXPECT().ReadPage(ctx, byAuthor).Return(&domain.BookPage{}, nil)
+				books.EXPECT().ReadPage(ctx, trashedByAuthor).Return(&domain.BookPage{}, nil)
+				authors.EXPECT().Delete(ctx, authorID).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			mockAuthorRepository := mocks.NewMockAuthorRepository(mockCtl)
+			mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockAuthorRepository, mockBookRepository)
+			}
+			ai := interactor.NewAuthorInteractor(
+				logger, mockAuthorRepository, mockBookRepository, mocks.NewMockAuditRepository(mockCtl),
+				interactor.NewCreditLock(),
+			)
+
+			err := ai.DeleteAuthor(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("DeleteAuthor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAuthorInteractor_DeleteAuthor_WhileCredited(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockAuthorRepository := mocks.NewMockAuthorRepository(mockCtl)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	author := &domain.Author{ID: uuid.New(), Name: "Umberto Eco"}
+	credits := interactor.NewCreditLock()
+	bi := interactor.NewBookInteractor(
+		logger, mockBookRepository, mockStockRepository, mockAuthorRepository, mockAuditRepository, credits,
+	)
+	ai := interactor.NewAuthorInteractor(logger, mockAuthorRepository, mockBookRepository, mockAuditRepository, credits)
+
+	var (
+		mu      sync.Mutex
+		created []*domain.Book
+	)
+	crediting, resume := make(chan struct{}), make(chan struct{})
+	mockAuthorRepository.EXPECT().
+		ReadByID(gomock.Any(), author.ID).
+		DoAndReturn(func(context.Context, uuid.UUID) (*domain.Author, error) {
+			close(crediting)
+			<-resume
+			return author, nil
+		})
+	mockBookRepository.EXPECT().
+		Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, b *domain.Book, _ ...*domain.Event) error {
+			mu.Lock()
+			defer mu.Unlock()
+			created = append(created, b)
+			return nil
+		})
+	mockAuditRepository.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil)
+	mockStockRepository.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+	mockBookRepository.EXPECT().
+		ReadPage(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(context.Context, domain.BookQuery) (*domain.BookPage, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return &domain.BookPage{Books: created, Total: len(created)}, nil
+		})
+	// Only called if the author is deleted while the book is created, which the test reports.
+	mockAuthorRepository.EXPECT().Delete(gomock.Any(), author.ID).Return(nil).MaxTimes(1)
+
+	createErr := make(chan error)
+	go func() {
+		createErr <- bi.CreateBook(ctx, &domain.Book{
+			Title:   "Il nome della rosa",
+			Authors: []domain.BookAuthor{{AuthorID: author.ID, Role: domain.RoleAuthor}},
+		})
+	}()
+	<-crediting
+	deleteErr := make(chan error)
+	go func() {
+		deleteErr <- ai.DeleteAuthor(ctx, author.ID.String())
+	}()
+	select {
+	case err := <-deleteErr:
+		t.Fatalf("DeleteAuthor() returned %v while a book crediting the author was created", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(resume)
+
+	if err := <-createErr; err != nil {
+		t.Fatalf("CreateBook() error = %v", err)
+	}
+	if err := <-deleteErr; !errors.Is(err, domain.ErrAuthorHasBooks) {
+		t.Errorf("DeleteAuthor() error = %v, want %v", err, domain.ErrAuthorHasBooks)
+	}
+}
+
+func TestAuthorInteractor_RenameAuthor(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockAuthorRepository := mocks.NewMockAuthorRepository(mockCtl)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	tolkien := &domain.Author{ID: uuid.New(), Name: "Tolkien"}
+	illustrator := &domain.Author{ID: uuid.New(), Name: "Alan Lee"}
+	credits := func() []domain.BookAuthor {
+		return []domain.BookAuthor{
+			{AuthorID: tolkien.ID, Role: domain.RoleAuthor},
+			{AuthorID: illustrator.ID, Role: domain.RoleIllustrator},
+		}
+	}
+	derived := &domain.Book{ID: uuid.New(), Author: "Tolkien", Authors: credits(), Version: 3}
+	custom := &domain.Book{ID: uuid.New(), Author: "J. R. R. Tolkien", Authors: credits(), Version: 1}
+	names := map[uuid.UUID]string{tolkien.ID: tolkien.Name, illustrator.ID: illustrator.Name}
+
+	mockAuthorRepository.EXPECT().
+		ReadByID(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (*domain.Author, error) {
+			return &domain.Author{ID: id, Name: names[id]}, nil
+		}).
+		AnyTimes()
+	mockBookRepository.EXPECT().
+		ReadPage(ctx, domain.BookQuery{Filter: domain.BookFilter{AuthorID: tolkien.ID}, Limit: domain.MaxBookPageSize}).
+		Return(&domain.BookPage{Books: []*domain.Book{derived, custom}, Total: 2}, nil)
+	mockAuthorRepository.EXPECT().
+		Update(ctx, &domain.Author{ID: tolkien.ID, Name: "J.R.R. Tolkien"}).
+		DoAndReturn(func(_ context.Context, a *domain.Author) error {
+			names[a.ID] = a.Name
+			return nil
+		})
+	mockBookRepository.EXPECT().
+		Update(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, b *domain.Book, events ...*domain.Event) error {
+			if b.ID != derived.ID || b.Author != "J.R.R. Tolkien" || b.Version != 3 {
+				t.Errorf("unexpected book update: %+v", b)
+			}
+			if len(events) != 1 || events[0].Type != domain.EventBookUpdated {
+				t.Errorf("unexpected book update events: %+v", events)
+			}
+			b.Version++
+			return nil
+		})
+	mockAuditRepository.EXPECT().
+		Append(gomock.Any(), gomock.Cond(func(e *domain.AuditEntry) bool {
+			return e.BookID == derived.ID && e.Operation == domain.AuditUpdate && e.Version == 4 &&
+				reflect.DeepEqual(e.Changes, []domain.FieldChange{
+					{Field: "author", Before: "Tolkien", After: "J.R.R. Tolkien"},
+				})
+		})).
+		Return(nil)
+
+	ai := interactor.NewAuthorInteractor(
+		logger, mockAuthorRepository, mockBookRepository, mockAuditRepository, interactor.NewCreditLock(),
+	)
+	got, err := ai.RenameAuthor(ctx, tolkien.ID.String(), "J.R.R. Tolkien")
+	if err != nil {
+		t.Fatalf("RenameAuthor() error = %v", err)
+	}
+	if got.Name != "J.R.R. Tolkien" {
+		t.Errorf("RenameAuthor() got = %+v", got)
+	}
+	if custom.Author != "J. R. R. Tolkien" {
+		t.Errorf("RenameAuthor() changed a custom byline to %q", custom.Author)
+	}
+}
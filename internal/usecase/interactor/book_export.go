@@ -0,0 +1,31 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// ExportBooks calls fn with every book of the catalog, oldest first, credits included,
+// and stops at the first error fn returns. Books are read from the repository as fn consumes them.
+// Trashed books and the audit summary of every book are only included if opts asks for them.
+func (bi *BookInteractor) ExportBooks(
+	ctx context.Context,
+	opts domain.ExportOptions,
+	fn func(*domain.ExportedBook) error,
+) error {
+	return bi.repo.Each(ctx, opts.WithTrashed, func(book *domain.Book) error {
+		if err := fillCredits(ctx, bi.authors, book); err != nil {
+			return err
+		}
+		exported := &domain.ExportedBook{Book: book}
+		if opts.WithAudit {
+			summary, err := bi.audit.Summary(ctx, book.ID)
+			if err != nil {
+				return err
+			}
+			exported.Audit = summary
+		}
+		return fn(exported)
+	})
+}
@@ -0,0 +1,126 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+func TestBookInteractor_ExportBooks(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	authorID := uuid.New()
+	// each stands in for the repository, passing a credited book and a trashed book to fn.
+	each := func(_ context.Context, _ bool, fn func(*domain.Book) error) error {
+		for _, b := range []*domain.Book{
+			{ID: uuid.New(), Title: "Dune", Authors: []domain.BookAuthor{{AuthorID: authorID}}},
+			{ID: uuid.New(), Title: "Trashed"},
+		} {
+			if err := fn(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	summary := &domain.AuditSummary{CreatedBy: "alice", UpdatedBy: "bob", Changes: 2}
+
+	tests := []struct {
+		name             string
+		opts             domain.ExportOptions
+		fnErr            error
+		wantErr          error
+		wantBooks        int
+		wantAudit        *domain.AuditSummary
+		mockExpectations func(*mocks.MockBookRepository, *mocks.MockAuditRepository, *mocks.MockAuthorRepository)
+	}{
+		{
+			name: "exports every book with credits",
+			mockExpectations: func(books *mocks.MockBookRepository, _ *mocks.MockAuditRepository,
+				authors *mocks.MockAuthorRepository,
+			) {
+				books.EXPECT().Each(ctx, false, gomock.Any()).DoAndReturn(each)
+				authors.EXPECT().ReadByID(ctx, authorID).Return(&domain.Author{ID: authorID, Name: "Frank Herbert"}, nil)
+			},
+			wantBooks: 2,
+		},
+		{
+			name: "exports trashed books and audit summaries",
+			opts: domain.ExportOptions{WithTrashed: true, WithAudit: true},
+			mockExpectations: func(books *mocks.MockBookRepository, audit *mocks.MockAuditRepository,
+				authors *mocks.MockAuthorRepository,
+			) {
+				books.EXPECT().Each(ctx, true, gomock.Any()).DoAndReturn(each)
+				authors.EXPECT().ReadByID(ctx, authorID).Return(&domain.Author{ID: authorID, Name: "Frank Herbert"}, nil)
+				audit.EXPECT().Summary(ctx, gomock.Any()).Return(summary, nil).Times(2)
+			},
+			wantBooks: 2,
+			wantAudit: summary,
+		},
+		{
+			name: "fails if the audit summary can't be read",
+			opts: domain.ExportOptions{WithAudit: true},
+			mockExpectations: func(books *mocks.MockBookRepository, audit *mocks.MockAuditRepository,
+				authors *mocks.MockAuthorRepository,
+			) {
+				books.EXPECT().Each(ctx, false, gomock.Any()).DoAndReturn(each)
+				authors.EXPECT().ReadByID(ctx, authorID).Return(&domain.Author{ID: authorID, Name: "Frank Herbert"}, nil)
+				audit.EXPECT().Summary(ctx, gomock.Any()).Return(nil, errors.New("oops"))
+			},
+			wantErr: errors.New("oops"),
+		},
+		{
+			name:  "stops at the first error of fn",
+			fnErr: errors.New("broken pipe"),
+			mockExpectations: func(books *mocks.MockBookRepository, _ *mocks.MockAuditRepository,
+				authors *mocks.MockAuthorRepository,
+			) {
+				books.EXPECT().Each(ctx, false, gomock.Any()).DoAndReturn(each)
+				authors.EXPECT().ReadByID(ctx, authorID).Return(&domain.Author{ID: authorID, Name: "Frank Herbert"}, nil)
+			},
+			wantErr:   errors.New("broken pipe"),
+			wantBooks: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+			mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
+			mockAuthorRepository := mocks.NewMockAuthorRepository(mockCtl)
+			tt.mockExpectations(mockBookRepository, mockAuditRepository, mockAuthorRepository)
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mocks.NewMockStockRepository(mockCtl), mockAuthorRepository,
+				mockAuditRepository, interactor.NewCreditLock(),
+			)
+
+			var got []*domain.ExportedBook
+			err := bi.ExportBooks(ctx, tt.opts, func(b *domain.ExportedBook) error {
+				got = append(got, b)
+				return tt.fnErr
+			})
+			if (err != nil || tt.wantErr != nil) && (err == nil || tt.wantErr == nil || err.Error() != tt.wantErr.Error()) {
+				t.Fatalf("ExportBooks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.wantBooks {
+				t.Fatalf("ExportBooks() got %d books, want %d", len(got), tt.wantBooks)
+			}
+			for _, b := range got {
+				if !reflect.DeepEqual(b.Audit, tt.wantAudit) {
+					t.Errorf("ExportBooks() got audit %+v, want %+v", b.Audit, tt.wantAudit)
+				}
+			}
+			if len(got) > 0 && got[0].Book.Authors[0].Name != "Frank Herbert" {
+				t.Errorf("ExportBooks() expected credits to be filled in, got %+v", got[0].Book.Authors)
+			}
+		})
+	}
+}
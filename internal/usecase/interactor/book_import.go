@@ -0,0 +1,107 @@
+package interactor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+)
+
+// ImportBooks creates the books of rows, each validated and completed like CreateBook,
+// and reports the outcome of every row. Rows the caller already rejected are reported as they are.
+// Books must not share an ISBN with a stored book, nor with an earlier row.
+// By default every valid row is created on its own, so a failing row does not stop the others.
+// A dry run only validates the rows; an atomic import creates every book at once, and only if no row failed.
+// Raises domain.EventBookCreated for each created book.
+func (bi *BookInteractor) ImportBooks(
+	ctx context.Context,
+	rows []*domain.ImportRow,
+	opts domain.ImportOptions,
+) (*domain.ImportReport, error) {
+	if len(rows) > domain.MaxImportRows {
+		return nil, domain.ErrTooManyImportRows
+	}
+	bi.credits.mu.RLock()
+	defer bi.credits.mu.RUnlock()
+	report := &domain.ImportReport{Rows: rows, Options: opts}
+	lines := make(map[string]int, len(rows))
+	for _, row := range rows {
+		if row.Err == nil {
+			row.Err = bi.prepareImport(ctx, row, lines)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if row.Err != nil {
+			report.Failed++
+		}
+	}
+	if opts.DryRun || report.Rejected() {
+		return report, nil
+	}
+
+	if opts.Atomic {
+		if err := bi.importAll(ctx, report); err != nil {
+			return nil, err
+		}
+		return report, nil
+	}
+	for _, row := range rows {
+		if row.Err != nil {
+			continue
+		}
+		if row.Err = bi.repo.Create(ctx, row.Book, domain.NewBookCreated()); row.Err != nil {
+			report.Failed++
+			continue
+		}
+		if err := bi.created(ctx, row.Book); err != nil {
+			return nil, err
+		}
+		report.Created++
+	}
+	return report, nil
+}
+
+// prepareImport validates and completes the book of row, as CreateBook does, and checks its ISBN is unique.
+// lines maps the ISBNs of the rows prepared so far to their line, row is added to it.
+func (bi *BookInteractor) prepareImport(ctx context.Context, row *domain.ImportRow, lines map[string]int) error {
+	if err := bi.prepare(ctx, row.Book); err != nil {
+		return err
+	}
+	isbn := row.Book.ISBN
+	if isbn == "" {
+		return nil
+	}
+	if line, ok := lines[isbn]; ok {
+		return fmt.Errorf("%w: same as line %d", domain.ErrDuplicateISBN, line)
+	}
+	lines[isbn] = row.Line
+	_, err := bi.repo.ReadByISBN(ctx, isbn)
+	switch {
+	case err == nil:
+		return domain.ErrDuplicateISBN
+	case db.IsNotFoundError(err):
+		return nil
+	default:
+		return err
+	}
+}
+
+// importAll creates the books of every row of report at once.
+func (bi *BookInteractor) importAll(ctx context.Context, report *domain.ImportReport) error {
+	books := make([]*domain.Book, len(report.Rows))
+	for i, row := range report.Rows {
+		books[i] = row.Book
+	}
+	if err := bi.repo.CreateAll(ctx, books, domain.NewBookCreated()); err != nil {
+		return err
+	}
+	for _, b := range books {
+		if err := bi.created(ctx, b); err != nil {
+			return err
+		}
+		report.Created++
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+func TestBookInteractor_ImportBooks(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	const isbn, takenISBN = "9780306406157", "9780262033848"
+	// rows mixes a row rejected by the caller, a valid row, an ISBN repeated within the import,
+	// an ISBN of a stored book and a valid row without ISBN.
+	rows := func() []*domain.ImportRow {
+		return []*domain.ImportRow{
+			{Line: 2, Err: errors.New("title is required")},
+			{Line: 3, Book: &domain.Book{Title: "Dune", ISBN: "978-0-306-40615-7"}},
+			{Line: 4, Book: &domain.Book{Title: "Dune again", ISBN: isbn}},
+			{Line: 5, Book: &domain.Book{Title: "Taken", ISBN: takenISBN}},
+			{Line: 6, Book: &domain.Book{Title: "Plain"}},
+		}
+	}
+	validated := func(books *mocks.MockBookRepository) {
+		books.EXPECT().ReadByISBN(ctx, isbn).Return(nil, errors.New("book not found"))
+		books.EXPECT().ReadByISBN(ctx, takenISBN).Return(&domain.Book{ID: uuid.New()}, nil)
+	}
+	created := func(audit *mocks.MockAuditRepository, stocks *mocks.MockStockRepository, n int) {
+		audit.EXPECT().Append(gomock.Any(), gomock.Any()).Return(nil).Times(n)
+		stocks.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(n)
+	}
+
+	tests := []struct {
+		name             string
+		rows             []*domain.ImportRow
+		opts             domain.ImportOptions
+		wantErr          error
+		wantCreated      int
+		wantFailed       int
+		wantRowErrs      []bool
+		mockExpectations func(*mocks.MockBookRepository, *mocks.MockAuditRepository, *mocks.MockStockRepository)
+	}{
+		{
+			name:    "fails with too many rows",
+			rows:    make([]*domain.ImportRow, domain.MaxImportRows+1),
+			wantErr: domain.ErrTooManyImportRows,
+		},
+		{
+			name: "dry run validates without creating",
+			rows: rows(),
+			opts: domain.ImportOptions{DryRun: true},
+			mockExpectations: func(books *mocks.MockBookRepository, _ *mocks.MockAuditRepository,
+				_ *mocks.MockStockRepository,
+			) {
+				validated(books)
+			},
+			wantFailed:  3,
+			wantRowErrs: []bool{true, false, true, true, false},
+		},
+		{
+			name: "atomic import creates nothing if a row failed",
+			rows: rows(),
+			opts: domain.ImportOptions{Atomic: true},
+			mockExpectations: func(books *mocks.MockBookRepository, _ *mocks.MockAuditRepository,
+				_ *mocks.MockStockRepository,
+			) {
+				validated(books)
+			},
+			wantFailed:  3,
+			wantRowErrs: []bool{true, false, true, true, false},
+		},
+		{
+			name: "default import creates every valid row on its own",
+			rows: rows(),
+			mockExpectations: func(books *mocks.MockBookRepository, audit *mocks.MockAuditRepository,
+				stocks *mocks.MockStockRepository,
+			) {
+				validated(books)
+				books.EXPECT().
+					Create(ctx, gomock.Cond(func(b *domain.Book) bool { return b.ISBN == isbn }),
+						isEvent(&domain.Event{Type: domain.EventBookCreated})).
+					Return(nil)
+				books.EXPECT().
+					Create(ctx, gomock.Cond(func(b *domain.Book) bool { return b.Title == "Plain" }), gomock.Any()).
+					Return(errors.New("oops"))
+				created(audit, stocks, 1)
+			},
+			wantCreated: 1,
+			wantFailed:  4,
+			wantRowErrs: []bool{true, false, true, true, true},
+		},
+		{
+			name: "atomic import fails if the books can't be created",
+			rows: []*domain.ImportRow{{Line: 1, Book: &domain.Book{Title: "Plain"}}},
+			opts: domain.ImportOptions{Atomic: true},
+			mockExpectations: func(books *mocks.MockBookRepository, _ *mocks.MockAuditRepository,
+				_ *mocks.MockStockRepository,
+			) {
+				books.EXPECT().CreateAll(ctx, gomock.Any(), gomock.Any()).Return(domain.ErrDuplicateISBN)
+			},
+			wantErr: domain.ErrDuplicateISBN,
+		},
+		{
+			name: "atomic import creates every book at once",
+			rows: []*domain.ImportRow{
+				{Line: 1, Book: &domain.Book{Title: "Dune", ISBN: isbn}},
+				{Line: 2, Book: &domain.Book{Title: "Plain"}},
+			},
+			opts: domain.ImportOptions{Atomic: true},
+			mockExpectations: func(books *mocks.MockBookRepository, audit *mocks.MockAuditRepository,
+				stocks *mocks.MockStockRepository,
+			) {
+				books.EXPECT().ReadByISBN(ctx, isbn).Return(nil, errors.New("book not found"))
+				books.EXPECT().
+					CreateAll(ctx, gomock.Len(2), isEvent(&domain.Event{Type: domain.EventBookCreated})).
+					DoAndReturn(func(_ context.Context, bs []*domain.Book, _ ...*domain.Event) error {
+						for _, b := range bs {
+							b.ID = uuid.New()
+						}
+						return nil
+					})
+				created(audit, stocks, 2)
+			},
+			wantCreated: 2,
+			wantRowErrs: []bool{false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+			mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
+			mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockBookRepository, mockAuditRepository, mockStockRepository)
+			}
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mockStockRepository, mocks.NewMockAuthorRepository(mockCtl),
+				mockAuditRepository, interactor.NewCreditLock(),
+			)
+
+			report, err := bi.ImportBooks(ctx, tt.rows, tt.opts)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("ImportBooks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if report.Created != tt.wantCreated || report.Failed != tt.wantFailed || report.Options != tt.opts {
+				t.Errorf("ImportBooks() got %d created and %d failed with %+v, want %d and %d",
+					report.Created, report.Failed, report.Options, tt.wantCreated, tt.wantFailed)
+			}
+			for i, row := range report.Rows {
+				if (row.Err != nil) != tt.wantRowErrs[i] {
+					t.Errorf("ImportBooks() row at line %d got error %v", row.Line, row.Err)
+				}
+			}
+		})
+	}
+}
@@ -4,7 +4,12 @@
 package interactor
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"golang.org/x/text/language"
@@ -14,64 +19,336 @@ import (
 )
 
 // BookInteractor handles business logic.
+// Every change to a book is recorded in the audit log, attributed to the actor of the context it is made with.
+// Changes the rest of the system cares about raise a domain.Event, written to the outbox together with the change.
 type BookInteractor struct {
-	repo   domain.BookRepository
-	logger *slog.Logger
+	repo    domain.BookRepository
+	stocks  domain.StockRepository
+	authors domain.AuthorRepository
+	audit   domain.AuditRepository
+	credits *CreditLock
+	logger  *slog.Logger
 }
 
 // NewBookInteractor creates a new BookInteractor.
-func NewBookInteractor(logger *slog.Logger, repo domain.BookRepository) *BookInteractor {
-	return &BookInteractor{repo: repo, logger: logger}
+// Books are credited under credits, shared with the AuthorInteractor.
+func NewBookInteractor(
+	logger *slog.Logger,
+	repo domain.BookRepository,
+	stocks domain.StockRepository,
+	authors domain.AuthorRepository,
+	audit domain.AuditRepository,
+	credits *CreditLock,
+) *BookInteractor {
+	return &BookInteractor{
+		repo: repo, stocks: stocks, authors: authors, audit: audit, credits: credits, logger: logger,
+	}
 }
 
-// CreateBook sends the book to be created to the underlying repository.
-// Sets the language tag to english.
-func (bi *BookInteractor) CreateBook(book *domain.Book) error {
-	// in the real world, CreateBook might, for example,
-	// trigger inventory updates, sends events or validates stock.
-	book.LanguageTag = language.English.String()
-	return bi.repo.Create(book)
+// CreateBook sends the book to be created to the underlying repository
+// and initializes its stock with no copies.
+// Defaults the language tag to english when missing.
+// Credited authors must exist; when the byline is missing it is derived from the credits.
+// The optional ISBN is validated and normalized to ISBN-13, it must not belong to another book.
+// Raises domain.EventBookCreated.
+func (bi *BookInteractor) CreateBook(ctx context.Context, book *domain.Book) error {
+	bi.credits.mu.RLock()
+	defer bi.credits.mu.RUnlock()
+	if err := bi.prepare(ctx, book); err != nil {
+		return err
+	}
+	if err := bi.repo.Create(ctx, book, domain.NewBookCreated()); err != nil {
+		return err
+	}
+	return bi.created(ctx, book)
+}
+
+// prepare validates and completes a new book before it is created, see CreateBook.
+func (bi *BookInteractor) prepare(ctx context.Context, book *domain.Book) error {
+	if book.LanguageTag == "" {
+		book.LanguageTag = language.English.String()
+	}
+	if book.ISBN != "" {
+		isbn, err := domain.ParseISBN(book.ISBN)
+		if err != nil {
+			return err
+		}
+		book.ISBN = isbn
+	}
+	if err := bi.credit(ctx, book.Authors); err != nil {
+		return err
+	}
+	if book.Author == "" {
+		book.Author = domain.Byline(book.Authors)
+	}
+	return nil
+}
+
+// created initializes the stock of book and records its creation in the audit log.
+// The book is already stored, so both run even if the caller gave up or the other one fails.
+func (bi *BookInteractor) created(ctx context.Context, book *domain.Book) error {
+	err := bi.stocks.Create(context.WithoutCancel(ctx), &domain.Stock{BookID: book.ID})
+	if err != nil {
+		bi.logger.With("error", err, "book_id", book.ID).Error("failed to initialize stock")
+	}
+	return errors.Join(err, bi.record(ctx, domain.AuditCreate, nil, book))
 }
 
 // GetBook retrieves a domain.Book by its ID.
-// Validates the given id is a valid UUID.
-func (bi *BookInteractor) GetBook(id string) (*domain.Book, error) {
-	uid, err := uuid.Parse(id)
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+func (bi *BookInteractor) GetBook(ctx context.Context, id string) (*domain.Book, error) {
+	uid, err := domain.BookResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	b, err := bi.repo.ReadByID(ctx, uid)
+	if db.IsNotFoundError(err) {
+		return nil, domain.ErrBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return b, fillCredits(ctx, bi.authors, b)
+}
+
+// GetBookByISBN retrieves a domain.Book by its ISBN.
+// Validates the given isbn is a valid ISBN-10 or ISBN-13.
+func (bi *BookInteractor) GetBookByISBN(ctx context.Context, isbn string) (*domain.Book, error) {
+	normalized, err := domain.ParseISBN(isbn)
 	if err != nil {
-		return nil, domain.ErrInvalidBookID
+		return nil, err
 	}
-	b, err := bi.repo.ReadByID(uid)
+	b, err := bi.repo.ReadByISBN(ctx, normalized)
 	if db.IsNotFoundError(err) {
 		return nil, domain.ErrBookNotFound
 	}
-	return b, err
+	if err != nil {
+		return nil, err
+	}
+	return b, fillCredits(ctx, bi.authors, b)
 }
 
-// ListBooks retrieves a list of books.
+// ListBooks retrieves a page of books matching query.
+// Unset sort field and page size fall back to the domain defaults.
 // Does not fail if nothing is found.
-func (bi *BookInteractor) ListBooks() ([]*domain.Book, error) {
-	return bi.repo.ReadAll()
+func (bi *BookInteractor) ListBooks(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
+	page, err := bi.repo.ReadPage(ctx, query.WithDefaults())
+	if err != nil {
+		return nil, err
+	}
+	return page, fillCredits(ctx, bi.authors, page.Books...)
 }
 
-// UpdateBook updates a single book by its ID.
-func (bi *BookInteractor) UpdateBook(book *domain.Book) error {
-	err := bi.repo.Update(book)
+// UpdateBook updates the price of a single book by its ID.
+// The stored currency is kept if book.Price.Currency is empty.
+// On success book.Version is set to the new version.
+func (bi *BookInteractor) UpdateBook(ctx context.Context, book *domain.Book) error {
+	p := &domain.BookPatch{Price: &book.Price.Amount}
+	if book.Price.Currency != "" {
+		p.Currency = &book.Price.Currency
+	}
+	updated, err := bi.patch(ctx, book.ID, p, book.Version)
+	if err != nil {
+		return err
+	}
+	book.Version = updated.Version
+	return nil
+}
+
+// PatchBook applies patch to the book matching id and returns the updated book.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+// If version is not zero, the patch is only applied if it matches the current version of the book.
+func (bi *BookInteractor) PatchBook(
+	ctx context.Context,
+	id string,
+	patch *domain.BookPatch,
+	version int,
+) (*domain.Book, error) {
+	uid, err := domain.BookResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return bi.patch(ctx, uid, patch, version)
+}
+
+// patch reads the stored book, applies the patch and writes it back.
+// The write is conditional on the version that was read, so concurrent changes are never overwritten.
+// Replaced credits must exist and, unless the patch changes the byline too, the byline is derived from them.
+// Raises domain.EventBookUpdated, and domain.EventBookPriceChanged if the price or its currency changed.
+func (bi *BookInteractor) patch(
+	ctx context.Context,
+	id uuid.UUID,
+	patch *domain.BookPatch,
+	version int,
+) (*domain.Book, error) {
+	book, err := bi.repo.ReadByID(ctx, id)
+	if db.IsNotFoundError(err) {
+		return nil, domain.ErrBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if version != 0 && version != book.Version {
+		return nil, domain.ErrVersionConflict
+	}
+	before := *book
+	before.Authors = slices.Clone(book.Authors)
+
+	if patch.Authors != nil {
+		bi.credits.mu.RLock()
+		defer bi.credits.mu.RUnlock()
+	}
+	if patch.Authors == nil {
+		err = fillCredits(ctx, bi.authors, book)
+	} else {
+		err = bi.credit(ctx, *patch.Authors)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	patch.Apply(book)
+	if patch.Authors != nil && patch.Author == nil && len(book.Authors) > 0 {
+		book.Author = domain.Byline(book.Authors)
+	}
+	events := []*domain.Event{domain.NewBookUpdated()}
+	if book.Price != before.Price {
+		events = append(events, domain.NewBookPriceChanged(before.Price))
+	}
+	err = bi.repo.Update(ctx, book, events...)
+	if db.IsNotFoundError(err) {
+		return nil, domain.ErrBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return book, bi.record(ctx, domain.AuditUpdate, &before, book)
+}
+
+// record appends the change of a book from before to after to the audit log.
+// A nil before records a book entering the catalog, a nil after a book leaving it,
+// before being the book as the repository trashed it.
+func (bi *BookInteractor) record(ctx context.Context, op domain.AuditOperation, before, after *domain.Book) error {
+	return recordBookChange(ctx, bi.audit, bi.logger, op, before, after)
+}
+
+// recordBookChange appends the change of a book from before to after to audit, see BookInteractor.record.
+// It is shared by the interactors changing books.
+func recordBookChange(
+	ctx context.Context,
+	audit domain.AuditRepository,
+	logger *slog.Logger,
+	op domain.AuditOperation,
+	before, after *domain.Book,
+) error {
+	entry := &domain.AuditEntry{
+		At:        time.Now().UTC(),
+		Actor:     domain.ActorFrom(ctx),
+		Operation: op,
+		Changes:   domain.BookChanges(before, after),
+	}
+	if after != nil {
+		entry.BookID, entry.Version = after.ID, after.Version
+	} else {
+		entry.BookID, entry.Version = before.ID, before.Version
+	}
+	// The change already happened, so it must be recorded even if the caller gave up.
+	if err := audit.Append(context.WithoutCancel(ctx), entry); err != nil {
+		logger.With("error", err, "book_id", entry.BookID, "operation", op).Error("failed to record change")
+		return err
+	}
+	return nil
+}
+
+// credit sets the name of every credit from its author.
+// Fails with domain.ErrAuthorNotFound if an author does not exist.
+func (bi *BookInteractor) credit(ctx context.Context, credits []domain.BookAuthor) error {
+	for i := range credits {
+		a, err := bi.authors.ReadByID(ctx, credits[i].AuthorID)
+		if db.IsAuthorNotFoundError(err) {
+			return fmt.Errorf("%w: %s", domain.ErrAuthorNotFound, credits[i].AuthorID)
+		}
+		if err != nil {
+			return err
+		}
+		credits[i].Name = a.Name
+	}
+	return nil
+}
+
+// DeleteBook moves a book to the trash, its stock is kept until the book is purged.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+// Raises domain.EventBookDeleted.
+func (bi *BookInteractor) DeleteBook(ctx context.Context, id string) error {
+	uid, err := domain.BookResource.Parse(id)
+	if err != nil {
+		return err
+	}
+	book, err := bi.repo.Delete(ctx, uid, domain.NewBookDeleted())
 	if db.IsNotFoundError(err) {
 		return domain.ErrBookNotFound
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	return bi.record(ctx, domain.AuditDelete, book, nil)
 }
 
-// DeleteBook removes a book from the repository.
+// ListTrash retrieves a page of trashed books matching query.
+// Unset sort field and page size fall back to the domain defaults.
 // Does not fail if nothing is found.
-func (bi *BookInteractor) DeleteBook(id string) error {
-	uid, err := uuid.Parse(id)
+func (bi *BookInteractor) ListTrash(ctx context.Context, query domain.BookQuery) (*domain.BookPage, error) {
+	query.Trashed = true
+	return bi.ListBooks(ctx, query)
+}
+
+// RestoreBook moves a trashed book back to the catalog and returns it.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+// Raises domain.EventBookRestored.
+func (bi *BookInteractor) RestoreBook(ctx context.Context, id string) (*domain.Book, error) {
+	uid, err := domain.BookResource.Parse(id)
 	if err != nil {
-		return domain.ErrInvalidBookID
+		return nil, err
 	}
-	err = bi.repo.Delete(uid)
+	b, err := bi.repo.Restore(ctx, uid, domain.NewBookRestored())
 	if db.IsNotFoundError(err) {
-		return nil
+		return nil, domain.ErrBookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = bi.record(ctx, domain.AuditRestore, nil, b); err != nil {
+		return nil, err
+	}
+	return b, fillCredits(ctx, bi.authors, b)
+}
+
+// GetBookHistory retrieves a page of the audit log of a book, oldest change first.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+// The history of trashed and purged books is kept, so it does not fail if the book is not found.
+func (bi *BookInteractor) GetBookHistory(
+	ctx context.Context,
+	id string,
+	query domain.AuditQuery,
+) (*domain.AuditPage, error) {
+	uid, err := domain.BookResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	query.BookID = uid
+	return bi.audit.ReadPage(ctx, query.WithDefaults())
+}
+
+// PurgeTrash removes for good the books that have been in the trash for longer than retention,
+// together with their stock, and returns how many books were purged.
+func (bi *BookInteractor) PurgeTrash(ctx context.Context, retention time.Duration) (int, error) {
+	purged, err := bi.repo.Purge(ctx, time.Now().UTC().Add(-retention))
+	for _, id := range purged {
+		// The books are gone already, their stock must follow even if the caller gave up.
+		if stockErr := bi.stocks.Delete(context.WithoutCancel(ctx), id); stockErr != nil &&
+			!db.IsStockNotFoundError(stockErr) {
+			err = errors.Join(err, stockErr)
+		}
 	}
-	return err
+	return len(purged), err
 }
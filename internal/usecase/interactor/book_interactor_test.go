@@ -1,10 +1,12 @@
 package interactor_test
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"go.uber.org/mock/gomock"
@@ -18,24 +20,32 @@ import (
 
 func TestBookInteractor_CreateBook(t *testing.T) {
 	mockCtl := gomock.NewController(t)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
 	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+	mockAuthorRepository := mocks.NewMockAuthorRepository(mockCtl)
 	logger := testlog.NewTestLogger()
-	book := &domain.Book{Title: "A book"}
+	ctx := context.Background()
+	bookID := uuid.New()
+	authorID := uuid.New()
+	translatorID := uuid.New()
 
 	tests := []struct {
 		name             string
+		book             *domain.Book
 		wantErr          bool
 		compareErr       func(error) bool
 		mockExpectations func()
 	}{
 		{
 			name: "fails",
+			book: &domain.Book{Title: "A book"},
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					Create(&domain.Book{
-						Title:       book.Title,
+					Create(ctx, &domain.Book{
+						Title:       "A book",
 						LanguageTag: language.English.String(),
-					}).
+					}, gomock.Any()).
 					Return(errors.New("oops"))
 			},
 			wantErr: true,
@@ -43,14 +53,165 @@ func TestBookInteractor_CreateBook(t *testing.T) {
 				return strings.Contains(err.Error(), "oops")
 			},
 		},
+		{
+			name: "fails to initialize stock",
+			book: &domain.Book{Title: "A book"},
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					Create(ctx, gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, b *domain.Book, _ ...*domain.Event) error {
+						b.ID = bookID
+						return nil
+					})
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockStockRepository.EXPECT().
+					Create(gomock.Any(), &domain.Stock{BookID: bookID}).
+					Return(errors.New("no room"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "no room")
+			},
+		},
+		{
+			name: "fails to record change",
+			book: &domain.Book{Title: "A book"},
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					Create(ctx, gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					Return(errors.New("disk full"))
+				mockStockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "disk full")
+			},
+		},
 		{
 			name: "succeeds",
+			book: &domain.Book{Title: "A book"},
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					Create(&domain.Book{
-						Title:       book.Title,
+					Create(ctx, &domain.Book{
+						Title:       "A book",
 						LanguageTag: language.English.String(),
-					}).
+					}, isEvent(&domain.Event{Type: domain.EventBookCreated})).
+					DoAndReturn(func(_ context.Context, b *domain.Book, _ ...*domain.Event) error {
+						b.ID = bookID
+						b.Version = 1
+						return nil
+					})
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, e *domain.AuditEntry) error {
+						want := []domain.FieldChange{
+							{Field: "language", After: "en"},
+							{Field: "price", After: "0"},
+							{Field: "title", After: "A book"},
+						}
+						if e.BookID != bookID || e.Version != 1 || e.Operation != domain.AuditCreate ||
+							e.Actor != domain.AnonymousActor || !reflect.DeepEqual(e.Changes, want) {
+							t.Errorf("Append() got unexpected entry %+v", e)
+						}
+						return nil
+					})
+				mockStockRepository.EXPECT().
+					Create(gomock.Any(), &domain.Stock{BookID: bookID}).
+					Return(nil)
+			},
+		},
+		{
+			name: "succeeds keeping the book language",
+			book: &domain.Book{Title: "Un libro", LanguageTag: language.Italian.String()},
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					Create(ctx, &domain.Book{
+						Title:       "Un libro",
+						LanguageTag: language.Italian.String(),
+					}, gomock.Any()).
+					Return(nil)
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockStockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name:    "fails with invalid isbn",
+			book:    &domain.Book{Title: "A book", ISBN: "0306406153"},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidISBN)
+			},
+		},
+		{
+			name: "fails with duplicate isbn normalizing it",
+			book: &domain.Book{Title: "A book", ISBN: "0-306-40615-2"},
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					Create(ctx, &domain.Book{
+						Title:       "A book",
+						ISBN:        "9780306406157",
+						LanguageTag: language.English.String(),
+					}, gomock.Any()).
+					Return(domain.ErrDuplicateISBN)
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrDuplicateISBN)
+			},
+		},
+		{
+			name: "fails with author not found error",
+			book: &domain.Book{Title: "A book", Authors: []domain.BookAuthor{{AuthorID: authorID}}},
+			mockExpectations: func() {
+				mockAuthorRepository.EXPECT().
+					ReadByID(ctx, authorID).
+					Return(nil, errors.New("author not found"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrAuthorNotFound)
+			},
+		},
+		{
+			name: "succeeds deriving the byline from credits",
+			book: &domain.Book{Title: "A book", Authors: []domain.BookAuthor{
+				{AuthorID: translatorID, Role: domain.RoleTranslator},
+				{AuthorID: authorID, Role: domain.RoleAuthor},
+			}},
+			mockExpectations: func() {
+				mockAuthorRepository.EXPECT().
+					ReadByID(ctx, translatorID).
+					Return(&domain.Author{ID: translatorID, Name: "William Weaver"}, nil)
+				mockAuthorRepository.EXPECT().
+					ReadByID(ctx, authorID).
+					Return(&domain.Author{ID: authorID, Name: "Umberto Eco"}, nil)
+				mockBookRepository.EXPECT().
+					Create(ctx, &domain.Book{
+						Title:       "A book",
+						Author:      "Umberto Eco",
+						LanguageTag: language.English.String(),
+						Authors: []domain.BookAuthor{
+							{AuthorID: translatorID, Role: domain.RoleTranslator, Name: "William Weaver"},
+							{AuthorID: authorID, Role: domain.RoleAuthor, Name: "Umberto Eco"},
+						},
+					}, gomock.Any()).
+					Return(nil)
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					Return(nil)
+				mockStockRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any()).
 					Return(nil)
 			},
 		},
@@ -60,8 +221,11 @@ func TestBookInteractor_CreateBook(t *testing.T) {
 			if tt.mockExpectations != nil {
 				tt.mockExpectations()
 			}
-			bi := interactor.NewBookInteractor(logger, mockBookRepository)
-			err := bi.CreateBook(book)
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mockStockRepository, mockAuthorRepository, mockAuditRepository,
+				interactor.NewCreditLock(),
+			)
+			err := bi.CreateBook(ctx, tt.book)
 			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
 				t.Errorf("CreateBook() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -73,7 +237,9 @@ func TestBookInteractor_CreateBook(t *testing.T) {
 func TestBookInteractor_GetBook(t *testing.T) {
 	mockCtl := gomock.NewController(t)
 	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
 	logger := testlog.NewTestLogger()
+	ctx := context.Background()
 	book := &domain.Book{Title: "A book", ID: uuid.New()}
 
 	tests := []struct {
@@ -97,7 +263,7 @@ func TestBookInteractor_GetBook(t *testing.T) {
 			id:   book.ID.String(),
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					ReadByID(book.ID).
+					ReadByID(ctx, book.ID).
 					Return(nil, errors.New("book not found"))
 			},
 			wantErr: true,
@@ -110,7 +276,7 @@ func TestBookInteractor_GetBook(t *testing.T) {
 			id:   book.ID.String(),
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					ReadByID(book.ID).
+					ReadByID(ctx, book.ID).
 					Return(nil, errors.New("something broke"))
 			},
 			wantErr: true,
@@ -118,12 +284,30 @@ func TestBookInteractor_GetBook(t *testing.T) {
 				return strings.Contains(err.Error(), "something broke")
 			},
 		},
+		{
+			name:    "fails with the id of another resource type",
+			id:      domain.AuthorResource.Format(book.ID),
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrWrongResourceType)
+			},
+		},
 		{
 			name: "succeeds",
 			id:   book.ID.String(),
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					ReadByID(book.ID).
+					ReadByID(ctx, book.ID).
+					Return(book, nil)
+			},
+			want: book,
+		},
+		{
+			name: "succeeds with a prefixed id",
+			id:   domain.BookResource.Format(book.ID),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, book.ID).
 					Return(book, nil)
 			},
 			want: book,
@@ -134,8 +318,12 @@ func TestBookInteractor_GetBook(t *testing.T) {
 			if tt.mockExpectations != nil {
 				tt.mockExpectations()
 			}
-			bi := interactor.NewBookInteractor(logger, mockBookRepository)
-			got, err := bi.GetBook(tt.id)
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mockStockRepository,
+				mocks.NewMockAuthorRepository(mockCtl), mocks.NewMockAuditRepository(mockCtl),
+				interactor.NewCreditLock(),
+			)
+			got, err := bi.GetBook(ctx, tt.id)
 			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
 				t.Errorf("GetBook() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -150,12 +338,15 @@ func TestBookInteractor_GetBook(t *testing.T) {
 func TestBookInteractor_ListBooks(t *testing.T) {
 	mockCtl := gomock.NewController(t)
 	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
 	logger := testlog.NewTestLogger()
-	books := []*domain.Book{{Title: "A book", ID: uuid.New()}}
+	ctx := context.Background()
+	page := &domain.BookPage{Books: []*domain.Book{{Title: "A book", ID: uuid.New()}}, Total: 1}
 
 	tests := []struct {
 		name             string
-		want             []*domain.Book
+		query            domain.BookQuery
+		want             *domain.BookPage
 		wantErr          bool
 		compareErr       func(error) bool
 		mockExpectations func()
@@ -164,7 +355,7 @@ func TestBookInteractor_ListBooks(t *testing.T) {
 			name: "fails",
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					ReadAll().
+					ReadPage(ctx, gomock.Any()).
 					Return(nil, errors.New("oops"))
 			},
 			wantErr: true,
@@ -172,14 +363,39 @@ func TestBookInteractor_ListBooks(t *testing.T) {
 				return strings.Contains(err.Error(), "oops")
 			},
 		},
+		{
+			name: "applies defaults",
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadPage(ctx, domain.BookQuery{
+						SortBy: domain.SortByCreated,
+						Limit:  domain.DefaultBookPageSize,
+					}).
+					Return(page, nil)
+			},
+			want: page,
+		},
 		{
 			name: "succeeds",
+			query: domain.BookQuery{
+				Filter:     domain.BookFilter{Author: "someone"},
+				SortBy:     domain.SortByPrice,
+				Descending: true,
+				Cursor:     "cursor",
+				Limit:      5,
+			},
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					ReadAll().
-					Return(books, nil)
+					ReadPage(ctx, domain.BookQuery{
+						Filter:     domain.BookFilter{Author: "someone"},
+						SortBy:     domain.SortByPrice,
+						Descending: true,
+						Cursor:     "cursor",
+						Limit:      5,
+					}).
+					Return(page, nil)
 			},
-			want: books,
+			want: page,
 		},
 	}
 	for _, tt := range tests {
@@ -187,10 +403,14 @@ func TestBookInteractor_ListBooks(t *testing.T) {
 			if tt.mockExpectations != nil {
 				tt.mockExpectations()
 			}
-			bi := interactor.NewBookInteractor(logger, mockBookRepository)
-			got, err := bi.ListBooks()
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mockStockRepository,
+				mocks.NewMockAuthorRepository(mockCtl), mocks.NewMockAuditRepository(mockCtl),
+				interactor.NewCreditLock(),
+			)
+			got, err := bi.ListBooks(ctx, tt.query)
 			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
-				t.Errorf("GetBook() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("ListBooks() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
 			if !reflect.DeepEqual(got, tt.want) {
@@ -202,36 +422,79 @@ func TestBookInteractor_ListBooks(t *testing.T) {
 
 func TestBookInteractor_UpdateBook(t *testing.T) {
 	mockCtl := gomock.NewController(t)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
 	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
 	logger := testlog.NewTestLogger()
-	book := &domain.Book{Title: "A book", ID: uuid.New()}
+	ctx := domain.WithActor(context.Background(), "alice")
+	id := uuid.New()
+	stored := func() *domain.Book {
+		return &domain.Book{ID: id, Title: "A book", Price: domain.Money{Amount: 10, Currency: "EUR"}, Version: 2}
+	}
 
 	tests := []struct {
 		name             string
 		book             *domain.Book
+		wantVersion      int
 		wantErr          bool
 		compareErr       func(error) bool
 		mockExpectations func()
 	}{
 		{
 			name: "fails with book not found error",
-			book: book,
+			book: &domain.Book{ID: id, Price: domain.Money{Amount: 42, Currency: "EUR"}},
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					Update(book).
-					Return(errors.New("book not found"))
+					ReadByID(ctx, id).
+					Return(nil, errors.New("book not found"))
 			},
 			wantErr: true,
 			compareErr: func(err error) bool {
 				return errors.Is(err, domain.ErrBookNotFound)
 			},
 		},
+		{
+			name: "fails with stale version",
+			book: &domain.Book{ID: id, Price: domain.Money{Amount: 42, Currency: "EUR"}, Version: 1},
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, id).
+					Return(stored(), nil)
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrVersionConflict)
+			},
+		},
+		{
+			name: "fails with version conflict",
+			book: &domain.Book{ID: id, Price: domain.Money{Amount: 42, Currency: "EUR"}},
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, id).
+					Return(stored(), nil)
+				mockBookRepository.EXPECT().
+					Update(
+						ctx,
+						&domain.Book{ID: id, Title: "A book", Price: domain.Money{Amount: 42, Currency: "EUR"}, Version: 2},
+						gomock.Any(), gomock.Any(),
+					).
+					Return(domain.ErrVersionConflict)
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrVersionConflict)
+			},
+		},
 		{
 			name: "fails with generic error",
-			book: book,
+			book: &domain.Book{ID: id, Price: domain.Money{Amount: 42, Currency: "EUR"}},
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					Update(book).
+					ReadByID(ctx, id).
+					Return(stored(), nil)
+				mockBookRepository.EXPECT().
+					Update(ctx, gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("something broke"))
 			},
 			wantErr: true,
@@ -241,12 +504,37 @@ func TestBookInteractor_UpdateBook(t *testing.T) {
 		},
 		{
 			name: "succeeds",
-			book: book,
+			book: &domain.Book{ID: id, Price: domain.Money{Amount: 42, Currency: "EUR"}, Version: 2},
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					Update(book).
-					Return(nil)
+					ReadByID(ctx, id).
+					Return(stored(), nil)
+				mockBookRepository.EXPECT().
+					Update(
+						ctx,
+						&domain.Book{ID: id, Title: "A book", Price: domain.Money{Amount: 42, Currency: "EUR"}, Version: 2},
+						isEvent(&domain.Event{Type: domain.EventBookUpdated}),
+						isEvent(&domain.Event{
+							Type:          domain.EventBookPriceChanged,
+							PreviousPrice: domain.Money{Amount: 10, Currency: "EUR"},
+						}),
+					).
+					DoAndReturn(func(_ context.Context, b *domain.Book, _ ...*domain.Event) error {
+						b.Version = 3
+						return nil
+					})
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, e *domain.AuditEntry) error {
+						want := []domain.FieldChange{{Field: "price", Before: "10", After: "42"}}
+						if e.BookID != id || e.Version != 3 || e.Operation != domain.AuditUpdate ||
+							e.Actor != "alice" || !reflect.DeepEqual(e.Changes, want) {
+							t.Errorf("Append() got unexpected entry %+v", e)
+						}
+						return nil
+					})
 			},
+			wantVersion: 3,
 		},
 	}
 	for _, tt := range tests {
@@ -254,21 +542,121 @@ func TestBookInteractor_UpdateBook(t *testing.T) {
 			if tt.mockExpectations != nil {
 				tt.mockExpectations()
 			}
-			bi := interactor.NewBookInteractor(logger, mockBookRepository)
-			err := bi.UpdateBook(tt.book)
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mockStockRepository, mocks.NewMockAuthorRepository(mockCtl), mockAuditRepository,
+				interactor.NewCreditLock(),
+			)
+			err := bi.UpdateBook(ctx, tt.book)
 			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
 				t.Errorf("UpdateBook() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if !tt.wantErr && tt.book.Version != tt.wantVersion {
+				t.Errorf("UpdateBook() version = %d, want %d", tt.book.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestBookInteractor_PatchBook(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	id := uuid.New()
+	title, lang := "Il nome della rosa", "it"
+	patch := &domain.BookPatch{Title: &title, LanguageTag: &lang}
+
+	tests := []struct {
+		name             string
+		id               string
+		version          int
+		want             *domain.Book
+		wantErr          bool
+		compareErr       func(error) bool
+		mockExpectations func()
+	}{
+		{
+			name:    "fails to parse id",
+			id:      "invalid",
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidBookID)
+			},
+		},
+		{
+			name: "fails with book deleted concurrently",
+			id:   id.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, id).
+					Return(&domain.Book{ID: id, Version: 1}, nil)
+				mockBookRepository.EXPECT().
+					Update(ctx, gomock.Any(), gomock.Any()).
+					Return(errors.New("book not found"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrBookNotFound)
+			},
+		},
+		{
+			name:    "succeeds",
+			id:      id.String(),
+			version: 1,
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, id).
+					Return(&domain.Book{ID: id, Title: "The name of the rose", Author: "Umberto Eco", Version: 1}, nil)
+				mockBookRepository.EXPECT().
+					Update(ctx, &domain.Book{
+						ID:          id,
+						Title:       title,
+						Author:      "Umberto Eco",
+						LanguageTag: lang,
+						Version:     1,
+					}, isEvent(&domain.Event{Type: domain.EventBookUpdated})).
+					DoAndReturn(func(_ context.Context, b *domain.Book, _ ...*domain.Event) error {
+						b.Version = 2
+						return nil
+					})
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			want: &domain.Book{ID: id, Title: title, Author: "Umberto Eco", LanguageTag: lang, Version: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mockStockRepository, mocks.NewMockAuthorRepository(mockCtl), mockAuditRepository,
+				interactor.NewCreditLock(),
+			)
+			got, err := bi.PatchBook(ctx, tt.id, patch, tt.version)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("PatchBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PatchBook() got = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
 
 func TestBookInteractor_DeleteBook(t *testing.T) {
 	mockCtl := gomock.NewController(t)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
 	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
 	logger := testlog.NewTestLogger()
-	book := &domain.Book{Title: "A book", ID: uuid.New()}
+	ctx := context.Background()
+	book := &domain.Book{Title: "A book", ID: uuid.New(), Version: 1}
 
 	tests := []struct {
 		name             string
@@ -286,12 +674,16 @@ func TestBookInteractor_DeleteBook(t *testing.T) {
 			},
 		},
 		{
-			name: "succeeds with book not found error",
+			name: "fails with book not found error",
 			id:   book.ID.String(),
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					Delete(book.ID).
-					Return(errors.New("book not found"))
+					Delete(ctx, book.ID, gomock.Any()).
+					Return(nil, errors.New("book not found"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrBookNotFound)
 			},
 		},
 		{
@@ -299,8 +691,8 @@ func TestBookInteractor_DeleteBook(t *testing.T) {
 			id:   book.ID.String(),
 			mockExpectations: func() {
 				mockBookRepository.EXPECT().
-					Delete(book.ID).
-					Return(errors.New("something broke"))
+					Delete(ctx, book.ID, gomock.Any()).
+					Return(nil, errors.New("something broke"))
 			},
 			wantErr: true,
 			compareErr: func(err error) bool {
@@ -308,12 +700,27 @@ func TestBookInteractor_DeleteBook(t *testing.T) {
 			},
 		},
 		{
-			name: "succeeds",
+			name: "succeeds keeping the stock",
 			id:   book.ID.String(),
 			mockExpectations: func() {
+				// The book was updated since it was last read, the entry records it as it was deleted.
+				trashed := &domain.Book{Title: "A book", ID: book.ID, Version: 3, DeletedAt: time.Now().UTC()}
 				mockBookRepository.EXPECT().
-					Delete(book.ID).
-					Return(nil)
+					Delete(ctx, book.ID, isEvent(&domain.Event{Type: domain.EventBookDeleted})).
+					Return(trashed, nil)
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, e *domain.AuditEntry) error {
+						want := []domain.FieldChange{
+							{Field: "price", Before: "0"},
+							{Field: "title", Before: "A book"},
+						}
+						if e.BookID != book.ID || e.Version != 3 || e.Operation != domain.AuditDelete ||
+							!reflect.DeepEqual(e.Changes, want) {
+							t.Errorf("Append() got unexpected entry %+v", e)
+						}
+						return nil
+					})
 			},
 		},
 	}
@@ -322,8 +729,11 @@ func TestBookInteractor_DeleteBook(t *testing.T) {
 			if tt.mockExpectations != nil {
 				tt.mockExpectations()
 			}
-			bi := interactor.NewBookInteractor(logger, mockBookRepository)
-			err := bi.DeleteBook(tt.id)
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mocks.NewMockStockRepository(mockCtl),
+				mocks.NewMockAuthorRepository(mockCtl), mockAuditRepository, interactor.NewCreditLock(),
+			)
+			err := bi.DeleteBook(ctx, tt.id)
 			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
 				t.Errorf("Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -331,3 +741,250 @@ func TestBookInteractor_DeleteBook(t *testing.T) {
 		})
 	}
 }
+
+func TestBookInteractor_ListTrash(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	ctx := context.Background()
+	page := &domain.BookPage{Books: []*domain.Book{{Title: "A book", ID: uuid.New()}}, Total: 1}
+
+	mockBookRepository.EXPECT().
+		ReadPage(ctx, domain.BookQuery{
+			SortBy:  domain.SortByCreated,
+			Limit:   domain.DefaultBookPageSize,
+			Trashed: true,
+		}).
+		Return(page, nil)
+
+	bi := interactor.NewBookInteractor(
+		testlog.NewTestLogger(), mockBookRepository,
+		mocks.NewMockStockRepository(mockCtl), mocks.NewMockAuthorRepository(mockCtl), mocks.NewMockAuditRepository(mockCtl),
+		interactor.NewCreditLock(),
+	)
+	got, err := bi.ListTrash(ctx, domain.BookQuery{})
+	if err != nil {
+		t.Fatalf("ListTrash() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, page) {
+		t.Errorf("ListTrash() got = %v, want %v", got, page)
+	}
+}
+
+func TestBookInteractor_RestoreBook(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	book := &domain.Book{Title: "A book", ID: uuid.New(), Version: 3}
+
+	tests := []struct {
+		name             string
+		id               string
+		want             *domain.Book
+		wantErr          error
+		mockExpectations func()
+	}{
+		{
+			name:    "fails to parse id",
+			id:      "invalid",
+			wantErr: domain.ErrInvalidBookID,
+		},
+		{
+			name: "fails with book not found error",
+			id:   book.ID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					Restore(ctx, book.ID, gomock.Any()).
+					Return(nil, errors.New("book not found"))
+			},
+			wantErr: domain.ErrBookNotFound,
+		},
+		{
+			name: "succeeds",
+			id:   book.ID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					Restore(ctx, book.ID, isEvent(&domain.Event{Type: domain.EventBookRestored})).
+					Return(book, nil)
+				mockAuditRepository.EXPECT().
+					Append(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+			want: book,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mocks.NewMockStockRepository(mockCtl),
+				mocks.NewMockAuthorRepository(mockCtl), mockAuditRepository, interactor.NewCreditLock(),
+			)
+			got, err := bi.RestoreBook(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RestoreBook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RestoreBook() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookInteractor_PurgeTrash(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+	ctx := context.Background()
+	withStock, withoutStock := uuid.New(), uuid.New()
+	const retention = time.Hour
+
+	mockBookRepository.EXPECT().
+		Purge(ctx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, deletedBefore time.Time) ([]uuid.UUID, error) {
+			if age := time.Since(deletedBefore); age < retention || age > retention+time.Minute {
+				t.Errorf("Purge() expected books deleted %s ago, got %s", retention, age)
+			}
+			return []uuid.UUID{withStock, withoutStock}, nil
+		})
+	mockStockRepository.EXPECT().Delete(gomock.Any(), withStock).Return(nil)
+	mockStockRepository.EXPECT().Delete(gomock.Any(), withoutStock).Return(errors.New("stock not found"))
+
+	bi := interactor.NewBookInteractor(
+		testlog.NewTestLogger(), mockBookRepository, mockStockRepository,
+		mocks.NewMockAuthorRepository(mockCtl), mocks.NewMockAuditRepository(mockCtl), interactor.NewCreditLock(),
+	)
+	n, err := bi.PurgeTrash(ctx, retention)
+	if err != nil {
+		t.Fatalf("PurgeTrash() error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("PurgeTrash() expected 2 purged books, got %d", n)
+	}
+}
+
+func TestBookInteractor_GetBookByISBN(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	book := &domain.Book{Title: "A book", ID: uuid.New(), ISBN: "9780306406157"}
+
+	tests := []struct {
+		name             string
+		isbn             string
+		want             *domain.Book
+		wantErr          error
+		mockExpectations func()
+	}{
+		{
+			name:    "fails with invalid isbn",
+			isbn:    "0306406153",
+			wantErr: domain.ErrInvalidISBN,
+		},
+		{
+			name: "fails with book not found error",
+			isbn: "978-0-306-40615-7",
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByISBN(ctx, "9780306406157").
+					Return(nil, errors.New("book not found"))
+			},
+			wantErr: domain.ErrBookNotFound,
+		},
+		{
+			name: "succeeds with ISBN-10",
+			isbn: "0-306-40615-2",
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByISBN(ctx, "9780306406157").
+					Return(book, nil)
+			},
+			want: book,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			bi := interactor.NewBookInteractor(
+				logger, mockBookRepository, mocks.NewMockStockRepository(mockCtl),
+				mocks.NewMockAuthorRepository(mockCtl), mocks.NewMockAuditRepository(mockCtl),
+				interactor.NewCreditLock(),
+			)
+			got, err := bi.GetBookByISBN(ctx, tt.isbn)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetBookByISBN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBookByISBN() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBookInteractor_GetBookHistory(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockAuditRepository := mocks.NewMockAuditRepository(mockCtl)
+	ctx := context.Background()
+	id := uuid.New()
+	page := &domain.AuditPage{Entries: []*domain.AuditEntry{{BookID: id, Operation: domain.AuditCreate}}, Total: 1}
+
+	tests := []struct {
+		name             string
+		id               string
+		want             *domain.AuditPage
+		wantErr          error
+		mockExpectations func()
+	}{
+		{
+			name:    "fails to parse id",
+			id:      "invalid",
+			wantErr: domain.ErrInvalidBookID,
+		},
+		{
+			name: "succeeds with default page size",
+			id:   id.String(),
+			mockExpectations: func() {
+				mockAuditRepository.EXPECT().
+					ReadPage(ctx, domain.AuditQuery{BookID: id, Cursor: "next", Limit: domain.DefaultAuditPageSize}).
+					Return(page, nil)
+			},
+			want: page,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			bi := interactor.NewBookInteractor(
+				testlog.NewTestLogger(), mocks.NewMockBookRepository(mockCtl), mocks.NewMockStockRepository(mockCtl),
+				mocks.NewMockAuthorRepository(mockCtl), mockAuditRepository, interactor.NewCreditLock(),
+			)
+			got, err := bi.GetBookHistory(ctx, tt.id, domain.AuditQuery{Cursor: "next"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GetBookHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetBookHistory() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// isEvent matches a *domain.Event equal to want but for the time it was raised at, which must be set.
+func isEvent(want *domain.Event) gomock.Matcher {
+	return gomock.Cond(func(e *domain.Event) bool {
+		got := *e
+		got.At = time.Time{}
+		return !e.At.IsZero() && reflect.DeepEqual(&got, want)
+	})
+}
@@ -0,0 +1,56 @@
+package interactor
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+)
+
+// BookSearchInteractor handles full-text search business logic.
+type BookSearchInteractor struct {
+	repo    domain.BookRepository
+	index   domain.BookSearchIndex
+	authors domain.AuthorRepository
+	logger  *slog.Logger
+}
+
+// NewBookSearchInteractor creates a new BookSearchInteractor.
+func NewBookSearchInteractor(
+	logger *slog.Logger,
+	repo domain.BookRepository,
+	index domain.BookSearchIndex,
+	authors domain.AuthorRepository,
+) *BookSearchInteractor {
+	return &BookSearchInteractor{repo: repo, index: index, authors: authors, logger: logger}
+}
+
+// SearchBooks retrieves at most limit books matching query, most relevant first.
+// A non-positive limit defaults to domain.DefaultBookPageSize.
+// Books removed between the index lookup and the read are skipped.
+// Does not fail if nothing is found.
+func (si *BookSearchInteractor) SearchBooks(ctx context.Context, query string, limit int) ([]*domain.Book, error) {
+	n := limit
+	if n <= 0 {
+		n = domain.DefaultBookPageSize
+	}
+	ids, err := si.index.Search(ctx, query, n)
+	if err != nil {
+		return nil, err
+	}
+
+	books := make([]*domain.Book, 0, len(ids))
+	for _, id := range ids {
+		b, err := si.repo.ReadByID(ctx, id)
+		if db.IsNotFoundError(err) {
+			si.logger.With("book_id", id).Warn("search index returned a missing book")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		books = append(books, b)
+	}
+	return books, fillCredits(ctx, si.authors, books...)
+}
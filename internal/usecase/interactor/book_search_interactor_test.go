@@ -0,0 +1,115 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+func TestBookSearchInteractor_SearchBooks(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockSearchIndex := mocks.NewMockBookSearchIndex(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	first := &domain.Book{Title: "Dune Messiah", ID: uuid.New()}
+	second := &domain.Book{Title: "Dune", ID: uuid.New()}
+
+	tests := []struct {
+		name             string
+		limit            int
+		want             []*domain.Book
+		wantErr          bool
+		compareErr       func(error) bool
+		mockExpectations func()
+	}{
+		{
+			name:  "fails to search the index",
+			limit: 5,
+			mockExpectations: func() {
+				mockSearchIndex.EXPECT().
+					Search(ctx, "dune", 5).
+					Return(nil, errors.New("oops"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "oops")
+			},
+		},
+		{
+			name:  "fails to read a book",
+			limit: 5,
+			mockExpectations: func() {
+				mockSearchIndex.EXPECT().
+					Search(ctx, "dune", 5).
+					Return([]uuid.UUID{first.ID}, nil)
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, first.ID).
+					Return(nil, errors.New("something broke"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "something broke")
+			},
+		},
+		{
+			name: "skips missing books and applies default limit",
+			mockExpectations: func() {
+				mockSearchIndex.EXPECT().
+					Search(ctx, "dune", domain.DefaultBookPageSize).
+					Return([]uuid.UUID{first.ID, second.ID}, nil)
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, first.ID).
+					Return(nil, errors.New("book not found"))
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, second.ID).
+					Return(second, nil)
+			},
+			want: []*domain.Book{second},
+		},
+		{
+			name:  "succeeds preserving relevance order",
+			limit: 2,
+			mockExpectations: func() {
+				mockSearchIndex.EXPECT().
+					Search(ctx, "dune", 2).
+					Return([]uuid.UUID{first.ID, second.ID}, nil)
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, first.ID).
+					Return(first, nil)
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, second.ID).
+					Return(second, nil)
+			},
+			want: []*domain.Book{first, second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			si := interactor.NewBookSearchInteractor(
+				logger, mockBookRepository, mockSearchIndex, mocks.NewMockAuthorRepository(mockCtl),
+			)
+			got, err := si.SearchBooks(ctx, "dune", tt.limit)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("SearchBooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SearchBooks() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package interactor
+
+import "sync"
+
+// CreditLock serializes the deletion of authors with the writes of the books crediting them.
+// Books are credited under a read lock, from the check that their authors exist to the write of the book,
+// while an author is deleted under the write lock, from the check that they have no books to the delete:
+// an author can't be deleted while a book crediting them is written.
+// The BookInteractor and AuthorInteractor of a service must share the same CreditLock.
+type CreditLock struct {
+	mu sync.RWMutex
+}
+
+// NewCreditLock creates a new CreditLock.
+func NewCreditLock() *CreditLock {
+	return &CreditLock{}
+}
@@ -0,0 +1,56 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+)
+
+// EventDispatcher delivers the events waiting in an outbox to their subscribers.
+// An event is removed from the outbox only after it was published, so every event is delivered at least once:
+// a failure between the two, or of any subscriber, delivers the event again on the next Dispatch.
+type EventDispatcher struct {
+	outbox    domain.EventOutbox
+	publisher domain.EventPublisher
+	logger    *slog.Logger
+}
+
+// NewEventDispatcher creates a new EventDispatcher.
+func NewEventDispatcher(
+	logger *slog.Logger,
+	outbox domain.EventOutbox,
+	publisher domain.EventPublisher,
+) *EventDispatcher {
+	return &EventDispatcher{outbox: outbox, publisher: publisher, logger: logger}
+}
+
+// Dispatch publishes up to limit pending events, oldest first, and returns how many were delivered.
+// Events are delivered in the order they were raised: the first failure stops the batch,
+// and the failed event is the first to be published again by the next Dispatch.
+func (ed *EventDispatcher) Dispatch(ctx context.Context, limit int) (int, error) {
+	pending, err := ed.outbox.Pending(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+	delivered := make([]uuid.UUID, 0, len(pending))
+	var publishErr error
+	for _, e := range pending {
+		if publishErr = ed.publisher.Publish(ctx, e); publishErr != nil {
+			ed.logger.With("error", publishErr, "event_id", e.ID, "type", e.Type).Warn("failed to publish event")
+			break
+		}
+		delivered = append(delivered, e.ID)
+	}
+	if len(delivered) == 0 {
+		return 0, publishErr
+	}
+	// The events were published already, they must leave the outbox even if the caller gave up.
+	if err = ed.outbox.Ack(context.WithoutCancel(ctx), delivered...); err != nil {
+		return 0, errors.Join(publishErr, err)
+	}
+	return len(delivered), publishErr
+}
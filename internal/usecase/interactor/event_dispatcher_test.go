@@ -0,0 +1,136 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+func TestEventDispatcher_Dispatch(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockEventOutbox := mocks.NewMockEventOutbox(mockCtl)
+	mockEventPublisher := mocks.NewMockEventPublisher(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	first := &domain.Event{ID: uuid.New(), Type: domain.EventBookCreated}
+	second := &domain.Event{ID: uuid.New(), Type: domain.EventBookPriceChanged}
+	third := &domain.Event{ID: uuid.New(), Type: domain.EventBookDeleted}
+
+	tests := []struct {
+		name             string
+		want             int
+		wantErr          bool
+		compareErr       func(error) bool
+		mockExpectations func()
+	}{
+		{
+			name: "fails to read the outbox",
+			mockExpectations: func() {
+				mockEventOutbox.EXPECT().
+					Pending(ctx, 10).
+					Return(nil, errors.New("disk error"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "disk error")
+			},
+		},
+		{
+			name: "succeeds with nothing pending",
+			mockExpectations: func() {
+				mockEventOutbox.EXPECT().
+					Pending(ctx, 10).
+					Return([]*domain.Event{}, nil)
+			},
+		},
+		{
+			name: "succeeds delivering in order",
+			mockExpectations: func() {
+				mockEventOutbox.EXPECT().
+					Pending(ctx, 10).
+					Return([]*domain.Event{first, second, third}, nil)
+				gomock.InOrder(
+					mockEventPublisher.EXPECT().Publish(ctx, first).Return(nil),
+					mockEventPublisher.EXPECT().Publish(ctx, second).Return(nil),
+					mockEventPublisher.EXPECT().Publish(ctx, third).Return(nil),
+				)
+				mockEventOutbox.EXPECT().
+					Ack(gomock.Any(), first.ID, second.ID, third.ID).
+					Return(nil)
+			},
+			want: 3,
+		},
+		{
+			name: "stops at the first failure, acknowledging what was delivered",
+			mockExpectations: func() {
+				mockEventOutbox.EXPECT().
+					Pending(ctx, 10).
+					Return([]*domain.Event{first, second, third}, nil)
+				mockEventPublisher.EXPECT().Publish(ctx, first).Return(nil)
+				mockEventPublisher.EXPECT().Publish(ctx, second).Return(errors.New("subscriber down"))
+				mockEventOutbox.EXPECT().
+					Ack(gomock.Any(), first.ID).
+					Return(nil)
+			},
+			want:    1,
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "subscriber down")
+			},
+		},
+		{
+			name: "fails without acknowledging when nothing was delivered",
+			mockExpectations: func() {
+				mockEventOutbox.EXPECT().
+					Pending(ctx, 10).
+					Return([]*domain.Event{first}, nil)
+				mockEventPublisher.EXPECT().Publish(ctx, first).Return(errors.New("subscriber down"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "subscriber down")
+			},
+		},
+		{
+			name: "fails to acknowledge",
+			mockExpectations: func() {
+				mockEventOutbox.EXPECT().
+					Pending(ctx, 10).
+					Return([]*domain.Event{first}, nil)
+				mockEventPublisher.EXPECT().Publish(ctx, first).Return(nil)
+				mockEventOutbox.EXPECT().
+					Ack(gomock.Any(), first.ID).
+					Return(errors.New("disk full"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "disk full")
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			ed := interactor.NewEventDispatcher(logger, mockEventOutbox, mockEventPublisher)
+			got, err := ed.Dispatch(ctx, 10)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("Dispatch() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Dispatch() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package interactor
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+)
+
+// InventoryInteractor handles stock management business logic.
+type InventoryInteractor struct {
+	books  domain.BookRepository
+	stocks domain.StockRepository
+	logger *slog.Logger
+}
+
+// NewInventoryInteractor creates a new InventoryInteractor.
+func NewInventoryInteractor(
+	logger *slog.Logger,
+	books domain.BookRepository,
+	stocks domain.StockRepository,
+) *InventoryInteractor {
+	return &InventoryInteractor{books: books, stocks: stocks, logger: logger}
+}
+
+// GetStock retrieves the stock of the book matching id.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+// A book without a stored stock has no copies.
+func (ii *InventoryInteractor) GetStock(ctx context.Context, id string) (*domain.Stock, error) {
+	uid, err := ii.bookID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := ii.stocks.ReadByBookID(ctx, uid)
+	if db.IsStockNotFoundError(err) {
+		return &domain.Stock{BookID: uid}, nil
+	}
+	return stock, err
+}
+
+// ReceiveStock adds quantity delivered copies to the stock of the book matching id.
+func (ii *InventoryInteractor) ReceiveStock(ctx context.Context, id string, quantity int) (*domain.Stock, error) {
+	return ii.update(ctx, id, func(s *domain.Stock) error {
+		return s.Receive(quantity)
+	})
+}
+
+// AdjustStock corrects the copies on hand of the book matching id by delta.
+// Fails with domain.ErrInsufficientStock if fewer copies than are reserved would be left.
+func (ii *InventoryInteractor) AdjustStock(ctx context.Context, id string, delta int) (*domain.Stock, error) {
+	return ii.update(ctx, id, func(s *domain.Stock) error {
+		return s.Adjust(delta)
+	})
+}
+
+// ReserveStock sets quantity copies of the book matching id aside.
+// Fails with domain.ErrInsufficientStock if fewer copies are available,
+// concurrent reservations included.
+func (ii *InventoryInteractor) ReserveStock(ctx context.Context, id string, quantity int) (*domain.Stock, error) {
+	return ii.update(ctx, id, func(s *domain.Stock) error {
+		return s.Reserve(quantity)
+	})
+}
+
+// update atomically applies change to the stock of the book matching id.
+// Books created before their stock was tracked get an empty one first.
+func (ii *InventoryInteractor) update(
+	ctx context.Context,
+	id string,
+	change func(*domain.Stock) error,
+) (*domain.Stock, error) {
+	uid, err := ii.bookID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stock, err := ii.stocks.Update(ctx, uid, change)
+	if !db.IsStockNotFoundError(err) {
+		return stock, err
+	}
+	ii.logger.With("book_id", uid).Warn("initializing missing stock")
+	if err = ii.stocks.Create(ctx, &domain.Stock{BookID: uid}); err != nil {
+		return nil, err
+	}
+	return ii.stocks.Update(ctx, uid, change)
+}
+
+// bookID parses id and checks the book exists.
+func (ii *InventoryInteractor) bookID(ctx context.Context, id string) (uuid.UUID, error) {
+	uid, err := domain.BookResource.Parse(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	_, err = ii.books.ReadByID(ctx, uid)
+	if db.IsNotFoundError(err) {
+		return uuid.Nil, domain.ErrBookNotFound
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uid, nil
+}
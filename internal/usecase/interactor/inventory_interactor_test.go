@@ -0,0 +1,323 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+func TestInventoryInteractor_GetStock(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	bookID := uuid.New()
+
+	tests := []struct {
+		name             string
+		id               string
+		want             *domain.Stock
+		wantErr          bool
+		compareErr       func(error) bool
+		mockExpectations func()
+	}{
+		{
+			name:    "fails to parse id",
+			id:      "invalid",
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidBookID)
+			},
+		},
+		{
+			name: "fails with book not found error",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, bookID).
+					Return(nil, errors.New("book not found"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrBookNotFound)
+			},
+		},
+		{
+			name: "fails with generic error",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, bookID).
+					Return(&domain.Book{ID: bookID}, nil)
+				mockStockRepository.EXPECT().
+					ReadByBookID(ctx, bookID).
+					Return(nil, errors.New("something broke"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "something broke")
+			},
+		},
+		{
+			name: "succeeds with untracked stock",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, bookID).
+					Return(&domain.Book{ID: bookID}, nil)
+				mockStockRepository.EXPECT().
+					ReadByBookID(ctx, bookID).
+					Return(nil, errors.New("stock not found"))
+			},
+			want: &domain.Stock{BookID: bookID},
+		},
+		{
+			name: "succeeds",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, bookID).
+					Return(&domain.Book{ID: bookID}, nil)
+				mockStockRepository.EXPECT().
+					ReadByBookID(ctx, bookID).
+					Return(&domain.Stock{BookID: bookID, OnHand: 3, Reserved: 1}, nil)
+			},
+			want: &domain.Stock{BookID: bookID, OnHand: 3, Reserved: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			ii := interactor.NewInventoryInteractor(logger, mockBookRepository, mockStockRepository)
+			got, err := ii.GetStock(ctx, tt.id)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("GetStock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetStock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryInteractor_UpdateStock(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	bookID := uuid.New()
+	ii := interactor.NewInventoryInteractor(logger, mockBookRepository, mockStockRepository)
+
+	// stored is the stock the mocked repository applies changes to.
+	var stored *domain.Stock
+	update := func(_ context.Context, _ uuid.UUID, change func(*domain.Stock) error) (*domain.Stock, error) {
+		s := *stored
+		if err := change(&s); err != nil {
+			return nil, err
+		}
+		*stored = s
+		return &s, nil
+	}
+
+	tests := []struct {
+		name             string
+		stored           *domain.Stock
+		operation        func() (*domain.Stock, error)
+		want             *domain.Stock
+		wantErr          bool
+		compareErr       func(error) bool
+		mockExpectations func()
+	}{
+		{
+			name: "receive fails with invalid quantity",
+			operation: func() (*domain.Stock, error) {
+				return ii.ReceiveStock(ctx, bookID.String(), 0)
+			},
+			stored:  &domain.Stock{BookID: bookID},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidQuantity)
+			},
+		},
+		{
+			name: "receive succeeds",
+			operation: func() (*domain.Stock, error) {
+				return ii.ReceiveStock(ctx, bookID.String(), 5)
+			},
+			stored: &domain.Stock{BookID: bookID, OnHand: 1},
+			want:   &domain.Stock{BookID: bookID, OnHand: 6},
+		},
+		{
+			name: "adjust fails below reserved copies",
+			operation: func() (*domain.Stock, error) {
+				return ii.AdjustStock(ctx, bookID.String(), -3)
+			},
+			stored:  &domain.Stock{BookID: bookID, OnHand: 4, Reserved: 2},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInsufficientStock)
+			},
+		},
+		{
+			name: "adjust succeeds",
+			operation: func() (*domain.Stock, error) {
+				return ii.AdjustStock(ctx, bookID.String(), -2)
+			},
+			stored: &domain.Stock{BookID: bookID, OnHand: 4, Reserved: 2},
+			want:   &domain.Stock{BookID: bookID, OnHand: 2, Reserved: 2},
+		},
+		{
+			name: "reserve fails with insufficient stock",
+			operation: func() (*domain.Stock, error) {
+				return ii.ReserveStock(ctx, bookID.String(), 3)
+			},
+			stored:  &domain.Stock{BookID: bookID, OnHand: 4, Reserved: 2},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInsufficientStock)
+			},
+		},
+		{
+			name: "reserve succeeds",
+			operation: func() (*domain.Stock, error) {
+				return ii.ReserveStock(ctx, bookID.String(), 2)
+			},
+			stored: &domain.Stock{BookID: bookID, OnHand: 4, Reserved: 2},
+			want:   &domain.Stock{BookID: bookID, OnHand: 4, Reserved: 4},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored = tt.stored
+			mockBookRepository.EXPECT().
+				ReadByID(ctx, bookID).
+				Return(&domain.Book{ID: bookID}, nil)
+			mockStockRepository.EXPECT().
+				Update(ctx, bookID, gomock.Any()).
+				DoAndReturn(update)
+
+			got, err := tt.operation()
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("operation error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("operation got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInventoryInteractor_ReceiveStock(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+	mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	bookID := uuid.New()
+
+	tests := []struct {
+		name             string
+		id               string
+		want             *domain.Stock
+		wantErr          bool
+		compareErr       func(error) bool
+		mockExpectations func()
+	}{
+		{
+			name:    "fails to parse id",
+			id:      "invalid",
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidBookID)
+			},
+		},
+		{
+			name: "fails with book not found error",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, bookID).
+					Return(nil, errors.New("book not found"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrBookNotFound)
+			},
+		},
+		{
+			name: "fails to initialize missing stock",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, bookID).
+					Return(&domain.Book{ID: bookID}, nil)
+				mockStockRepository.EXPECT().
+					Update(ctx, bookID, gomock.Any()).
+					Return(nil, errors.New("stock not found"))
+				mockStockRepository.EXPECT().
+					Create(ctx, &domain.Stock{BookID: bookID}).
+					Return(errors.New("something broke"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "something broke")
+			},
+		},
+		{
+			name: "succeeds initializing missing stock",
+			id:   bookID.String(),
+			mockExpectations: func() {
+				mockBookRepository.EXPECT().
+					ReadByID(ctx, bookID).
+					Return(&domain.Book{ID: bookID}, nil)
+				gomock.InOrder(
+					mockStockRepository.EXPECT().
+						Update(ctx, bookID, gomock.Any()).
+						Return(nil, errors.New("stock not found")),
+					mockStockRepository.EXPECT().
+						Create(ctx, &domain.Stock{BookID: bookID}).
+						Return(nil),
+					mockStockRepository.EXPECT().
+						Update(ctx, bookID, gomock.Any()).
+						DoAndReturn(func(_ context.Context, id uuid.UUID, change func(*domain.Stock) error) (*domain.Stock, error) {
+							s := &domain.Stock{BookID: id}
+							return s, change(s)
+						}),
+				)
+			},
+			want: &domain.Stock{BookID: bookID, OnHand: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.mockExpectations != nil {
+				tt.mockExpectations()
+			}
+			ii := interactor.NewInventoryInteractor(logger, mockBookRepository, mockStockRepository)
+			got, err := ii.ReceiveStock(ctx, tt.id, 2)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("ReceiveStock() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ReceiveStock() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
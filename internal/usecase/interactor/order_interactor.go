@@ -0,0 +1,201 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+)
+
+// OrderInteractor handles checkout and order lifecycle business logic.
+type OrderInteractor struct {
+	books  domain.BookRepository
+	stocks domain.StockRepository
+	orders domain.OrderRepository
+	logger *slog.Logger
+}
+
+// NewOrderInteractor creates a new OrderInteractor.
+func NewOrderInteractor(
+	logger *slog.Logger,
+	books domain.BookRepository,
+	stocks domain.StockRepository,
+	orders domain.OrderRepository,
+) *OrderInteractor {
+	return &OrderInteractor{books: books, stocks: stocks, orders: orders, logger: logger}
+}
+
+// PlaceOrder creates a pending order for the requested copies of each book.
+// Only BookID and Quantity of items are read: titles and prices are taken from the catalog.
+// The copies are reserved, so the order fails with domain.ErrInsufficientStock
+// if any book doesn't have enough available copies; no copies are reserved in that case.
+func (oi *OrderInteractor) PlaceOrder(ctx context.Context, items []domain.OrderItem) (*domain.Order, error) {
+	priced := make([]domain.OrderItem, 0, len(items))
+	for _, item := range items {
+		book, err := oi.books.ReadByID(ctx, item.BookID)
+		if db.IsNotFoundError(err) {
+			return nil, domain.ErrBookNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		priced = append(priced, domain.OrderItem{
+			BookID:    book.ID,
+			Title:     book.Title,
+			UnitPrice: book.Price,
+			Quantity:  item.Quantity,
+		})
+	}
+	order, err := domain.NewOrder(priced)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, item := range order.Items {
+		err = oi.changeStock(ctx, item, (*domain.Stock).Reserve)
+		if db.IsStockNotFoundError(err) {
+			err = domain.ErrInsufficientStock
+		}
+		if err != nil {
+			return nil, errors.Join(err, oi.revertStock(ctx, order.Items[:i], (*domain.Stock).Release))
+		}
+	}
+	if err = oi.orders.Create(ctx, order); err != nil {
+		return nil, errors.Join(err, oi.revertStock(ctx, order.Items, (*domain.Stock).Release))
+	}
+	return order, nil
+}
+
+// GetOrder retrieves a domain.Order by its ID.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+func (oi *OrderInteractor) GetOrder(ctx context.Context, id string) (*domain.Order, error) {
+	uid, err := domain.OrderResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	o, err := oi.orders.ReadByID(ctx, uid)
+	if db.IsOrderNotFoundError(err) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return o, err
+}
+
+// ListOrders retrieves every order, oldest first.
+func (oi *OrderInteractor) ListOrders(ctx context.Context) ([]*domain.Order, error) {
+	return oi.orders.ReadAll(ctx)
+}
+
+// PayOrder marks a pending order as paid.
+func (oi *OrderInteractor) PayOrder(ctx context.Context, id string) (*domain.Order, error) {
+	return oi.transition(ctx, id, domain.OrderPaid)
+}
+
+// ShipOrder marks a paid order as shipped, removing its reserved copies from the warehouse.
+func (oi *OrderInteractor) ShipOrder(ctx context.Context, id string) (*domain.Order, error) {
+	return oi.transitionWithStock(ctx, id, domain.OrderShipped, (*domain.Stock).Fulfil, (*domain.Stock).Unfulfil)
+}
+
+// CancelOrder cancels an order that was not shipped yet, releasing its reserved copies.
+func (oi *OrderInteractor) CancelOrder(ctx context.Context, id string) (*domain.Order, error) {
+	return oi.transitionWithStock(ctx, id, domain.OrderCancelled, (*domain.Stock).Release, (*domain.Stock).Reserve)
+}
+
+// transition moves the order matching id to status.
+// Illegal transitions fail with domain.ErrIllegalOrderTransition.
+func (oi *OrderInteractor) transition(
+	ctx context.Context,
+	id string,
+	status domain.OrderStatus,
+) (*domain.Order, error) {
+	uid, err := domain.OrderResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	return oi.update(ctx, uid, status)
+}
+
+// transitionWithStock moves the order matching id to status like transition,
+// applying change to the stock of its items while the order is updated: no other update of the order
+// runs in the meantime, so concurrent requests can't both move its stock.
+// If the stock of an item can't be changed, or the order can't be stored, the stock already changed
+// is restored with undo: the order keeps its status and stock, so that the request can be retried.
+func (oi *OrderInteractor) transitionWithStock(
+	ctx context.Context,
+	id string,
+	status domain.OrderStatus,
+	change, undo func(*domain.Stock, int) error,
+) (*domain.Order, error) {
+	uid, err := domain.OrderResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	var moved []domain.OrderItem
+	o, err := oi.orders.Update(ctx, uid, func(o *domain.Order) error {
+		if err := o.TransitionTo(status); err != nil {
+			return err
+		}
+		for i, item := range o.Items {
+			if err := oi.changeStock(ctx, item, change); err != nil {
+				return errors.Join(err, oi.revertStock(ctx, o.Items[:i], undo))
+			}
+		}
+		moved = o.Items
+		return nil
+	})
+	if db.IsOrderNotFoundError(err) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, errors.Join(err, oi.revertStock(ctx, moved, undo))
+	}
+	return o, nil
+}
+
+// update moves the order matching uid to status.
+func (oi *OrderInteractor) update(
+	ctx context.Context,
+	uid uuid.UUID,
+	status domain.OrderStatus,
+) (*domain.Order, error) {
+	o, err := oi.orders.Update(ctx, uid, func(o *domain.Order) error {
+		return o.TransitionTo(status)
+	})
+	if db.IsOrderNotFoundError(err) {
+		return nil, domain.ErrOrderNotFound
+	}
+	return o, err
+}
+
+// revertStock applies undo to the stock of items, undoing a change that was applied to them.
+// It runs even if ctx is done, so that a cancelled request doesn't leave the stock half changed.
+func (oi *OrderInteractor) revertStock(
+	ctx context.Context,
+	items []domain.OrderItem,
+	undo func(*domain.Stock, int) error,
+) error {
+	ctx = context.WithoutCancel(ctx)
+	var errs []error
+	for _, item := range items {
+		if err := oi.changeStock(ctx, item, undo); err != nil {
+			oi.logger.With("book_id", item.BookID, "error", err).Error("failed to revert stock")
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// changeStock applies change with the item quantity to the stock of the item book.
+func (oi *OrderInteractor) changeStock(
+	ctx context.Context,
+	item domain.OrderItem,
+	change func(*domain.Stock, int) error,
+) error {
+	_, err := oi.stocks.Update(ctx, item.BookID, func(s *domain.Stock) error {
+		return change(s, item.Quantity)
+	})
+	return err
+}
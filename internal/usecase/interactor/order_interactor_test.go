@@ -0,0 +1,476 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+// fakeStocks makes a mock stock repository apply changes to the given stocks.
+func fakeStocks(m *mocks.MockStockRepository, stocks map[uuid.UUID]*domain.Stock) {
+	m.EXPECT().
+		Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id uuid.UUID, change func(*domain.Stock) error) (*domain.Stock, error) {
+			s, ok := stocks[id]
+			if !ok {
+				return nil, errors.New("stock not found")
+			}
+			c := *s
+			if err := change(&c); err != nil {
+				return nil, err
+			}
+			*s = c
+			return &c, nil
+		}).
+		AnyTimes()
+}
+
+// fakeOrders makes a mock order repository read and update the given order.
+func fakeOrders(m *mocks.MockOrderRepository, order *domain.Order) {
+	m.EXPECT().
+		ReadByID(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id uuid.UUID) (*domain.Order, error) {
+			if id != order.ID {
+				return nil, errors.New("order not found")
+			}
+			o := *order
+			return &o, nil
+		}).
+		AnyTimes()
+	m.EXPECT().
+		Update(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, id uuid.UUID, change func(*domain.Order) error) (*domain.Order, error) {
+			if id != order.ID {
+				return nil, errors.New("order not found")
+			}
+			o := *order
+			if err := change(&o); err != nil {
+				return nil, err
+			}
+			*order = o
+			return &o, nil
+		}).
+		AnyTimes()
+}
+
+func TestOrderInteractor_PlaceOrder(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	dune := &domain.Book{ID: uuid.New(), Title: "Dune", Price: domain.Money{Amount: 1000, Currency: "EUR"}}
+	emma := &domain.Book{ID: uuid.New(), Title: "Emma", Price: domain.Money{Amount: 750, Currency: "EUR"}}
+	dollars := &domain.Book{ID: uuid.New(), Title: "Ulysses", Price: domain.Money{Amount: 900, Currency: "USD"}}
+
+	tests := []struct {
+		name             string
+		items            []domain.OrderItem
+		want             *domain.Order
+		wantStocks       map[uuid.UUID]domain.Stock
+		wantErr          bool
+		compareErr       func(error) bool
+		mockExpectations func(mockOrderRepository *mocks.MockOrderRepository)
+	}{
+		{
+			name:    "fails with book not found error",
+			items:   []domain.OrderItem{{BookID: uuid.New(), Quantity: 1}},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrBookNotFound)
+			},
+		},
+		{
+			name:    "fails with currency mismatch error",
+			items:   []domain.OrderItem{{BookID: dune.ID, Quantity: 1}, {BookID: dollars.ID, Quantity: 1}},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrCurrencyMismatch)
+			},
+		},
+		{
+			name:    "fails with insufficient stock error releasing reserved copies",
+			items:   []domain.OrderItem{{BookID: dune.ID, Quantity: 2}, {BookID: emma.ID, Quantity: 4}},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInsufficientStock)
+			},
+			wantStocks: map[uuid.UUID]domain.Stock{
+				dune.ID: {BookID: dune.ID, OnHand: 5},
+				emma.ID: {BookID: emma.ID, OnHand: 3},
+			},
+		},
+		{
+			name:    "fails with insufficient stock error for untracked stock",
+			items:   []domain.OrderItem{{BookID: dollars.ID, Quantity: 1}},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInsufficientStock)
+			},
+		},
+		{
+			name:  "fails with generic error releasing reserved copies",
+			items: []domain.OrderItem{{BookID: dune.ID, Quantity: 2}},
+			mockExpectations: func(mockOrderRepository *mocks.MockOrderRepository) {
+				mockOrderRepository.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("something broke"))
+			},
+			wantErr: true,
+			compareErr: func(err error) bool {
+				return strings.Contains(err.Error(), "something broke")
+			},
+			wantStocks: map[uuid.UUID]domain.Stock{
+				dune.ID: {BookID: dune.ID, OnHand: 5},
+				emma.ID: {BookID: emma.ID, OnHand: 3},
+			},
+		},
+		{
+			name:  "succeeds",
+			items: []domain.OrderItem{{BookID: dune.ID, Quantity: 2}, {BookID: emma.ID, Quantity: 3}},
+			mockExpectations: func(mockOrderRepository *mocks.MockOrderRepository) {
+				mockOrderRepository.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+			},
+			want: &domain.Order{
+				Status: domain.OrderPending,
+				Items: []domain.OrderItem{
+					{BookID: dune.ID, Title: "Dune", UnitPrice: dune.Price, Quantity: 2},
+					{BookID: emma.ID, Title: "Emma", UnitPrice: emma.Price, Quantity: 3},
+				},
+				Total: domain.Money{Amount: 4250, Currency: "EUR"},
+			},
+			wantStocks: map[uuid.UUID]domain.Stock{
+				dune.ID: {BookID: dune.ID, OnHand: 5, Reserved: 2},
+				emma.ID: {BookID: emma.ID, OnHand: 3, Reserved: 3},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			mockBookRepository := mocks.NewMockBookRepository(mockCtl)
+			mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+			mockOrderRepository := mocks.NewMockOrderRepository(mockCtl)
+			stocks := map[uuid.UUID]*domain.Stock{
+				dune.ID: {BookID: dune.ID, OnHand: 5},
+				emma.ID: {BookID: emma.ID, OnHand: 3},
+			}
+			fakeStocks(mockStockRepository, stocks)
+			mockBookRepository.EXPECT().
+				ReadByID(ctx, gomock.Any()).
+				DoAndReturn(func(_ context.Context, id uuid.UUID) (*domain.Book, error) {
+					for _, b := range []*domain.Book{dune, emma, dollars} {
+						if b.ID == id {
+							return b, nil
+						}
+					}
+					return nil, errors.New("book not found")
+				}).
+				AnyTimes()
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockOrderRepository)
+			}
+			oi := interactor.NewOrderInteractor(logger, mockBookRepository, mockStockRepository, mockOrderRepository)
+
+			got, err := oi.PlaceOrder(ctx, tt.items)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("PlaceOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != nil {
+				got.CreatedAt, got.UpdatedAt = tt.want.CreatedAt, tt.want.UpdatedAt
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("PlaceOrder() got = %+v, want %+v", got, tt.want)
+			}
+			for id, want := range tt.wantStocks {
+				if *stocks[id] != want {
+					t.Errorf("PlaceOrder() left stock %+v, want %+v", *stocks[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderInteractor_Lifecycle(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	orderID := uuid.New()
+	bookID := uuid.New()
+
+	tests := []struct {
+		name       string
+		id         string
+		status     domain.OrderStatus
+		operation  func(oi *interactor.OrderInteractor, ctx context.Context, id string) (*domain.Order, error)
+		want       domain.OrderStatus
+		wantStock  domain.Stock
+		wantErr    bool
+		compareErr func(error) bool
+	}{
+		{
+			name:      "fails to parse id",
+			id:        "invalid",
+			operation: (*interactor.OrderInteractor).PayOrder,
+			wantErr:   true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrInvalidOrderID)
+			},
+		},
+		{
+			name:      "fails with order not found error",
+			id:        uuid.NewString(),
+			operation: (*interactor.OrderInteractor).PayOrder,
+			wantErr:   true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrOrderNotFound)
+			},
+		},
+		{
+			name:      "fails to ship a pending order",
+			id:        orderID.String(),
+			status:    domain.OrderPending,
+			operation: (*interactor.OrderInteractor).ShipOrder,
+			wantErr:   true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrIllegalOrderTransition)
+			},
+		},
+		{
+			name:      "fails to cancel a shipped order",
+			id:        orderID.String(),
+			status:    domain.OrderShipped,
+			operation: (*interactor.OrderInteractor).CancelOrder,
+			wantErr:   true,
+			compareErr: func(err error) bool {
+				return errors.Is(err, domain.ErrIllegalOrderTransition)
+			},
+		},
+		{
+			name:      "pays a pending order",
+			id:        orderID.String(),
+			status:    domain.OrderPending,
+			operation: (*interactor.OrderInteractor).PayOrder,
+			want:      domain.OrderPaid,
+			wantStock: domain.Stock{BookID: bookID, OnHand: 5, Reserved: 2},
+		},
+		{
+			name:      "ships a paid order removing its copies",
+			id:        orderID.String(),
+			status:    domain.OrderPaid,
+			operation: (*interactor.OrderInteractor).ShipOrder,
+			want:      domain.OrderShipped,
+			wantStock: domain.Stock{BookID: bookID, OnHand: 3},
+		},
+		{
+			name:      "cancels a paid order releasing its copies",
+			id:        orderID.String(),
+			status:    domain.OrderPaid,
+			operation: (*interactor.OrderInteractor).CancelOrder,
+			want:      domain.OrderCancelled,
+			wantStock: domain.Stock{BookID: bookID, OnHand: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+			mockOrderRepository := mocks.NewMockOrderRepository(mockCtl)
+			oi := interactor.NewOrderInteractor(
+				logger, mocks.NewMockBookRepository(mockCtl), mockStockRepository, mockOrderRepository,
+			)
+			stock := &domain.Stock{BookID: bookID, OnHand: 5, Reserved: 2}
+			fakeStocks(mockStockRepository, map[uuid.UUID]*domain.Stock{bookID: stock})
+			fakeOrders(mockOrderRepository, &domain.Order{
+				ID:     orderID,
+				Status: tt.status,
+				Items:  []domain.OrderItem{{BookID: bookID, Quantity: 2}},
+			})
+
+			got, err := tt.operation(oi, ctx, tt.id)
+			if (tt.wantErr != (err != nil)) || (tt.wantErr && !tt.compareErr(err)) {
+				t.Errorf("operation error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Status != tt.want {
+				t.Errorf("operation got status %s, want %s", got.Status, tt.want)
+			}
+			if *stock != tt.wantStock {
+				t.Errorf("operation left stock %+v, want %+v", *stock, tt.wantStock)
+			}
+		})
+	}
+}
+
+func TestOrderInteractor_RevertsStock(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	bookID, otherBookID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		operation  func(oi *interactor.OrderInteractor, ctx context.Context, id string) (*domain.Order, error)
+		updateErr  error
+		wantErr    error
+		name       string
+		status     domain.OrderStatus
+		otherStock domain.Stock
+	}{
+		{
+			name:       "ships no copies if a book lacks reserved copies",
+			status:     domain.OrderPaid,
+			operation:  (*interactor.OrderInteractor).ShipOrder,
+			otherStock: domain.Stock{BookID: otherBookID, OnHand: 5},
+			wantErr:    domain.ErrInsufficientStock,
+		},
+		{
+			name:       "releases no copies if a book lacks reserved copies",
+			status:     domain.OrderPaid,
+			operation:  (*interactor.OrderInteractor).CancelOrder,
+			otherStock: domain.Stock{BookID: otherBookID, OnHand: 5},
+			wantErr:    domain.ErrInsufficientStock,
+		},
+		{
+			name:       "puts shipped copies back if the order can't be stored",
+			status:     domain.OrderPaid,
+			operation:  (*interactor.OrderInteractor).ShipOrder,
+			otherStock: domain.Stock{BookID: otherBookID, OnHand: 5, Reserved: 1},
+			updateErr:  context.DeadlineExceeded,
+			wantErr:    context.DeadlineExceeded,
+		},
+		{
+			name:       "reserves released copies again if the order can't be stored",
+			status:     domain.OrderPending,
+			operation:  (*interactor.OrderInteractor).CancelOrder,
+			otherStock: domain.Stock{BookID: otherBookID, OnHand: 5, Reserved: 1},
+			updateErr:  context.DeadlineExceeded,
+			wantErr:    context.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			mockStockRepository := mocks.NewMockStockRepository(mockCtl)
+			mockOrderRepository := mocks.NewMockOrderRepository(mockCtl)
+			oi := interactor.NewOrderInteractor(
+				logger, mocks.NewMockBookRepository(mockCtl), mockStockRepository, mockOrderRepository,
+			)
+			stock := &domain.Stock{BookID: bookID, OnHand: 5, Reserved: 2}
+			otherStock := tt.otherStock
+			fakeStocks(mockStockRepository, map[uuid.UUID]*domain.Stock{bookID: stock, otherBookID: &otherStock})
+			order := &domain.Order{
+				ID:     uuid.New(),
+				Status: tt.status,
+				Items:  []domain.OrderItem{{BookID: bookID, Quantity: 2}, {BookID: otherBookID, Quantity: 1}},
+			}
+			mockOrderRepository.EXPECT().
+				Update(ctx, order.ID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ uuid.UUID, change func(*domain.Order) error) (*domain.Order, error) {
+					o := *order
+					if err := change(&o); err != nil {
+						return nil, err
+					}
+					return nil, tt.updateErr
+				})
+
+			if _, err := tt.operation(oi, ctx, order.ID.String()); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("operation error = %v, want %v", err, tt.wantErr)
+			}
+			if want := (domain.Stock{BookID: bookID, OnHand: 5, Reserved: 2}); *stock != want {
+				t.Errorf("operation left stock %+v, want %+v", *stock, want)
+			}
+			if otherStock != tt.otherStock {
+				t.Errorf("operation left stock %+v, want %+v", otherStock, tt.otherStock)
+			}
+		})
+	}
+}
+
+// slowStocks is an in-memory stock repository slow to update, widening the window of races on the stock.
+type slowStocks struct {
+	*db.InMemoryStockRepo
+}
+
+// Update waits a bit before updating the stock.
+func (s slowStocks) Update(
+	ctx context.Context,
+	bookID uuid.UUID,
+	change func(*domain.Stock) error,
+) (*domain.Stock, error) {
+	time.Sleep(time.Millisecond)
+	return s.InMemoryStockRepo.Update(ctx, bookID, change)
+}
+
+// TestOrderInteractor_ConcurrentTransitions ships and cancels the same orders concurrently through the in-memory
+// repositories: each order moves its stock once, whichever request wins.
+func TestOrderInteractor_ConcurrentTransitions(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	books, stocks := db.NewInMemoryBookRepo(logger), slowStocks{db.NewInMemoryStockRepo(logger)}
+	oi := interactor.NewOrderInteractor(logger, books, stocks, db.NewInMemoryOrderRepo(logger))
+	book := &domain.Book{Title: "Dune", Price: domain.Money{Amount: 1299, Currency: "EUR"}}
+	if err := books.Create(ctx, book); err != nil {
+		t.Fatal("failed to create book:", err)
+	}
+	if err := stocks.Create(ctx, &domain.Stock{BookID: book.ID, OnHand: 10}); err != nil {
+		t.Fatal("failed to create stock:", err)
+	}
+	orders := make([]*domain.Order, 5)
+	for i := range orders {
+		o, err := oi.PlaceOrder(ctx, []domain.OrderItem{{BookID: book.ID, Quantity: 2}})
+		if err != nil {
+			t.Fatal("failed to place order:", err)
+		}
+		if _, err = oi.PayOrder(ctx, o.ID.String()); err != nil {
+			t.Fatal("failed to pay order:", err)
+		}
+		orders[i] = o
+	}
+
+	var wg sync.WaitGroup
+	for _, o := range orders {
+		for _, op := range []func(*interactor.OrderInteractor, context.Context, string) (*domain.Order, error){
+			(*interactor.OrderInteractor).ShipOrder, (*interactor.OrderInteractor).CancelOrder,
+			(*interactor.OrderInteractor).ShipOrder, (*interactor.OrderInteractor).CancelOrder,
+		} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := op(oi, ctx, o.ID.String())
+				if err != nil && !errors.Is(err, domain.ErrIllegalOrderTransition) {
+					t.Errorf("unexpected error: %v", err)
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	shipped := 0
+	for _, o := range orders {
+		got, err := oi.GetOrder(ctx, o.ID.String())
+		if err != nil {
+			t.Fatal("failed to get order:", err)
+		}
+		if got.Status == domain.OrderShipped {
+			shipped++
+		}
+	}
+	stock, err := stocks.ReadByBookID(ctx, book.ID)
+	if err != nil {
+		t.Fatal("failed to read stock:", err)
+	}
+	if stock.OnHand != 10-2*shipped || stock.Reserved != 0 {
+		t.Errorf("stock = %d on hand, %d reserved, want %d on hand, none reserved", stock.OnHand, stock.Reserved,
+			10-2*shipped)
+	}
+}
@@ -0,0 +1,208 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+)
+
+// maxConcurrentWebhooks is how many webhooks DeliverDue delivers to at a time.
+const maxConcurrentWebhooks = 8
+
+// WebhookInteractor handles webhook subscriptions and the delivery of events to them.
+// Events are queued as deliveries when they are handled, and delivered later by DeliverDue,
+// so a slow or unreachable receiver never holds back the events of the others.
+type WebhookInteractor struct {
+	webhooks   domain.WebhookRepository
+	deliveries domain.DeliveryRepository
+	sender     domain.WebhookSender
+	logger     *slog.Logger
+	policy     domain.RetryPolicy
+}
+
+// NewWebhookInteractor creates a new WebhookInteractor, retrying failed deliveries following policy.
+func NewWebhookInteractor(
+	logger *slog.Logger,
+	webhooks domain.WebhookRepository,
+	deliveries domain.DeliveryRepository,
+	sender domain.WebhookSender,
+	policy domain.RetryPolicy,
+) *WebhookInteractor {
+	return &WebhookInteractor{
+		webhooks:   webhooks,
+		deliveries: deliveries,
+		sender:     sender,
+		logger:     logger,
+		policy:     policy,
+	}
+}
+
+// CreateWebhook sends the webhook to be created to the underlying repository.
+func (wi *WebhookInteractor) CreateWebhook(ctx context.Context, webhook *domain.Webhook) error {
+	return wi.webhooks.Create(ctx, webhook)
+}
+
+// GetWebhook retrieves a domain.Webhook by its ID.
+// Validates the given id is a valid UUID, bare or prefixed by its resource type.
+func (wi *WebhookInteractor) GetWebhook(ctx context.Context, id string) (*domain.Webhook, error) {
+	uid, err := domain.WebhookResource.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+	w, err := wi.webhooks.ReadByID(ctx, uid)
+	if db.IsWebhookNotFoundError(err) {
+		return nil, domain.ErrWebhookNotFound
+	}
+	return w, err
+}
+
+// ListWebhooks retrieves every webhook, oldest first.
+// Does not fail if nothing is found.
+func (wi *WebhookInteractor) ListWebhooks(ctx context.Context) ([]*domain.Webhook, error) {
+	return wi.webhooks.ReadAll(ctx)
+}
+
+// DeleteWebhook removes a webhook from the repository, together with its deliveries.
+// Deliveries still pending are never attempted.
+func (wi *WebhookInteractor) DeleteWebhook(ctx context.Context, id string) error {
+	uid, err := domain.WebhookResource.Parse(id)
+	if err != nil {
+		return err
+	}
+	err = wi.webhooks.Delete(ctx, uid)
+	if db.IsWebhookNotFoundError(err) {
+		return domain.ErrWebhookNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return wi.deliveries.DeleteByWebhook(ctx, uid)
+}
+
+// ListDeliveries retrieves every delivery to the webhook matching id, newest first, attempts included.
+func (wi *WebhookInteractor) ListDeliveries(ctx context.Context, id string) ([]*domain.Delivery, error) {
+	webhook, err := wi.GetWebhook(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return wi.deliveries.ReadByWebhook(ctx, webhook.ID)
+}
+
+// HandleEvent queues a delivery of event, due now, to every webhook accepting its type.
+// An event handled twice is queued once per webhook.
+func (wi *WebhookInteractor) HandleEvent(ctx context.Context, event *domain.Event) error {
+	webhooks, err := wi.webhooks.ReadAll(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	for _, w := range webhooks {
+		if !w.Accepts(event.Type) {
+			continue
+		}
+		delivery := &domain.Delivery{
+			NextAttemptAt: now,
+			Status:        domain.DeliveryPending,
+			Event:         *event,
+			WebhookID:     w.ID,
+		}
+		if err = wi.deliveries.Create(ctx, delivery); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeliverDue attempts up to limit due deliveries, earliest first, and returns how many were attempted.
+// The deliveries of each webhook are attempted in order up to the first failed one, the others being left
+// for the next call, while different webhooks are delivered to concurrently, up to maxConcurrentWebhooks
+// at a time: a hung receiver costs a single attempt, and doesn't hold back the deliveries to the others.
+// Failed attempts are retried with an exponential backoff, until the delivery is dead-lettered
+// after the number of attempts allowed by the retry policy.
+// Deliveries to webhooks deleted in the meantime are skipped.
+func (wi *WebhookInteractor) DeliverDue(ctx context.Context, limit int) (int, error) {
+	due, err := wi.deliveries.ReadDue(ctx, time.Now().UTC(), limit)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		order  []uuid.UUID
+		queues = map[uuid.UUID][]*domain.Delivery{}
+	)
+	for _, d := range due {
+		if _, ok := queues[d.WebhookID]; !ok {
+			order = append(order, d.WebhookID)
+		}
+		queues[d.WebhookID] = append(queues[d.WebhookID], d)
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		attempted int
+		errs      []error
+		slots     = make(chan struct{}, maxConcurrentWebhooks)
+	)
+	for _, id := range order {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			n, err := wi.deliver(ctx, id, queues[id])
+			mu.Lock()
+			defer mu.Unlock()
+			attempted += n
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return attempted, errors.Join(errs...)
+}
+
+// deliver attempts the deliveries to the webhook identified by id, in order, and returns how many were attempted.
+// It stops at the first failed attempt, leaving the following deliveries to the next call rather than sending
+// them ahead of the one to retry, and at the first error.
+func (wi *WebhookInteractor) deliver(ctx context.Context, id uuid.UUID, due []*domain.Delivery) (int, error) {
+	webhook, err := wi.webhooks.ReadByID(ctx, id)
+	if db.IsWebhookNotFoundError(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	attempted := 0
+	for _, d := range due {
+		attempt := wi.sender.Send(ctx, webhook, d)
+		d.Record(attempt, wi.policy)
+		attempted++
+		log := wi.logger.With("delivery_id", d.ID, "webhook_id", d.WebhookID, "event_id", d.Event.ID)
+		switch d.Status {
+		case domain.DeliveryDeadLettered:
+			log.With("error", attempt.Error, "attempts", len(d.Attempts)).Error("webhook delivery dead-lettered")
+		case domain.DeliveryPending:
+			log.With("error", attempt.Error, "next_attempt_at", d.NextAttemptAt).Warn("webhook delivery failed")
+		}
+
+		// The attempt was made already, it must be recorded even if the caller gave up.
+		err = wi.deliveries.Update(context.WithoutCancel(ctx), d)
+		if err != nil && !db.IsDeliveryNotFoundError(err) {
+			return attempted, err
+		}
+		if attempt.Failed() {
+			break
+		}
+	}
+	return attempted, nil
+}
@@ -0,0 +1,359 @@
+package interactor_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/domain"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/db"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/infrastructure/webhook"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/mocks"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/test/testlog"
+	"github.com/CanobbioE/strict-clean-arch-go-webservice/internal/usecase/interactor"
+)
+
+func TestWebhookInteractor_DeleteWebhook(t *testing.T) {
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+	webhookID := uuid.New()
+
+	tests := []struct {
+		name             string
+		id               string
+		wantErr          error
+		mockExpectations func(*mocks.MockWebhookRepository, *mocks.MockDeliveryRepository)
+	}{
+		{
+			name:    "fails to parse id",
+			id:      "invalid",
+			wantErr: domain.ErrInvalidWebhookID,
+		},
+		{
+			name: "fails with webhook not found error",
+			id:   webhookID.String(),
+			mockExpectations: func(webhooks *mocks.MockWebhookRepository, _ *mocks.MockDeliveryRepository) {
+				webhooks.EXPECT().Delete(ctx, webhookID).Return(errors.New("webhook not found"))
+			},
+			wantErr: domain.ErrWebhookNotFound,
+		},
+		{
+			name: "succeeds dropping the deliveries",
+			id:   webhookID.String(),
+			mockExpectations: func(webhooks *mocks.MockWebhookRepository, deliveries *mocks.MockDeliveryRepository) {
+				webhooks.EXPECT().Delete(ctx, webhookID).Return(nil)
+				deliveries.EXPECT().DeleteByWebhook(ctx, webhookID).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtl := gomock.NewController(t)
+			mockWebhookRepository := mocks.NewMockWebhookRepository(mockCtl)
+			mockDeliveryRepository := mocks.NewMockDeliveryRepository(mockCtl)
+			if tt.mockExpectations != nil {
+				tt.mockExpectations(mockWebhookRepository, mockDeliveryRepository)
+			}
+			wi := interactor.NewWebhookInteractor(
+				logger, mockWebhookRepository, mockDeliveryRepository,
+				mocks.NewMockWebhookSender(mockCtl), domain.RetryPolicy{},
+			)
+
+			err := wi.DeleteWebhook(ctx, tt.id)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("DeleteWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhookInteractor_ListDeliveries(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockWebhookRepository := mocks.NewMockWebhookRepository(mockCtl)
+	mockDeliveryRepository := mocks.NewMockDeliveryRepository(mockCtl)
+	ctx := context.Background()
+	wi := interactor.NewWebhookInteractor(
+		testlog.NewTestLogger(), mockWebhookRepository, mockDeliveryRepository,
+		mocks.NewMockWebhookSender(mockCtl), domain.RetryPolicy{},
+	)
+	missing, found := uuid.New(), &domain.Webhook{ID: uuid.New()}
+	deliveries := []*domain.Delivery{{ID: uuid.New(), WebhookID: found.ID}}
+
+	mockWebhookRepository.EXPECT().ReadByID(ctx, missing).Return(nil, errors.New("webhook not found"))
+	if _, err := wi.ListDeliveries(ctx, missing.String()); !errors.Is(err, domain.ErrWebhookNotFound) {
+		t.Errorf("ListDeliveries() expected webhook not found, got: %v", err)
+	}
+
+	mockWebhookRepository.EXPECT().ReadByID(ctx, found.ID).Return(found, nil)
+	mockDeliveryRepository.EXPECT().ReadByWebhook(ctx, found.ID).Return(deliveries, nil)
+	got, err := wi.ListDeliveries(ctx, found.ID.String())
+	if err != nil || len(got) != 1 || got[0] != deliveries[0] {
+		t.Errorf("ListDeliveries() got %v, %v", got, err)
+	}
+}
+
+func TestWebhookInteractor_HandleEvent(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockWebhookRepository := mocks.NewMockWebhookRepository(mockCtl)
+	mockDeliveryRepository := mocks.NewMockDeliveryRepository(mockCtl)
+	ctx := context.Background()
+	wi := interactor.NewWebhookInteractor(
+		testlog.NewTestLogger(), mockWebhookRepository, mockDeliveryRepository,
+		mocks.NewMockWebhookSender(mockCtl), domain.RetryPolicy{},
+	)
+	event := &domain.Event{ID: uuid.New(), Type: domain.EventBookDeleted, BookID: uuid.New()}
+	all := &domain.Webhook{ID: uuid.New()}
+	deletions := &domain.Webhook{ID: uuid.New(), Events: []domain.EventType{domain.EventBookDeleted}}
+	creations := &domain.Webhook{ID: uuid.New(), Events: []domain.EventType{domain.EventBookCreated}}
+
+	mockWebhookRepository.EXPECT().ReadAll(ctx).Return([]*domain.Webhook{all, deletions, creations}, nil)
+	for _, w := range []*domain.Webhook{all, deletions} {
+		mockDeliveryRepository.EXPECT().
+			Create(ctx, gomock.Cond(func(d *domain.Delivery) bool {
+				return d.WebhookID == w.ID && d.Event == *event &&
+					d.Status == domain.DeliveryPending && !d.NextAttemptAt.IsZero()
+			})).
+			Return(nil)
+	}
+
+	if err := wi.HandleEvent(ctx, event); err != nil {
+		t.Errorf("HandleEvent() unexpected error: %v", err)
+	}
+}
+
+func TestWebhookInteractor_DeliverDue(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockWebhookRepository := mocks.NewMockWebhookRepository(mockCtl)
+	mockDeliveryRepository := mocks.NewMockDeliveryRepository(mockCtl)
+	mockWebhookSender := mocks.NewMockWebhookSender(mockCtl)
+	ctx := context.Background()
+	policy := domain.RetryPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Hour, MaxAttempts: 2}
+	wi := interactor.NewWebhookInteractor(
+		testlog.NewTestLogger(), mockWebhookRepository, mockDeliveryRepository, mockWebhookSender, policy,
+	)
+	at := time.Now().UTC()
+	failure := domain.DeliveryAttempt{At: at, Error: "unexpected status 500", StatusCode: 500}
+	receiver := &domain.Webhook{ID: uuid.New(), URL: "https://example.com/hook"}
+	other := &domain.Webhook{ID: uuid.New(), URL: "https://example.org/hook"}
+	orphan := &domain.Delivery{ID: uuid.New(), Status: domain.DeliveryPending, WebhookID: uuid.New()}
+	first := &domain.Delivery{ID: uuid.New(), Status: domain.DeliveryPending, WebhookID: receiver.ID}
+	retried := &domain.Delivery{
+		ID: uuid.New(), Status: domain.DeliveryPending, WebhookID: other.ID, Attempts: []domain.DeliveryAttempt{failure},
+	}
+
+	mockDeliveryRepository.EXPECT().
+		ReadDue(ctx, gomock.Any(), 10).
+		Return([]*domain.Delivery{orphan, first, retried}, nil)
+	mockWebhookRepository.EXPECT().ReadByID(ctx, orphan.WebhookID).Return(nil, errors.New("webhook not found"))
+	mockWebhookRepository.EXPECT().ReadByID(ctx, receiver.ID).Return(receiver, nil)
+	mockWebhookRepository.EXPECT().ReadByID(ctx, other.ID).Return(other, nil)
+	mockWebhookSender.EXPECT().Send(ctx, receiver, first).Return(failure)
+	mockWebhookSender.EXPECT().Send(ctx, other, retried).Return(failure)
+	mockDeliveryRepository.EXPECT().
+		Update(gomock.Any(), gomock.Cond(func(d *domain.Delivery) bool {
+			return d.ID == first.ID && d.Status == domain.DeliveryPending && d.NextAttemptAt.Equal(at.Add(time.Minute))
+		})).
+		Return(nil)
+	mockDeliveryRepository.EXPECT().
+		Update(gomock.Any(), gomock.Cond(func(d *domain.Delivery) bool {
+			return d.ID == retried.ID && d.Status == domain.DeliveryDeadLettered && len(d.Attempts) == 2
+		})).
+		Return(nil)
+
+	n, err := wi.DeliverDue(ctx, 10)
+	if err != nil || n != 2 {
+		t.Errorf("DeliverDue() = %d, %v, want 2 attempts", n, err)
+	}
+}
+
+func TestWebhookInteractor_DeliverDue_HungReceiver(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockWebhookRepository := mocks.NewMockWebhookRepository(mockCtl)
+	mockDeliveryRepository := mocks.NewMockDeliveryRepository(mockCtl)
+	mockWebhookSender := mocks.NewMockWebhookSender(mockCtl)
+	ctx := context.Background()
+	wi := interactor.NewWebhookInteractor(
+		testlog.NewTestLogger(), mockWebhookRepository, mockDeliveryRepository, mockWebhookSender,
+		domain.RetryPolicy{MaxAttempts: 1},
+	)
+	hung := &domain.Webhook{ID: uuid.New()}
+	healthy := &domain.Webhook{ID: uuid.New()}
+	stuck := &domain.Delivery{ID: uuid.New(), Status: domain.DeliveryPending, WebhookID: hung.ID}
+	delivered := &domain.Delivery{ID: uuid.New(), Status: domain.DeliveryPending, WebhookID: healthy.ID}
+
+	release, updated := make(chan struct{}), make(chan struct{})
+	mockDeliveryRepository.EXPECT().ReadDue(ctx, gomock.Any(), 10).Return([]*domain.Delivery{stuck, delivered}, nil)
+	mockWebhookRepository.EXPECT().ReadByID(ctx, hung.ID).Return(hung, nil)
+	mockWebhookRepository.EXPECT().ReadByID(ctx, healthy.ID).Return(healthy, nil)
+	mockWebhookSender.EXPECT().Send(ctx, hung, stuck).DoAndReturn(
+		func(context.Context, *domain.Webhook, *domain.Delivery) domain.DeliveryAttempt {
+			<-release
+			return domain.DeliveryAttempt{Error: "timeout"}
+		})
+	mockWebhookSender.EXPECT().Send(ctx, healthy, delivered).Return(domain.DeliveryAttempt{StatusCode: 200})
+	mockDeliveryRepository.EXPECT().Update(gomock.Any(), stuck).Return(nil)
+	mockDeliveryRepository.EXPECT().Update(gomock.Any(), delivered).DoAndReturn(
+		func(context.Context, *domain.Delivery) error {
+			close(updated)
+			return nil
+		})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if n, err := wi.DeliverDue(ctx, 10); err != nil || n != 2 {
+			t.Errorf("DeliverDue() = %d, %v, want 2 attempts", n, err)
+		}
+	}()
+	select {
+	case <-updated:
+	case <-time.After(5 * time.Second):
+		t.Error("expected the healthy receiver to be delivered to while the other one hangs")
+	}
+	close(release)
+	<-done
+}
+
+func TestWebhookInteractor_DeliverDue_StopsAtFirstFailure(t *testing.T) {
+	mockCtl := gomock.NewController(t)
+	mockWebhookRepository := mocks.NewMockWebhookRepository(mockCtl)
+	mockDeliveryRepository := mocks.NewMockDeliveryRepository(mockCtl)
+	mockWebhookSender := mocks.NewMockWebhookSender(mockCtl)
+	ctx := context.Background()
+	wi := interactor.NewWebhookInteractor(
+		testlog.NewTestLogger(), mockWebhookRepository, mockDeliveryRepository, mockWebhookSender,
+		domain.RetryPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Hour, MaxAttempts: 3},
+	)
+	failing := &domain.Webhook{ID: uuid.New()}
+	healthy := &domain.Webhook{ID: uuid.New()}
+	var due []*domain.Delivery
+	for range 3 {
+		for _, w := range []*domain.Webhook{failing, healthy} {
+			due = append(due, &domain.Delivery{ID: uuid.New(), Status: domain.DeliveryPending, WebhookID: w.ID})
+		}
+	}
+
+	var (
+		mu   sync.Mutex
+		sent = map[uuid.UUID][]uuid.UUID{}
+	)
+	mockDeliveryRepository.EXPECT().ReadDue(ctx, gomock.Any(), 10).Return(due, nil)
+	mockWebhookRepository.EXPECT().ReadByID(ctx, failing.ID).Return(failing, nil)
+	mockWebhookRepository.EXPECT().ReadByID(ctx, healthy.ID).Return(healthy, nil)
+	mockWebhookSender.EXPECT().
+		Send(ctx, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, w *domain.Webhook, d *domain.Delivery) domain.DeliveryAttempt {
+			mu.Lock()
+			defer mu.Unlock()
+			sent[w.ID] = append(sent[w.ID], d.ID)
+			if w == failing {
+				return domain.DeliveryAttempt{At: time.Now().UTC(), Error: "timeout"}
+			}
+			return domain.DeliveryAttempt{At: time.Now().UTC(), StatusCode: http.StatusOK}
+		}).
+		Times(4)
+	mockDeliveryRepository.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil).Times(4)
+
+	n, err := wi.DeliverDue(ctx, 10)
+	if err != nil || n != 4 {
+		t.Errorf("DeliverDue() = %d, %v, want 4 attempts", n, err)
+	}
+	if want := []uuid.UUID{due[0].ID}; !slices.Equal(sent[failing.ID], want) {
+		t.Errorf("sent %v to the failing webhook, want only its first delivery %v", sent[failing.ID], want)
+	}
+	if want := []uuid.UUID{due[1].ID, due[3].ID, due[5].ID}; !slices.Equal(sent[healthy.ID], want) {
+		t.Errorf("sent %v to the healthy webhook, want %v in order", sent[healthy.ID], want)
+	}
+	if due[2].Status != domain.DeliveryPending || len(due[2].Attempts) != 0 {
+		t.Errorf("expected the deliveries after the failed one to be left for later, got %+v", due[2])
+	}
+}
+
+// TestWebhookInteractor_EndToEnd delivers events to httptest receivers through the in-memory repositories
+// and the HTTP sender: a flaky receiver gets its delivery after a retry, a broken one has it dead-lettered.
+func TestWebhookInteractor_EndToEnd(t *testing.T) {
+	const secret = "0123456789abcdef"
+	logger := testlog.NewTestLogger()
+	ctx := context.Background()
+
+	var flakyCalls, brokenCalls atomic.Int32
+	flaky := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(webhook.SignatureHeader) == "" {
+			t.Error("expected a signed delivery")
+		}
+		if flakyCalls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer flaky.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		brokenCalls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+
+	wi := interactor.NewWebhookInteractor(
+		logger,
+		db.NewInMemoryWebhookRepo(logger),
+		db.NewInMemoryDeliveryRepo(logger),
+		webhook.NewHTTPSender(logger, time.Second),
+		domain.RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond, MaxAttempts: 3},
+	)
+	flakyHook := &domain.Webhook{URL: flaky.URL, Secret: secret}
+	brokenHook := &domain.Webhook{URL: broken.URL, Secret: secret}
+	for _, w := range []*domain.Webhook{flakyHook, brokenHook} {
+		if err := wi.CreateWebhook(ctx, w); err != nil {
+			t.Fatalf("error creating webhook: %v", err)
+		}
+	}
+
+	event := &domain.Event{At: time.Now().UTC(), Type: domain.EventBookCreated, ID: uuid.New(), BookID: uuid.New()}
+	for range 2 {
+		// The event is handled twice, as after a redelivery by the dispatcher, and delivered once.
+		if err := wi.HandleEvent(ctx, event); err != nil {
+			t.Fatalf("HandleEvent() unexpected error: %v", err)
+		}
+	}
+	for range 5 {
+		if _, err := wi.DeliverDue(ctx, 10); err != nil {
+			t.Fatalf("DeliverDue() unexpected error: %v", err)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := flakyCalls.Load(); got != 2 {
+		t.Errorf("expected the flaky receiver to be called twice, got %d", got)
+	}
+	if got := brokenCalls.Load(); got != 3 {
+		t.Errorf("expected the broken receiver to be called 3 times, got %d", got)
+	}
+	for _, tc := range []struct {
+		webhook  *domain.Webhook
+		status   domain.DeliveryStatus
+		attempts int
+	}{
+		{webhook: flakyHook, status: domain.DeliverySucceeded, attempts: 2},
+		{webhook: brokenHook, status: domain.DeliveryDeadLettered, attempts: 3},
+	} {
+		deliveries, err := wi.ListDeliveries(ctx, tc.webhook.ID.String())
+		if err != nil {
+			t.Fatalf("ListDeliveries() unexpected error: %v", err)
+		}
+		if len(deliveries) != 1 {
+			t.Fatalf("expected a single delivery to %s, got %d", tc.webhook.URL, len(deliveries))
+		}
+		d := deliveries[0]
+		if d.Status != tc.status || len(d.Attempts) != tc.attempts || !d.NextAttemptAt.IsZero() {
+			t.Errorf("expected a %s delivery after %d attempts, got %+v", tc.status, tc.attempts, d)
+		}
+	}
+}